@@ -18,7 +18,7 @@ func dbOpen(port string) (*gorm.DB, error) {
 		"host=%s user=%s password=%s dbname=%s port=%s sslmode=disable TimeZone=Asia/Jakarta",
 		os.Getenv("DB_HOST"), os.Getenv("DB_USER"), os.Getenv("DB_PASSWORD"), os.Getenv("DB_NAME"), port)
 
-	return gorm.Open(postgres.Open(dsn), &gorm.Config{})
+	return gorm.Open(postgres.Open(dsn), &gorm.Config{TranslateError: true})
 }
 
 func SetupDB(port string) *gorm.DB {
@@ -28,10 +28,40 @@ func SetupDB(port string) *gorm.DB {
 	}
 	log.Printf("DB connection established: %+v\n", DB)
 	DB.AutoMigrate(&models.Auth{})
+	if err := DedupeArticleSlugs(DB); err != nil {
+		log.Fatalf("Error deduplicating article slugs: %+v\n", err)
+	}
 	DB.AutoMigrate(&models.Article{})
+	DB.AutoMigrate(&models.ArticleSlugRedirect{})
 	DB.AutoMigrate(&models.ArticleTag{})
 	DB.AutoMigrate(&models.Tag{})
 	DB.AutoMigrate(&models.TagTrendingScore{})
-	DB.AutoMigrate(&models.ArticleHistory{})
+	DB.AutoMigrate(&models.ArticleRelation{})
+	if err := DedupeArticleHistoryVersions(DB); err != nil {
+		log.Fatalf("Error deduplicating article history versions: %+v\n", err)
+	}
+	if err := DB.AutoMigrate(&models.ArticleHistory{}); err != nil {
+		log.Fatalf("Error migrating article histories: %+v\n", err)
+	}
+	DB.AutoMigrate(&models.ArticleLock{})
+	if err := SyncArticleVersions(DB); err != nil {
+		log.Fatalf("Error syncing article versions: %+v\n", err)
+	}
+	DB.AutoMigrate(&models.RefreshToken{})
+	DB.AutoMigrate(&models.RevokedToken{})
+	DB.AutoMigrate(&models.Role{})
+	DB.AutoMigrate(&models.RolePermission{})
+	if err := SetupSearch(DB); err != nil {
+		log.Fatalf("Error setting up search: %+v\n", err)
+	}
+	if err := SetupTagSearch(DB); err != nil {
+		log.Fatalf("Error setting up tag search: %+v\n", err)
+	}
+	if err := SeedRoles(DB); err != nil {
+		log.Fatalf("Error seeding roles: %+v\n", err)
+	}
+	if err := SeedAdmin(DB); err != nil {
+		log.Fatalf("Error seeding admin: %+v\n", err)
+	}
 	return DB
 }
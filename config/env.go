@@ -1,6 +1,11 @@
 package config
 
 import (
+	"log"
+	"os"
+	"strconv"
+	"time"
+
 	"github.com/joho/godotenv"
 )
 
@@ -10,3 +15,42 @@ func LoadEnv(file string) {
 		panic("Error loading .env file")
 	}
 }
+
+// GetDuration reads a duration (e.g. "15m", "720h") from env, falling back when it is empty or invalid
+func GetDuration(key string, fallback time.Duration) time.Duration {
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		log.Printf("Invalid duration for %s: %+v\n", key, err.Error())
+		return fallback
+	}
+	return d
+}
+
+// GetPositiveDuration reads a duration like GetDuration, also falling back when it is zero or negative.
+// It is meant for intervals and lifetimes, which cannot be zero.
+func GetPositiveDuration(key string, fallback time.Duration) time.Duration {
+	d := GetDuration(key, fallback)
+	if d <= 0 {
+		log.Printf("Invalid duration for %s: %s is not positive\n", key, d)
+		return fallback
+	}
+	return d
+}
+
+// GetBool reads a boolean (e.g. "true", "1", "false") from env, falling back when it is empty or invalid
+func GetBool(key string, fallback bool) bool {
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback
+	}
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		log.Printf("Invalid boolean for %s: %+v\n", key, err.Error())
+		return fallback
+	}
+	return b
+}
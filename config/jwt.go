@@ -0,0 +1,202 @@
+package config
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"os"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/herdiansc/go-cms/models"
+)
+
+// SigningKey holds a JWT key identified by kid. SignKey is nil for verification-only keys.
+type SigningKey struct {
+	ID        string
+	Method    jwt.SigningMethod
+	SignKey   any
+	VerifyKey any
+}
+
+// KeySet holds the key used for signing and every key accepted for verification
+type KeySet struct {
+	Active SigningKey
+	keys   map[string]SigningKey
+}
+
+// NewKeySet inits KeySet, activeKid must refer to a key able to sign
+func NewKeySet(activeKid string, keys ...SigningKey) (KeySet, error) {
+	ks := KeySet{keys: make(map[string]SigningKey)}
+	for _, key := range keys {
+		if _, ok := ks.keys[key.ID]; ok {
+			return KeySet{}, fmt.Errorf("duplicate kid %q", key.ID)
+		}
+		ks.keys[key.ID] = key
+	}
+	active, ok := ks.keys[activeKid]
+	if !ok {
+		return KeySet{}, fmt.Errorf("active kid %q is not configured", activeKid)
+	}
+	if active.SignKey == nil {
+		return KeySet{}, fmt.Errorf("active kid %q has no private key", activeKid)
+	}
+	ks.Active = active
+	return ks, nil
+}
+
+// Lookup finds a verification key by kid
+func (ks KeySet) Lookup(kid string) (SigningKey, bool) {
+	key, ok := ks.keys[kid]
+	return key, ok
+}
+
+// Algorithms lists the algorithms of every verification key
+func (ks KeySet) Algorithms() []string {
+	var algs []string
+	seen := make(map[string]bool)
+	for _, key := range ks.keys {
+		alg := key.Method.Alg()
+		if !seen[alg] {
+			seen[alg] = true
+			algs = append(algs, alg)
+		}
+	}
+	return algs
+}
+
+// JWKS publishes the public part of every asymmetric key. Shared secrets are never published.
+func (ks KeySet) JWKS() models.JWKSet {
+	set := models.JWKSet{Keys: []models.JWK{}}
+	for _, key := range ks.keys {
+		jwk := models.JWK{Kid: key.ID, Use: "sig", Alg: key.Method.Alg()}
+		switch pub := key.VerifyKey.(type) {
+		case *rsa.PublicKey:
+			jwk.Kty = "RSA"
+			jwk.N = base64.RawURLEncoding.EncodeToString(pub.N.Bytes())
+			jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes())
+		case *ecdsa.PublicKey:
+			size := (pub.Curve.Params().BitSize + 7) / 8
+			jwk.Kty = "EC"
+			jwk.Crv = pub.Curve.Params().Name
+			jwk.X = base64.RawURLEncoding.EncodeToString(pub.X.FillBytes(make([]byte, size)))
+			jwk.Y = base64.RawURLEncoding.EncodeToString(pub.Y.FillBytes(make([]byte, size)))
+		case ed25519.PublicKey:
+			jwk.Kty = "OKP"
+			jwk.Crv = "Ed25519"
+			jwk.X = base64.RawURLEncoding.EncodeToString(pub)
+		default:
+			continue
+		}
+		set.Keys = append(set.Keys, jwk)
+	}
+	return set
+}
+
+// ParseSigningKey builds a SigningKey from a shared secret (HS256) or a PEM encoded private/public key
+func ParseSigningKey(kid, alg string, data []byte) (SigningKey, error) {
+	key := SigningKey{ID: kid, Method: jwt.GetSigningMethod(alg)}
+	if key.Method == nil {
+		return SigningKey{}, fmt.Errorf("kid %q: unsupported algorithm %q", kid, alg)
+	}
+
+	switch alg {
+	case jwt.SigningMethodHS256.Alg():
+		secret := []byte(strings.TrimSpace(string(data)))
+		if len(secret) < 32 {
+			return SigningKey{}, fmt.Errorf("kid %q: HS256 secret must be at least 32 bytes", kid)
+		}
+		key.SignKey, key.VerifyKey = secret, secret
+	case jwt.SigningMethodRS256.Alg():
+		if private, err := jwt.ParseRSAPrivateKeyFromPEM(data); err == nil {
+			key.SignKey, key.VerifyKey = private, &private.PublicKey
+		} else if public, err := jwt.ParseRSAPublicKeyFromPEM(data); err == nil {
+			key.VerifyKey = public
+		} else {
+			return SigningKey{}, fmt.Errorf("kid %q: invalid RSA key: %w", kid, err)
+		}
+	case jwt.SigningMethodES256.Alg():
+		if private, err := jwt.ParseECPrivateKeyFromPEM(data); err == nil {
+			key.SignKey, key.VerifyKey = private, &private.PublicKey
+		} else if public, err := jwt.ParseECPublicKeyFromPEM(data); err == nil {
+			key.VerifyKey = public
+		} else {
+			return SigningKey{}, fmt.Errorf("kid %q: invalid EC key: %w", kid, err)
+		}
+		if key.VerifyKey.(*ecdsa.PublicKey).Curve != elliptic.P256() {
+			return SigningKey{}, fmt.Errorf("kid %q: ES256 requires a P-256 key", kid)
+		}
+	case jwt.SigningMethodEdDSA.Alg():
+		if private, err := jwt.ParseEdPrivateKeyFromPEM(data); err == nil {
+			key.SignKey, key.VerifyKey = private, private.(ed25519.PrivateKey).Public()
+		} else if public, err := jwt.ParseEdPublicKeyFromPEM(data); err == nil {
+			key.VerifyKey = public
+		} else {
+			return SigningKey{}, fmt.Errorf("kid %q: invalid Ed25519 key: %w", kid, err)
+		}
+	default:
+		return SigningKey{}, fmt.Errorf("kid %q: unsupported algorithm %q", kid, alg)
+	}
+
+	return key, nil
+}
+
+// LoadKeySet loads keys from env.
+//
+// JWT_KEYS is a comma separated list of kid:ALG:path entries, the file holding either the HS256 secret
+// or a PEM key. Keys with only a public key are accepted for verification, which allows retiring a key
+// without logging everyone out. JWT_ACTIVE_KID selects the signing key and defaults to the first entry.
+// When JWT_KEYS is empty JWT_SECRET is used as a single HS256 key.
+func LoadKeySet() (KeySet, error) {
+	entries := strings.TrimSpace(os.Getenv("JWT_KEYS"))
+	if entries == "" {
+		secret := os.Getenv("JWT_SECRET")
+		if secret == "" {
+			return KeySet{}, errors.New("either JWT_KEYS or JWT_SECRET must be set")
+		}
+		key, err := ParseSigningKey("default", jwt.SigningMethodHS256.Alg(), []byte(secret))
+		if err != nil {
+			return KeySet{}, err
+		}
+		return NewKeySet(key.ID, key)
+	}
+
+	var keys []SigningKey
+	for _, entry := range strings.Split(entries, ",") {
+		parts := strings.SplitN(strings.TrimSpace(entry), ":", 3)
+		if len(parts) != 3 {
+			return KeySet{}, fmt.Errorf("invalid JWT_KEYS entry %q, expected kid:ALG:path", entry)
+		}
+		data, err := os.ReadFile(parts[2])
+		if err != nil {
+			return KeySet{}, fmt.Errorf("kid %q: %w", parts[0], err)
+		}
+		key, err := ParseSigningKey(parts[0], parts[1], data)
+		if err != nil {
+			return KeySet{}, err
+		}
+		keys = append(keys, key)
+	}
+
+	activeKid := os.Getenv("JWT_ACTIVE_KID")
+	if activeKid == "" {
+		activeKid = keys[0].ID
+	}
+	return NewKeySet(activeKid, keys...)
+}
+
+// SetupKeySet loads the JWT keys and stops the service when they are misconfigured
+func SetupKeySet() KeySet {
+	keys, err := LoadKeySet()
+	if err != nil {
+		log.Fatalf("Error loading JWT keys: %+v\n", err)
+	}
+	log.Printf("JWT keys loaded, signing with kid %s (%s)\n", keys.Active.ID, keys.Active.Method.Alg())
+	return keys
+}
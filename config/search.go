@@ -0,0 +1,78 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// defaultSearchLanguage is the text search configuration used when SEARCH_LANGUAGE is empty
+const defaultSearchLanguage = "english"
+
+var validSearchLanguage = regexp.MustCompile(`^[a-z_]+$`)
+
+// SearchLanguage returns the Postgres text search configuration (e.g. "english", "indonesian", "simple")
+// used to stem articles and search queries, read from SEARCH_LANGUAGE
+func SearchLanguage() string {
+	language := strings.ToLower(os.Getenv("SEARCH_LANGUAGE"))
+	if !validSearchLanguage.MatchString(language) {
+		return defaultSearchLanguage
+	}
+	return language
+}
+
+// SetupSearch adds the weighted search_vector column, title above content, and its GIN index to articles.
+// The column is generated with the configured language, so it is rebuilt when SEARCH_LANGUAGE changes.
+func SetupSearch(DB *gorm.DB) error {
+	language := SearchLanguage()
+
+	var count int64
+	if err := DB.Raw("SELECT count(*) FROM pg_ts_config WHERE cfgname = ?", language).Scan(&count).Error; err != nil {
+		return err
+	}
+	if count == 0 {
+		return fmt.Errorf("unknown text search configuration %q", language)
+	}
+
+	var expression string
+	err := DB.Raw(`SELECT coalesce(generation_expression, '') FROM information_schema.columns
+		WHERE table_name = 'articles' AND column_name = 'search_vector'`).Scan(&expression).Error
+	if err != nil {
+		return err
+	}
+
+	return DB.Transaction(func(tx *gorm.DB) error {
+		if expression != "" && !strings.Contains(expression, fmt.Sprintf("'%s'::regconfig", language)) {
+			if err := tx.Exec("ALTER TABLE articles DROP COLUMN search_vector").Error; err != nil {
+				return err
+			}
+		}
+		err := tx.Exec(fmt.Sprintf(`ALTER TABLE articles ADD COLUMN IF NOT EXISTS search_vector tsvector
+			GENERATED ALWAYS AS (
+				setweight(to_tsvector('%[1]s', coalesce(title, '')), 'A') ||
+				setweight(to_tsvector('%[1]s', coalesce(content, '')), 'B')
+			) STORED`, language)).Error
+		if err != nil {
+			return err
+		}
+		return tx.Exec("CREATE INDEX IF NOT EXISTS idx_articles_search_vector ON articles USING GIN (search_vector)").Error
+	})
+}
+
+// SetupTagSearch enables the pg_trgm and fuzzystrmatch extensions tag suggestions rely on and adds a
+// trigram index on tag titles
+func SetupTagSearch(DB *gorm.DB) error {
+	for _, sql := range []string{
+		"CREATE EXTENSION IF NOT EXISTS pg_trgm",
+		"CREATE EXTENSION IF NOT EXISTS fuzzystrmatch",
+		"CREATE INDEX IF NOT EXISTS idx_tags_title_trgm ON tags USING GIN (title gin_trgm_ops)",
+	} {
+		if err := DB.Exec(sql).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+package config
+
+import (
+	"log"
+	"os"
+
+	"github.com/herdiansc/go-cms/models"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+// SeedRoles creates the default roles and grants their default permissions.
+// Existing roles and extra permissions granted by an operator are kept.
+func SeedRoles(DB *gorm.DB) error {
+	return DB.Transaction(func(tx *gorm.DB) error {
+		for _, defaultRole := range models.DefaultRoles {
+			role := models.Role{}
+			result := tx.Where(models.Role{Name: defaultRole.Name}).
+				Attrs(models.Role{Title: defaultRole.Title}).
+				FirstOrCreate(&role)
+			if result.Error != nil {
+				return result.Error
+			}
+			for _, permission := range models.DefaultRolePermissions[role.Name] {
+				result = tx.Where(models.RolePermission{RoleID: role.ID, Permission: permission}).
+					FirstOrCreate(&models.RolePermission{})
+				if result.Error != nil {
+					return result.Error
+				}
+			}
+		}
+		return nil
+	})
+}
+
+// SeedAdmin creates the ADMIN user configured by ADMIN_USERNAME and ADMIN_PASSWORD when it does not exist yet
+func SeedAdmin(DB *gorm.DB) error {
+	username, password := os.Getenv("ADMIN_USERNAME"), os.Getenv("ADMIN_PASSWORD")
+	if username == "" || password == "" {
+		return nil
+	}
+
+	var count int64
+	if err := DB.Model(&models.Auth{}).Where("username = ?", username).Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return nil
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), 14)
+	if err != nil {
+		return err
+	}
+	log.Printf("Creating admin user %s\n", username)
+	return DB.Create(&models.Auth{
+		Username: username,
+		Password: string(hash),
+		RoleName: models.RoleAdmin,
+	}).Error
+}
@@ -0,0 +1,17 @@
+package config
+
+import (
+	"github.com/herdiansc/go-cms/models"
+	"gorm.io/gorm"
+)
+
+// DedupeArticleSlugs suffixes the slugs shared by several articles with their id, keeping the oldest
+// article's slug, so the unique index on slugs can be created on databases made before slugs were unique.
+// It must run before articles are migrated.
+func DedupeArticleSlugs(DB *gorm.DB) error {
+	if !DB.Migrator().HasTable(&models.Article{}) {
+		return nil
+	}
+	return DB.Exec(`UPDATE articles SET slug = CASE WHEN slug = '' THEN 'article' ELSE slug END || '-' || id
+		WHERE id NOT IN (SELECT min(id) FROM articles GROUP BY slug)`).Error
+}
@@ -0,0 +1,36 @@
+package config
+
+import (
+	"log"
+	"os"
+	"strings"
+
+	"github.com/herdiansc/go-cms/models"
+)
+
+// defaultTrendingWindows are the trending windows used when TRENDING_WINDOWS is empty
+const defaultTrendingWindows = "7d,1d,30d"
+
+// TrendingWindows returns the windows tag trending scores are computed over, read from the comma
+// separated TRENDING_WINDOWS. The first one is the default window of the trending endpoint.
+func TrendingWindows() []models.TrendingWindow {
+	value := os.Getenv("TRENDING_WINDOWS")
+	if value == "" {
+		value = defaultTrendingWindows
+	}
+
+	var windows []models.TrendingWindow
+	for _, name := range strings.Split(value, ",") {
+		window, err := models.ParseTrendingWindow(strings.TrimSpace(name))
+		if err != nil {
+			log.Printf("Invalid TRENDING_WINDOWS: %+v\n", err.Error())
+			continue
+		}
+		windows = append(windows, window)
+	}
+	if len(windows) == 0 {
+		window, _ := models.ParseTrendingWindow("7d")
+		windows = append(windows, window)
+	}
+	return windows
+}
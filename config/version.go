@@ -0,0 +1,29 @@
+package config
+
+import (
+	"github.com/herdiansc/go-cms/models"
+	"gorm.io/gorm"
+)
+
+// DedupeArticleHistoryVersions renumbers the histories of articles holding several histories with the
+// same version, in version and creation order, so the unique index on article and version can be created
+// on databases made before history versions were unique. It must run before histories are migrated.
+func DedupeArticleHistoryVersions(DB *gorm.DB) error {
+	if !DB.Migrator().HasTable(&models.ArticleHistory{}) {
+		return nil
+	}
+	return DB.Exec(`UPDATE article_histories SET version = renumbered.version
+		FROM (SELECT id, row_number() OVER (PARTITION BY article_id ORDER BY version, created_at, id) AS version
+			FROM article_histories
+			WHERE article_id IN (SELECT article_id FROM article_histories GROUP BY article_id, version HAVING count(*) > 1)
+		) AS renumbered
+		WHERE renumbered.id = article_histories.id AND article_histories.version <> renumbered.version`).Error
+}
+
+// SyncArticleVersions sets the version of articles to the version of their latest history, on databases
+// made before articles carried their version. It must run after articles and histories are migrated.
+func SyncArticleVersions(DB *gorm.DB) error {
+	return DB.Exec(`UPDATE articles SET version = latest.version
+		FROM (SELECT article_id, max(version) AS version FROM article_histories GROUP BY article_id) AS latest
+		WHERE latest.article_id = articles.id AND articles.version < latest.version`).Error
+}
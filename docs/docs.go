@@ -15,7 +15,28 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/article-histories/{id}": {
+        "/.well-known/jwks.json": {
+            "get": {
+                "description": "Get the JSON Web Key Set of every asymmetric key accepted for verification, so other services can verify tokens without sharing a secret",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Get public keys used to verify issued tokens",
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "$ref": "#/definitions/models.JWKSet"
+                        }
+                    }
+                },
+                "x-order": 6
+            }
+        },
+        "/article-histories/{uuid}": {
             "get": {
                 "description": "details an article history from the database",
                 "consumes": [
@@ -37,9 +58,9 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "type": "integer",
-                        "description": "ID of article history",
-                        "name": "id",
+                        "type": "string",
+                        "description": "UUID of article history",
+                        "name": "uuid",
                         "in": "path",
                         "required": true
                     }
@@ -68,7 +89,7 @@ const docTemplate = `{
         },
         "/articles": {
             "get": {
-                "description": "lists articles from the database",
+                "description": "lists articles from the database. Filter by uuid, title, status, slug, writer or writer_id (uuid of a user), created_at, updated_at, publish_at or unpublish_at with an optional operator, e.g. status=in:DRAFT,PUBLISHED, created_at=gte:2026-01-01 or title=like:go, and by tag, category, created_from and created_to. Each article carries its tags and the uuid of its writer. Sort by title, status, created_at, updated_at, publish_at or unpublish_at. Invalid params are a bad request",
                 "consumes": [
                     "application/json"
                 ],
@@ -103,7 +124,7 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "default": "id",
+                        "default": "created_at",
                         "description": "order field",
                         "name": "orderField",
                         "in": "query"
@@ -114,6 +135,60 @@ const docTemplate = `{
                         "description": "order dir",
                         "name": "orderDir",
                         "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor of the previous page, empty for the first page, switches to cursor pagination",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "only articles having this tag, can be repeated to require each",
+                        "name": "tag",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "only articles having any of these comma separated tags",
+                        "name": "tags_any",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "only articles having all of these comma separated tags",
+                        "name": "tags_all",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "only articles having this tag or one of its descendants, can be repeated to require each",
+                        "name": "category",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "only articles written by the user of this uuid",
+                        "name": "writer",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "same as writer",
+                        "name": "writer_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "only articles created at or after this date or RFC 3339 time",
+                        "name": "created_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "only articles created before this date or RFC 3339 time",
+                        "name": "created_to",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -138,7 +213,7 @@ const docTemplate = `{
                 }
             },
             "post": {
-                "description": "creates new article and saves it to the database",
+                "description": "creates new article as DRAFT and saves it to the database. Tags not existing yet are created, the response warns about those looking like existing tags",
                 "consumes": [
                     "application/json"
                 ],
@@ -189,9 +264,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/articles/{id}": {
+        "/articles/by-slug/{slug}": {
             "get": {
-                "description": "details an article from the database",
+                "description": "details an article by its slug. A former slug of a published article redirects permanently to its current slug",
                 "consumes": [
                     "application/json"
                 ],
@@ -201,7 +276,7 @@ const docTemplate = `{
                 "tags": [
                     "article"
                 ],
-                "summary": "details an article",
+                "summary": "details an article by slug",
                 "parameters": [
                     {
                         "type": "string",
@@ -211,9 +286,9 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "type": "integer",
-                        "description": "ID of article",
-                        "name": "id",
+                        "type": "string",
+                        "description": "slug of article",
+                        "name": "slug",
                         "in": "path",
                         "required": true
                     }
@@ -225,22 +300,30 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.Response"
                         }
                     },
+                    "301": {
+                        "description": "moved to the slug in Location",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
                     "400": {
                         "description": "bad request",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
                     },
-                    "500": {
-                        "description": "internal server error",
+                    "404": {
+                        "description": "not found",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "deletes an article from the database",
+            }
+        },
+        "/articles/search": {
+            "get": {
+                "description": "full-text search over title and content, title matches rank higher. q supports quoted phrases, OR and -word. Results are ranked by relevance and carry a headline snippet, escaped as HTML, with matches wrapped in \u003cmark\u003e. Other params paginate and filter like the list endpoint",
                 "consumes": [
                     "application/json"
                 ],
@@ -250,7 +333,7 @@ const docTemplate = `{
                 "tags": [
                     "article"
                 ],
-                "summary": "deletes an article",
+                "summary": "searches articles",
                 "parameters": [
                     {
                         "type": "string",
@@ -260,69 +343,39 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "type": "integer",
-                        "description": "ID of article",
-                        "name": "id",
-                        "in": "path",
+                        "type": "string",
+                        "description": "search query",
+                        "name": "q",
+                        "in": "query",
                         "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "ok",
-                        "schema": {
-                            "$ref": "#/definitions/models.Response"
-                        }
                     },
-                    "400": {
-                        "description": "bad request",
-                        "schema": {
-                            "$ref": "#/definitions/models.Response"
-                        }
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "page number",
+                        "name": "page",
+                        "in": "query"
                     },
-                    "500": {
-                        "description": "internal server error",
-                        "schema": {
-                            "$ref": "#/definitions/models.Response"
-                        }
-                    }
-                }
-            },
-            "patch": {
-                "description": "patches an article from the database, example to update article status",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "article"
-                ],
-                "summary": "patches an article",
-                "parameters": [
                     {
-                        "type": "string",
-                        "description": "Basic [token]. Token obtained from log in endpoint",
-                        "name": "Authorization",
-                        "in": "header",
-                        "required": true
+                        "type": "integer",
+                        "default": 10,
+                        "description": "limit per page",
+                        "name": "limit",
+                        "in": "query"
                     },
                     {
-                        "description": "Request of Creating Article Object",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.PatchArticleRequest"
-                        }
+                        "type": "string",
+                        "default": "rank",
+                        "description": "order field",
+                        "name": "orderField",
+                        "in": "query"
                     },
                     {
-                        "type": "integer",
-                        "description": "ID of article",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "type": "string",
+                        "default": "desc",
+                        "description": "order dir",
+                        "name": "orderDir",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -347,9 +400,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/articles/{id}/histories": {
+        "/articles/trash": {
             "get": {
-                "description": "lists articles histories for an article from the database",
+                "description": "lists articles moved to the trash. Users allowed to delete any article see the whole trash, the others only their own articles. Filters are those of listing articles plus deleted_at, sort by deleted_at too. Trashed articles are purged after the retention period",
                 "consumes": [
                     "application/json"
                 ],
@@ -359,7 +412,7 @@ const docTemplate = `{
                 "tags": [
                     "article"
                 ],
-                "summary": "lists articles histories for an article",
+                "summary": "lists trashed articles",
                 "parameters": [
                     {
                         "type": "string",
@@ -368,13 +421,6 @@ const docTemplate = `{
                         "in": "header",
                         "required": true
                     },
-                    {
-                        "type": "integer",
-                        "description": "ID of article",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
                     {
                         "type": "integer",
                         "default": 1,
@@ -391,7 +437,7 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "default": "id",
+                        "default": "deleted_at",
                         "description": "order field",
                         "name": "orderField",
                         "in": "query"
@@ -402,6 +448,12 @@ const docTemplate = `{
                         "description": "order dir",
                         "name": "orderDir",
                         "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor of the previous page, empty for the first page, switches to cursor pagination",
+                        "name": "cursor",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -426,9 +478,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/auth/login": {
-            "post": {
-                "description": "Add a new auth to database",
+        "/articles/{uuid}": {
+            "get": {
+                "description": "details an article from the database. Its ETag is its version, send it back in If-Match to change the article only while nobody else changed it",
                 "consumes": [
                     "application/json"
                 ],
@@ -436,18 +488,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
+                    "article"
                 ],
-                "summary": "Add a new auth to database",
+                "summary": "details an article",
                 "parameters": [
                     {
-                        "description": "Request of login",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.LoginRequest"
-                        }
+                        "type": "string",
+                        "description": "Basic [token]. Token obtained from log in endpoint",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "UUID of article",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -455,6 +512,12 @@ const docTemplate = `{
                         "description": "ok",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "version of the article"
+                            }
                         }
                     },
                     "400": {
@@ -469,13 +532,10 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.Response"
                         }
                     }
-                },
-                "x-order": 2
-            }
-        },
-        "/auth/profile": {
-            "get": {
-                "description": "Get profile of currently logged in user",
+                }
+            },
+            "put": {
+                "description": "replaces title, content and tags of an article, status is kept. The change is recorded in article history. With If-Match the article is only changed while it is at that version; If-Match is required when REQUIRE_IF_MATCH is set",
                 "consumes": [
                     "application/json"
                 ],
@@ -483,9 +543,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
+                    "article"
                 ],
-                "summary": "Get profile of currently logged in user",
+                "summary": "replaces an article",
                 "parameters": [
                     {
                         "type": "string",
@@ -493,6 +553,28 @@ const docTemplate = `{
                         "name": "Authorization",
                         "in": "header",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the version the change is based on",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Request of Replacing Article Object",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.PutArticleRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "UUID of article",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -500,6 +582,12 @@ const docTemplate = `{
                         "description": "ok",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "new version of the article"
+                            }
                         }
                     },
                     "400": {
@@ -508,49 +596,32 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.Response"
                         }
                     },
-                    "500": {
-                        "description": "internal server error",
+                    "403": {
+                        "description": "not the writer of the article",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
-                    }
-                },
-                "x-order": 3
-            }
-        },
-        "/auth/register": {
-            "post": {
-                "description": "Add a new auth to database",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "auth"
-                ],
-                "summary": "Add a new auth to database",
-                "parameters": [
-                    {
-                        "description": "Request body of registration",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
+                    },
+                    "404": {
+                        "description": "not found",
                         "schema": {
-                            "$ref": "#/definitions/models.RegisterRequest"
+                            "$ref": "#/definitions/models.Response"
                         }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "ok",
+                    },
+                    "412": {
+                        "description": "article changed since the version of If-Match",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
                     },
-                    "400": {
-                        "description": "bad request",
+                    "423": {
+                        "description": "article locked by another user",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "428": {
+                        "description": "If-Match required",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
@@ -561,13 +632,10 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.Response"
                         }
                     }
-                },
-                "x-order": 1
-            }
-        },
-        "/tags": {
-            "get": {
-                "description": "lists tags from the database",
+                }
+            },
+            "delete": {
+                "description": "moves an article to the trash, it can be restored from there until it is purged. With purge=true, which needs article:purge, the article is deleted permanently with its tags and histories, whether it is in the trash or not. With If-Match the article is only deleted while it is at that version; If-Match is required when REQUIRE_IF_MATCH is set",
                 "consumes": [
                     "application/json"
                 ],
@@ -575,9 +643,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "tag"
+                    "article"
                 ],
-                "summary": "lists tags",
+                "summary": "deletes an article",
                 "parameters": [
                     {
                         "type": "string",
@@ -586,6 +654,207 @@ const docTemplate = `{
                         "in": "header",
                         "required": true
                     },
+                    {
+                        "type": "string",
+                        "description": "ETag of the version the delete is based on",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "UUID of article",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "delete permanently instead of moving to the trash",
+                        "name": "purge",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "bad request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "not the writer of the article or not allowed to purge",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "412": {
+                        "description": "article changed since the version of If-Match",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "423": {
+                        "description": "article locked by another user",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "428": {
+                        "description": "If-Match required",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "patches an article as a JSON merge patch: only title, content and tags present in the request are changed, tags given replace the tag set. Status is changed through transitions. The change is recorded in article history. With If-Match the article is only changed while it is at that version; If-Match is required when REQUIRE_IF_MATCH is set",
+                "consumes": [
+                    "application/json",
+                    "application/merge-patch+json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "article"
+                ],
+                "summary": "patches an article",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Basic [token]. Token obtained from log in endpoint",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the version the patch is based on",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Request of Patching Article Object",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.PatchArticleRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "UUID of article",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "new version of the article"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "bad request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "not the writer of the article",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "412": {
+                        "description": "article changed since the version of If-Match",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "423": {
+                        "description": "article locked by another user",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "428": {
+                        "description": "If-Match required",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/articles/{uuid}/histories": {
+            "get": {
+                "description": "lists articles histories for an article from the database. Filter by uuid, version, status, action, actor (uuid of a user), request_id or created_at with an optional operator, e.g. action=in:approve,reject. Sort by version or created_at. Invalid params are a bad request",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "article"
+                ],
+                "summary": "lists articles histories for an article",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Basic [token]. Token obtained from log in endpoint",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "UUID of article",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    },
                     {
                         "type": "integer",
                         "default": 1,
@@ -594,25 +863,1733 @@ const docTemplate = `{
                         "in": "query"
                     },
                     {
-                        "type": "integer",
-                        "default": 10,
-                        "description": "limit per page",
-                        "name": "limit",
-                        "in": "query"
+                        "type": "integer",
+                        "default": 10,
+                        "description": "limit per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "version",
+                        "description": "order field",
+                        "name": "orderField",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "desc",
+                        "description": "order dir",
+                        "name": "orderDir",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor of the previous page, empty for the first page, switches to cursor pagination",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "bad request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/articles/{uuid}/histories/diff": {
+            "get": {
+                "description": "returns the changed fields between two history versions with a line and word-level diff of the content. Send Accept: text/x-diff or format=diff to get the unified diff as text",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "text/x-diff"
+                ],
+                "tags": [
+                    "article"
+                ],
+                "summary": "compares two versions of an article",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Basic [token]. Token obtained from log in endpoint",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "UUID of article",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "version to compare from",
+                        "name": "from",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "version to compare to",
+                        "name": "to",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "json",
+                            "diff"
+                        ],
+                        "type": "string",
+                        "description": "json or diff",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "bad request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "not allowed to read the article",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/articles/{uuid}/histories/{version}/restore": {
+            "post": {
+                "description": "copies title, content, status and tags of a history version back into the article and records it as a new version with action restore. Restoring a different status needs article:publish, snapshots of a deleted article cannot be restored",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "article"
+                ],
+                "summary": "restores an article to a previous version",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Basic [token]. Token obtained from log in endpoint",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "UUID of article",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "version of article history",
+                        "name": "version",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "bad request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "not allowed to restore the article",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "snapshot of a deleted article",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "423": {
+                        "description": "article locked by another user",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/articles/{uuid}/lock": {
+            "post": {
+                "description": "locks an article so only the user may patch, replace or delete it until the lock expires after ARTICLE_LOCK_TTL. Locking it again renews the lock, clients send it as a heartbeat while editing. Users allowed to update the article may lock it",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "article"
+                ],
+                "summary": "locks an article for editing",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Basic [token]. Token obtained from log in endpoint",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "UUID of article",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "bad request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "not allowed to update the article",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "423": {
+                        "description": "article locked by another user",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "releases the lock of an article held by the user. The lock of another user is refused unless force is true, which needs article:unlock",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "article"
+                ],
+                "summary": "unlocks an article",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Basic [token]. Token obtained from log in endpoint",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "UUID of article",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "release the lock of another user",
+                        "name": "force",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "bad request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "not allowed to force unlock",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "article not found or not locked",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "423": {
+                        "description": "article locked by another user",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/articles/{uuid}/related": {
+            "get": {
+                "description": "lists other articles ranked by how related they are to an article by their tags, sharing a rare tag counting more than sharing a common one. Each article carries its score as TagRelationshipScore",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "article"
+                ],
+                "summary": "lists articles related to an article",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Basic [token]. Token obtained from log in endpoint",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "UUID of article",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "PUBLISHED",
+                        "description": "only articles of this status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "leave out articles of the same writer",
+                        "name": "exclude_same_writer",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "number of articles, at most 50",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "bad request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "not allowed to read the article",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/articles/{uuid}/restore": {
+            "post": {
+                "description": "restores a trashed article and records it as a new version with action undelete. Users allowed to delete the article may restore it",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "article"
+                ],
+                "summary": "restores an article from the trash",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Basic [token]. Token obtained from log in endpoint",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "UUID of article",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "bad request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "not allowed to restore the article",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "article not in the trash",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "423": {
+                        "description": "article locked by another user",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/articles/{uuid}/schedule": {
+            "put": {
+                "description": "sets when an article is published and unpublished. An APPROVED article is published once publish_at has passed and a PUBLISHED article is archived once unpublish_at has passed, recorded in article history as scheduled_publish and scheduled_unpublish. Times left out clear the schedule. Needs article:publish",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "article"
+                ],
+                "summary": "schedules publishing of an article",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Basic [token]. Token obtained from log in endpoint",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Request of Scheduling Article Object",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ScheduleArticleRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "UUID of article",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "bad request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "not allowed to schedule the article",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "article is archived",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "423": {
+                        "description": "article locked by another user",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/articles/{uuid}/transitions": {
+            "get": {
+                "description": "lists the workflow transitions the user may perform on an article from its current status",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "article"
+                ],
+                "summary": "lists transitions available on an article",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Basic [token]. Token obtained from log in endpoint",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "UUID of article",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "bad request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "not allowed to read the article",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "performs a workflow transition on an article: submit (DRAFT to IN_REVIEW, by its writer or an editor), approve (IN_REVIEW to APPROVED, needs article:review), reject (IN_REVIEW or APPROVED back to DRAFT with a required comment, needs article:review, clearing publish_at), publish (APPROVED to PUBLISHED, needs article:publish) and archive (PUBLISHED to ARCHIVED, needs article:publish). The transition is recorded in article history with its actor and comment",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "article"
+                ],
+                "summary": "moves an article through the editorial workflow",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Basic [token]. Token obtained from log in endpoint",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Request of Transitioning Article Object",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.TransitionArticleRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "UUID of article",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "bad request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "not allowed to perform the transition",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "transition not allowed from the current status",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "423": {
+                        "description": "article locked by another user",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/login": {
+            "post": {
+                "description": "Add a new auth to database",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Add a new auth to database",
+                "parameters": [
+                    {
+                        "description": "Request of login",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.LoginRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "bad request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                },
+                "x-order": 2
+            }
+        },
+        "/auth/logout": {
+            "post": {
+                "description": "Revoke the current access token and, when given, the family of the refresh token",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Revoke the current access token",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Basic [token]. Token obtained from log in endpoint",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Request of logout",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/models.LogoutRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "bad request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                },
+                "x-order": 4
+            }
+        },
+        "/auth/profile": {
+            "get": {
+                "description": "Get profile of currently logged in user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Get profile of currently logged in user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Basic [token]. Token obtained from log in endpoint",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "bad request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                },
+                "x-order": 5
+            }
+        },
+        "/auth/refresh": {
+            "post": {
+                "description": "Exchange a refresh token for a new access token and a rotated refresh token. Reusing a rotated refresh token revokes its whole family",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Exchange a refresh token for new tokens",
+                "parameters": [
+                    {
+                        "description": "Request of refreshing token",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.RefreshTokenRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "bad request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "invalid refresh token",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                },
+                "x-order": 3
+            }
+        },
+        "/auth/register": {
+            "post": {
+                "description": "Add a new auth to database. Only WRITER (default) and VIEWER roles can be self-assigned",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Add a new auth to database",
+                "parameters": [
+                    {
+                        "description": "Request body of registration",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.RegisterRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "bad request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "role cannot be self-assigned",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                },
+                "x-order": 1
+            }
+        },
+        "/public/articles": {
+            "get": {
+                "description": "lists published articles without authentication, leaving out uuids, writer and workflow fields. Filter by title, created_at or updated_at with an optional operator, e.g. title=like:go, and by tag, tags_any, tags_all, category, created_from and created_to. Sort by title, created_at or updated_at. Responses can be cached for PUBLIC_CACHE_MAX_AGE and revalidated with their ETag",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "public"
+                ],
+                "summary": "lists published articles",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "limit per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "created_at",
+                        "description": "order field",
+                        "name": "orderField",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "desc",
+                        "description": "order dir",
+                        "name": "orderDir",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor of the previous page, empty for the first page, switches to cursor pagination",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "only articles having this tag, can be repeated to require each",
+                        "name": "tag",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "only articles having this tag or one of its descendants",
+                        "name": "category",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of a cached response",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "304": {
+                        "description": "not modified",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "bad request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/public/articles/{slug}": {
+            "get": {
+                "description": "details a published article by slug without authentication, leaving out ids, writer and workflow fields. A former slug redirects permanently to the current one. Responses can be cached for PUBLIC_CACHE_MAX_AGE and revalidated with their ETag",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "public"
+                ],
+                "summary": "details a published article",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "slug of an article",
+                        "name": "slug",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of a cached response",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "301": {
+                        "description": "moved to the slug in Location",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "304": {
+                        "description": "not modified",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/public/tags": {
+            "get": {
+                "description": "lists the tags of published articles with the number of them without authentication. Filter by title with an optional operator, e.g. title=like:go. Sort by title or usage_count. Responses can be cached for PUBLIC_CACHE_MAX_AGE and revalidated with their ETag",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "public"
+                ],
+                "summary": "lists the tags of published articles",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "limit per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "usage_count",
+                        "description": "order field",
+                        "name": "orderField",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "desc",
+                        "description": "order dir",
+                        "name": "orderDir",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of a cached response",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "304": {
+                        "description": "not modified",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "bad request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/tags": {
+            "get": {
+                "description": "lists tags from the database. Filter by uuid, title, parent (uuid of a tag) or created_at with an optional operator, e.g. title=like:go. Sort by title, created_at or usage_count. Invalid params are a bad request",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tag"
+                ],
+                "summary": "lists tags",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Basic [token]. Token obtained from log in endpoint",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "limit per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "created_at",
+                        "description": "order field",
+                        "name": "orderField",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "desc",
+                        "description": "order dir",
+                        "name": "orderDir",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "bad request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "creates new tag and saves it to the database",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tag"
+                ],
+                "summary": "creates new tag",
+                "parameters": [
+                    {
+                        "description": "Request of Creating Tag Object",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CreateTagRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Basic [token]. Token obtained from log in endpoint",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "bad request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/tags/suggest": {
+            "get": {
+                "description": "suggests tags starting with q or similar to it, most used first. Meant for autocompleting tags while writing an article",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tag"
+                ],
+                "summary": "suggests tags",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Basic [token]. Token obtained from log in endpoint",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "typed tag",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "number of tags, at most 50",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "bad request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/tags/tree": {
+            "get": {
+                "description": "lists all tags nested under their parent tags, each with its usage count and subcategories sorted by title",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tag"
+                ],
+                "summary": "lists tags as a tree of categories",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Basic [token]. Token obtained from log in endpoint",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "bad request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/tags/trending": {
+            "get": {
+                "description": "lists the highest scored tags of a window. Scores are recomputed periodically from tagging and publishing of tagged articles within the window, recent events counting more",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tag"
+                ],
+                "summary": "lists trending tags",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Basic [token]. Token obtained from log in endpoint",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "one of the configured windows, e.g. 1d, 7d or 30d, defaults to the first",
+                        "name": "window",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "number of tags, at most 100",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "bad request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/tags/{uuid}": {
+            "get": {
+                "description": "details a tag from the database",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tag"
+                ],
+                "summary": "details a tag",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Basic [token]. Token obtained from log in endpoint",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "UUID of a tag",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "bad request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "deletes a tag. A tag still used by articles is refused unless force is true, which removes it from them with a new history version of each",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tag"
+                ],
+                "summary": "deletes a tag",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Basic [token]. Token obtained from log in endpoint",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "UUID of a tag",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "delete even when used by articles",
+                        "name": "force",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "bad request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "tag in use",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "renames a tag, the title is trimmed and lower-cased like on create and must not be taken by another tag",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tag"
+                ],
+                "summary": "renames a tag",
+                "parameters": [
+                    {
+                        "description": "Request of Renaming Tag Object",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.RenameTagRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Basic [token]. Token obtained from log in endpoint",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "UUID of a tag",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "bad request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "title taken by another tag",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/tags/{uuid}/merge": {
+            "post": {
+                "description": "moves the articles of a tag to the target tag and deletes the tag, articles already tagged with the target keep a single link. Each article of the tag gets a new history version",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tag"
+                ],
+                "summary": "merges a tag into another",
+                "parameters": [
+                    {
+                        "description": "Request of Merging Tag Object",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.MergeTagRequest"
+                        }
                     },
                     {
                         "type": "string",
-                        "default": "id",
-                        "description": "order field",
-                        "name": "orderField",
-                        "in": "query"
+                        "description": "Basic [token]. Token obtained from log in endpoint",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
                     },
                     {
                         "type": "string",
-                        "default": "desc",
-                        "description": "order dir",
-                        "name": "orderDir",
-                        "in": "query"
+                        "description": "UUID of the tag to merge",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -628,6 +2605,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.Response"
                         }
                     },
+                    "404": {
+                        "description": "not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "tag or target merged or deleted meanwhile",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
                     "500": {
                         "description": "internal server error",
                         "schema": {
@@ -635,9 +2624,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "post": {
-                "description": "creates new tag and saves it to the database",
+            }
+        },
+        "/tags/{uuid}/parent": {
+            "put": {
+                "description": "changes the parent category of a tag, its subcategories move along. A null parent_uuid makes it a root category. A tag cannot be moved under itself or its descendants",
                 "consumes": [
                     "application/json"
                 ],
@@ -647,15 +2638,15 @@ const docTemplate = `{
                 "tags": [
                     "tag"
                 ],
-                "summary": "creates new tag",
+                "summary": "moves a tag under another",
                 "parameters": [
                     {
-                        "description": "Request of Creating Tag Object",
+                        "description": "Request of Moving Tag Object",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.CreateTagRequest"
+                            "$ref": "#/definitions/models.MoveTagRequest"
                         }
                     },
                     {
@@ -664,6 +2655,13 @@ const docTemplate = `{
                         "name": "Authorization",
                         "in": "header",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "UUID of a tag",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -679,6 +2677,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.Response"
                         }
                     },
+                    "404": {
+                        "description": "tag or parent not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "parent is the tag or one of its descendants",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
                     "500": {
                         "description": "internal server error",
                         "schema": {
@@ -688,9 +2698,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/tags/{id}": {
-            "get": {
-                "description": "details a tag from the database",
+        "/users/{uuid}/role": {
+            "patch": {
+                "description": "changes role of a user, requires user:manage permission",
                 "consumes": [
                     "application/json"
                 ],
@@ -698,9 +2708,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "tag"
+                    "user"
                 ],
-                "summary": "details a tag",
+                "summary": "changes role of a user",
                 "parameters": [
                     {
                         "type": "string",
@@ -710,9 +2720,18 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "type": "integer",
-                        "description": "ID of a tag",
-                        "name": "id",
+                        "description": "Request of Changing Role",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ChangeRoleRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "UUID of user",
+                        "name": "uuid",
                         "in": "path",
                         "required": true
                     }
@@ -730,8 +2749,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.Response"
                         }
                     },
-                    "500": {
-                        "description": "internal server error",
+                    "403": {
+                        "description": "forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "not found",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
@@ -741,6 +2766,17 @@ const docTemplate = `{
         }
     },
     "definitions": {
+        "models.ChangeRoleRequest": {
+            "type": "object",
+            "required": [
+                "role"
+            ],
+            "properties": {
+                "role": {
+                    "type": "string"
+                }
+            }
+        },
         "models.CreateArticleRequest": {
             "type": "object",
             "required": [
@@ -751,9 +2787,6 @@ const docTemplate = `{
                 "content": {
                     "type": "string"
                 },
-                "status": {
-                    "type": "string"
-                },
                 "tags": {
                     "type": "array",
                     "items": {
@@ -771,11 +2804,57 @@ const docTemplate = `{
                 "title"
             ],
             "properties": {
+                "parent_uuid": {
+                    "type": "string"
+                },
                 "title": {
                     "type": "string"
                 }
             }
         },
+        "models.JWK": {
+            "type": "object",
+            "properties": {
+                "alg": {
+                    "type": "string"
+                },
+                "crv": {
+                    "type": "string"
+                },
+                "e": {
+                    "type": "string"
+                },
+                "kid": {
+                    "type": "string"
+                },
+                "kty": {
+                    "type": "string"
+                },
+                "n": {
+                    "type": "string"
+                },
+                "use": {
+                    "type": "string"
+                },
+                "x": {
+                    "type": "string"
+                },
+                "y": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.JWKSet": {
+            "type": "object",
+            "properties": {
+                "keys": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.JWK"
+                    }
+                }
+            }
+        },
         "models.LoginRequest": {
             "type": "object",
             "required": [
@@ -791,10 +2870,100 @@ const docTemplate = `{
                 }
             }
         },
+        "models.LogoutRequest": {
+            "type": "object",
+            "properties": {
+                "refresh_token": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.MergeTagRequest": {
+            "type": "object",
+            "required": [
+                "target_uuid"
+            ],
+            "properties": {
+                "target_uuid": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.MoveTagRequest": {
+            "type": "object",
+            "properties": {
+                "parent_uuid": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.PageMeta": {
+            "type": "object",
+            "properties": {
+                "has_next": {
+                    "type": "boolean"
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "next_cursor": {
+                    "type": "string"
+                },
+                "page": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
         "models.PatchArticleRequest": {
             "type": "object",
             "properties": {
-                "status": {
+                "content": {
+                    "type": "string",
+                    "minLength": 1
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "title": {
+                    "type": "string",
+                    "minLength": 1
+                }
+            }
+        },
+        "models.PutArticleRequest": {
+            "type": "object",
+            "required": [
+                "content",
+                "title"
+            ],
+            "properties": {
+                "content": {
+                    "type": "string"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.RefreshTokenRequest": {
+            "type": "object",
+            "required": [
+                "refresh_token"
+            ],
+            "properties": {
+                "refresh_token": {
                     "type": "string"
                 }
             }
@@ -817,12 +2986,74 @@ const docTemplate = `{
                 }
             }
         },
+        "models.RenameTagRequest": {
+            "type": "object",
+            "required": [
+                "title"
+            ],
+            "properties": {
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
         "models.Response": {
             "type": "object",
             "properties": {
                 "data": {},
                 "message": {
                     "type": "string"
+                },
+                "meta": {
+                    "$ref": "#/definitions/models.PageMeta"
+                },
+                "warnings": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.TagWarning"
+                    }
+                }
+            }
+        },
+        "models.ScheduleArticleRequest": {
+            "type": "object",
+            "properties": {
+                "publish_at": {
+                    "type": "string"
+                },
+                "unpublish_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.TagWarning": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string"
+                },
+                "similar": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "tag": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.TransitionArticleRequest": {
+            "type": "object",
+            "required": [
+                "action"
+            ],
+            "properties": {
+                "action": {
+                    "type": "string"
+                },
+                "comment": {
+                    "type": "string"
                 }
             }
         }
@@ -3,9 +3,13 @@ package handlers
 import (
 	"encoding/json"
 	"net/http"
+	"net/url"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/go-playground/validator/v10"
+	"github.com/herdiansc/go-cms/config"
 	"github.com/herdiansc/go-cms/models"
 	"github.com/herdiansc/go-cms/respositories"
 	"github.com/herdiansc/go-cms/services"
@@ -27,7 +31,7 @@ func NewArticleHandler(db *gorm.DB) ArticleHandler {
 // Create creates new article
 //
 //	@Summary		creates new article
-//	@Description	creates new article and saves it to the database
+//	@Description	creates new article as DRAFT and saves it to the database. Tags not existing yet are created, the response warns about those looking like existing tags
 //	@Tags			article
 //	@Accept			json
 //	@Produce		json
@@ -42,9 +46,9 @@ func (h ArticleHandler) Create(w http.ResponseWriter, r *http.Request) {
 	jd := json.NewDecoder(r.Body)
 	rv := validator.New(validator.WithRequiredStructEnabled())
 	ac := respositories.NewArticleRepository(h.db)
-	hr := respositories.NewArticleHistoryRepository(h.db)
+	ts := respositories.NewTagRepository(h.db)
 
-	svc := services.NewCreateArticleServices(ad, jd, rv, ac, hr)
+	svc := services.NewCreateArticleServices(ad, jd, rv, ac, ts)
 	code, res := svc.Create()
 	w.WriteHeader(code)
 	json.NewEncoder(w).Encode(res)
@@ -53,15 +57,24 @@ func (h ArticleHandler) Create(w http.ResponseWriter, r *http.Request) {
 // List lists articles
 //
 //	@Summary		lists articles
-//	@Description	lists articles from the database
+//	@Description	lists articles from the database. Filter by uuid, title, status, slug, writer or writer_id (uuid of a user), created_at, updated_at, publish_at or unpublish_at with an optional operator, e.g. status=in:DRAFT,PUBLISHED, created_at=gte:2026-01-01 or title=like:go, and by tag, category, created_from and created_to. Each article carries its tags and the uuid of its writer. Sort by title, status, created_at, updated_at, publish_at or unpublish_at. Invalid params are a bad request
 //	@Tags			article
 //	@Accept			json
 //	@Produce		json
 //	@Param			Authorization	header		string			true	"Basic [token]. Token obtained from log in endpoint"
 //	@Param			page			query		int				false	"page number"		default(1)
 //	@Param			limit			query		int				false	"limit per page"	default(10)
-//	@Param			orderField		query		string			false	"order field"		default(id)
+//	@Param			orderField		query		string			false	"order field"		default(created_at)
 //	@Param			orderDir		query		string			false	"order dir"			default(desc)
+//	@Param			cursor			query		string			false	"next_cursor of the previous page, empty for the first page, switches to cursor pagination"
+//	@Param			tag				query		string			false	"only articles having this tag, can be repeated to require each"
+//	@Param			tags_any		query		string			false	"only articles having any of these comma separated tags"
+//	@Param			tags_all		query		string			false	"only articles having all of these comma separated tags"
+//	@Param			category		query		string			false	"only articles having this tag or one of its descendants, can be repeated to require each"
+//	@Param			writer			query		string			false	"only articles written by the user of this uuid"
+//	@Param			writer_id		query		string			false	"same as writer"
+//	@Param			created_from	query		string			false	"only articles created at or after this date or RFC 3339 time"
+//	@Param			created_to		query		string			false	"only articles created before this date or RFC 3339 time"
 //	@Success		200				{object}	models.Response	"ok"
 //	@Failure		400				{object}	models.Response	"bad request"
 //	@Failure		500				{object}	models.Response	"internal server error"
@@ -76,31 +89,129 @@ func (h ArticleHandler) List(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(res)
 }
 
+// Search searches articles
+//
+//	@Summary		searches articles
+//	@Description	full-text search over title and content, title matches rank higher. q supports quoted phrases, OR and -word. Results are ranked by relevance and carry a headline snippet, escaped as HTML, with matches wrapped in <mark>. Other params paginate and filter like the list endpoint
+//	@Tags			article
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header		string			true	"Basic [token]. Token obtained from log in endpoint"
+//	@Param			q				query		string			true	"search query"
+//	@Param			page			query		int				false	"page number"		default(1)
+//	@Param			limit			query		int				false	"limit per page"	default(10)
+//	@Param			orderField		query		string			false	"order field"		default(rank)
+//	@Param			orderDir		query		string			false	"order dir"			default(desc)
+//	@Success		200				{object}	models.Response	"ok"
+//	@Failure		400				{object}	models.Response	"bad request"
+//	@Failure		500				{object}	models.Response	"internal server error"
+//	@Router			/articles/search [get]
+func (h ArticleHandler) Search(w http.ResponseWriter, r *http.Request) {
+	ad := r.Context().Value(models.AuthVerifyCtxKey)
+	as := respositories.NewArticleRepository(h.db)
+
+	svc := services.NewSearchArticleServices(ad, as)
+	code, res := svc.Search(r.URL.Query())
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(res)
+}
+
 // ListHistories lists articles histories for an article
 //
 //	@Summary		lists articles histories for an article
-//	@Description	lists articles histories for an article from the database
+//	@Description	lists articles histories for an article from the database. Filter by uuid, version, status, action, actor (uuid of a user), request_id or created_at with an optional operator, e.g. action=in:approve,reject. Sort by version or created_at. Invalid params are a bad request
 //	@Tags			article
 //	@Accept			json
 //	@Produce		json
 //	@Param			Authorization	header		string			true	"Basic [token]. Token obtained from log in endpoint"
-//	@Param			id				path		integer			true	"ID of article"
+//	@Param			uuid			path		string			true	"UUID of article"
 //	@Param			page			query		int				false	"page number"		default(1)
 //	@Param			limit			query		int				false	"limit per page"	default(10)
-//	@Param			orderField		query		string			false	"order field"		default(id)
+//	@Param			orderField		query		string			false	"order field"		default(version)
 //	@Param			orderDir		query		string			false	"order dir"			default(desc)
+//	@Param			cursor			query		string			false	"next_cursor of the previous page, empty for the first page, switches to cursor pagination"
 //	@Success		200				{object}	models.Response	"ok"
 //	@Failure		400				{object}	models.Response	"bad request"
 //	@Failure		500				{object}	models.Response	"internal server error"
-//	@Router			/articles/{id}/histories [get]
+//	@Router			/articles/{uuid}/histories [get]
 func (h ArticleHandler) ListHistories(w http.ResponseWriter, r *http.Request) {
 	ad := r.Context().Value(models.AuthVerifyCtxKey)
 	ar := respositories.NewArticleRepository(h.db)
+	pc := respositories.NewRoleRepository(h.db)
 	ac := respositories.NewArticleHistoryRepository(h.db)
 
-	svc := services.NewListArticleHistoryServices(ad, ar, ac)
-	id, _ := strconv.Atoi(r.PathValue("id"))
-	code, res := svc.List(int64(id), r.URL.Query())
+	svc := services.NewListArticleHistoryServices(ad, ar, pc, ac)
+	code, res := svc.List(r.PathValue("uuid"), r.URL.Query())
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(res)
+}
+
+// DiffHistories compares two versions of an article
+//
+//	@Summary		compares two versions of an article
+//	@Description	returns the changed fields between two history versions with a line and word-level diff of the content. Send Accept: text/x-diff or format=diff to get the unified diff as text
+//	@Tags			article
+//	@Accept			json
+//	@Produce		json
+//	@Produce		text/x-diff
+//	@Param			Authorization	header		string			true	"Basic [token]. Token obtained from log in endpoint"
+//	@Param			uuid			path		string			true	"UUID of article"
+//	@Param			from			query		int				true	"version to compare from"
+//	@Param			to				query		int				true	"version to compare to"
+//	@Param			format			query		string			false	"json or diff"	Enums(json, diff)
+//	@Success		200				{object}	models.Response	"ok"
+//	@Failure		400				{object}	models.Response	"bad request"
+//	@Failure		403				{object}	models.Response	"not allowed to read the article"
+//	@Failure		404				{object}	models.Response	"not found"
+//	@Failure		500				{object}	models.Response	"internal server error"
+//	@Router			/articles/{uuid}/histories/diff [get]
+func (h ArticleHandler) DiffHistories(w http.ResponseWriter, r *http.Request) {
+	ad := r.Context().Value(models.AuthVerifyCtxKey)
+	ar := respositories.NewArticleRepository(h.db)
+	pc := respositories.NewRoleRepository(h.db)
+	hr := respositories.NewArticleHistoryRepository(h.db)
+
+	svc := services.NewDiffArticleHistoryServices(ad, ar, pc, hr)
+	code, res := svc.Diff(r.PathValue("uuid"), r.URL.Query())
+
+	diff, ok := res.Data.(models.ArticleDiff)
+	if ok && (r.URL.Query().Get("format") == "diff" || strings.Contains(r.Header.Get("Accept"), "text/x-diff")) {
+		w.Header().Set("Content-Type", "text/x-diff; charset=utf-8")
+		w.WriteHeader(code)
+		w.Write([]byte(diff.Unified))
+		return
+	}
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(res)
+}
+
+// RestoreHistory restores an article to a previous version
+//
+//	@Summary		restores an article to a previous version
+//	@Description	copies title, content, status and tags of a history version back into the article and records it as a new version with action restore. Restoring a different status needs article:publish, snapshots of a deleted article cannot be restored
+//	@Tags			article
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header		string			true	"Basic [token]. Token obtained from log in endpoint"
+//	@Param			uuid			path		string			true	"UUID of article"
+//	@Param			version			path		integer			true	"version of article history"
+//	@Success		200				{object}	models.Response	"ok"
+//	@Failure		400				{object}	models.Response	"bad request"
+//	@Failure		403				{object}	models.Response	"not allowed to restore the article"
+//	@Failure		404				{object}	models.Response	"not found"
+//	@Failure		409				{object}	models.Response	"snapshot of a deleted article"
+//	@Failure		423				{object}	models.Response	"article locked by another user"
+//	@Failure		500				{object}	models.Response	"internal server error"
+//	@Router			/articles/{uuid}/histories/{version}/restore [post]
+func (h ArticleHandler) RestoreHistory(w http.ResponseWriter, r *http.Request) {
+	ad := r.Context().Value(models.AuthVerifyCtxKey)
+	ar := respositories.NewArticleRepository(h.db)
+	pc := respositories.NewRoleRepository(h.db)
+	hr := respositories.NewArticleHistoryRepository(h.db)
+	al := respositories.NewArticleLockRepository(h.db)
+
+	svc := services.NewRestoreArticleHistoryServices(ad, ar, pc, hr, ar, al)
+	code, res := svc.Restore(r.PathValue("uuid"), r.PathValue("version"))
 	w.WriteHeader(code)
 	json.NewEncoder(w).Encode(res)
 }
@@ -108,23 +219,51 @@ func (h ArticleHandler) ListHistories(w http.ResponseWriter, r *http.Request) {
 // Detail details an article
 //
 //	@Summary		details an article
-//	@Description	details an article from the database
+//	@Description	details an article from the database. Its ETag is its version, send it back in If-Match to change the article only while nobody else changed it
 //	@Tags			article
 //	@Accept			json
 //	@Produce		json
 //	@Param			Authorization	header		string			true	"Basic [token]. Token obtained from log in endpoint"
-//	@Param			id				path		integer			true	"ID of article"
+//	@Param			uuid			path		string			true	"UUID of article"
 //	@Success		200				{object}	models.Response	"ok"
+//	@Header			200				{string}	ETag			"version of the article"
 //	@Failure		400				{object}	models.Response	"bad request"
 //	@Failure		500				{object}	models.Response	"internal server error"
-//	@Router			/articles/{id} [get]
+//	@Router			/articles/{uuid} [get]
 func (h ArticleHandler) Detail(w http.ResponseWriter, r *http.Request) {
 	ad := r.Context().Value(models.AuthVerifyCtxKey)
 	ac := respositories.NewArticleRepository(h.db)
 
 	svc := services.NewDetailArticleServices(ad, ac)
-	id, _ := strconv.Atoi(r.PathValue("id"))
-	code, res := svc.GetDetailByUUID(int64(id))
+	code, res := svc.GetDetailByUUID(r.PathValue("uuid"))
+	setArticleETag(w, res)
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(res)
+}
+
+// DetailBySlug details an article by slug
+//
+//	@Summary		details an article by slug
+//	@Description	details an article by its slug. A former slug of a published article redirects permanently to its current slug
+//	@Tags			article
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header		string			true	"Basic [token]. Token obtained from log in endpoint"
+//	@Param			slug			path		string			true	"slug of article"
+//	@Success		200				{object}	models.Response	"ok"
+//	@Success		301				{object}	models.Response	"moved to the slug in Location"
+//	@Failure		400				{object}	models.Response	"bad request"
+//	@Failure		404				{object}	models.Response	"not found"
+//	@Router			/articles/by-slug/{slug} [get]
+func (h ArticleHandler) DetailBySlug(w http.ResponseWriter, r *http.Request) {
+	ad := r.Context().Value(models.AuthVerifyCtxKey)
+	af := respositories.NewArticleRepository(h.db)
+
+	svc := services.NewDetailArticleBySlugServices(ad, af)
+	code, res := svc.GetDetailBySlug(r.PathValue("slug"))
+	if moved, ok := res.Data.(models.MovedArticle); ok {
+		w.Header().Set("Location", "/articles/by-slug/"+url.PathEscape(moved.Slug))
+	}
 	w.WriteHeader(code)
 	json.NewEncoder(w).Encode(res)
 }
@@ -132,23 +271,89 @@ func (h ArticleHandler) Detail(w http.ResponseWriter, r *http.Request) {
 // Delete deletes an article
 //
 //	@Summary		deletes an article
-//	@Description	deletes an article from the database
+//	@Description	moves an article to the trash, it can be restored from there until it is purged. With purge=true, which needs article:purge, the article is deleted permanently with its tags and histories, whether it is in the trash or not. With If-Match the article is only deleted while it is at that version; If-Match is required when REQUIRE_IF_MATCH is set
 //	@Tags			article
 //	@Accept			json
 //	@Produce		json
 //	@Param			Authorization	header		string			true	"Basic [token]. Token obtained from log in endpoint"
-//	@Param			id				path		integer			true	"ID of article"
+//	@Param			If-Match		header		string			false	"ETag of the version the delete is based on"
+//	@Param			uuid			path		string			true	"UUID of article"
+//	@Param			purge			query		bool			false	"delete permanently instead of moving to the trash"
 //	@Success		200				{object}	models.Response	"ok"
 //	@Failure		400				{object}	models.Response	"bad request"
+//	@Failure		403				{object}	models.Response	"not the writer of the article or not allowed to purge"
+//	@Failure		404				{object}	models.Response	"not found"
+//	@Failure		412				{object}	models.Response	"article changed since the version of If-Match"
+//	@Failure		423				{object}	models.Response	"article locked by another user"
+//	@Failure		428				{object}	models.Response	"If-Match required"
 //	@Failure		500				{object}	models.Response	"internal server error"
-//	@Router			/articles/{id} [delete]
+//	@Router			/articles/{uuid} [delete]
 func (h ArticleHandler) Delete(w http.ResponseWriter, r *http.Request) {
 	ad := r.Context().Value(models.AuthVerifyCtxKey)
 	ade := respositories.NewArticleRepository(h.db)
+	pc := respositories.NewRoleRepository(h.db)
+	al := respositories.NewArticleLockRepository(h.db)
+
+	svc := services.NewDeleteArticleServices(ad, ade, pc, ade, al, config.GetBool("REQUIRE_IF_MATCH", false))
+	purge, _ := strconv.ParseBool(r.URL.Query().Get("purge"))
+	code, res := svc.Delete(r.PathValue("uuid"), purge, r.Header.Get("If-Match"))
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(res)
+}
+
+// ListTrash lists trashed articles
+//
+//	@Summary		lists trashed articles
+//	@Description	lists articles moved to the trash. Users allowed to delete any article see the whole trash, the others only their own articles. Filters are those of listing articles plus deleted_at, sort by deleted_at too. Trashed articles are purged after the retention period
+//	@Tags			article
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header		string			true	"Basic [token]. Token obtained from log in endpoint"
+//	@Param			page			query		int				false	"page number"		default(1)
+//	@Param			limit			query		int				false	"limit per page"	default(10)
+//	@Param			orderField		query		string			false	"order field"		default(deleted_at)
+//	@Param			orderDir		query		string			false	"order dir"			default(desc)
+//	@Param			cursor			query		string			false	"next_cursor of the previous page, empty for the first page, switches to cursor pagination"
+//	@Success		200				{object}	models.Response	"ok"
+//	@Failure		400				{object}	models.Response	"bad request"
+//	@Failure		500				{object}	models.Response	"internal server error"
+//	@Router			/articles/trash [get]
+func (h ArticleHandler) ListTrash(w http.ResponseWriter, r *http.Request) {
+	ad := r.Context().Value(models.AuthVerifyCtxKey)
+	al := respositories.NewArticleRepository(h.db)
+	pc := respositories.NewRoleRepository(h.db)
+
+	svc := services.NewListArticleTrashServices(ad, pc, al)
+	code, res := svc.List(r.URL.Query())
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(res)
+}
+
+// Undelete restores an article from the trash
+//
+//	@Summary		restores an article from the trash
+//	@Description	restores a trashed article and records it as a new version with action undelete. Users allowed to delete the article may restore it
+//	@Tags			article
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header		string			true	"Basic [token]. Token obtained from log in endpoint"
+//	@Param			uuid			path		string			true	"UUID of article"
+//	@Success		200				{object}	models.Response	"ok"
+//	@Failure		400				{object}	models.Response	"bad request"
+//	@Failure		403				{object}	models.Response	"not allowed to restore the article"
+//	@Failure		404				{object}	models.Response	"not found"
+//	@Failure		409				{object}	models.Response	"article not in the trash"
+//	@Failure		423				{object}	models.Response	"article locked by another user"
+//	@Failure		500				{object}	models.Response	"internal server error"
+//	@Router			/articles/{uuid}/restore [post]
+func (h ArticleHandler) Undelete(w http.ResponseWriter, r *http.Request) {
+	ad := r.Context().Value(models.AuthVerifyCtxKey)
+	ar := respositories.NewArticleRepository(h.db)
+	pc := respositories.NewRoleRepository(h.db)
+	al := respositories.NewArticleLockRepository(h.db)
 
-	svc := services.NewDeleteArticleServices(ad, ade)
-	id, _ := strconv.Atoi(r.PathValue("id"))
-	code, res := svc.Delete(int64(id))
+	svc := services.NewUndeleteArticleServices(ad, ar, pc, ar, al)
+	code, res := svc.Undelete(r.PathValue("uuid"))
 	w.WriteHeader(code)
 	json.NewEncoder(w).Encode(res)
 }
@@ -156,27 +361,255 @@ func (h ArticleHandler) Delete(w http.ResponseWriter, r *http.Request) {
 // Patch patches an article
 //
 //	@Summary		patches an article
-//	@Description	patches an article from the database, example to update article status
+//	@Description	patches an article as a JSON merge patch: only title, content and tags present in the request are changed, tags given replace the tag set. Status is changed through transitions. The change is recorded in article history. With If-Match the article is only changed while it is at that version; If-Match is required when REQUIRE_IF_MATCH is set
 //	@Tags			article
 //	@Accept			json
+//	@Accept			application/merge-patch+json
 //	@Produce		json
 //	@Param			Authorization	header		string						true	"Basic [token]. Token obtained from log in endpoint"
-//	@Param			request			body		models.PatchArticleRequest	true	"Request of Creating Article Object"
-//	@Param			id				path		integer						true	"ID of article"
+//	@Param			If-Match		header		string						false	"ETag of the version the patch is based on"
+//	@Param			request			body		models.PatchArticleRequest	true	"Request of Patching Article Object"
+//	@Param			uuid			path		string						true	"UUID of article"
 //	@Success		200				{object}	models.Response				"ok"
+//	@Header			200				{string}	ETag						"new version of the article"
 //	@Failure		400				{object}	models.Response				"bad request"
+//	@Failure		403				{object}	models.Response				"not the writer of the article"
+//	@Failure		404				{object}	models.Response				"not found"
+//	@Failure		412				{object}	models.Response				"article changed since the version of If-Match"
+//	@Failure		423				{object}	models.Response				"article locked by another user"
+//	@Failure		428				{object}	models.Response				"If-Match required"
 //	@Failure		500				{object}	models.Response				"internal server error"
-//	@Router			/articles/{id} [patch]
+//	@Router			/articles/{uuid} [patch]
 func (h ArticleHandler) Patch(w http.ResponseWriter, r *http.Request) {
 	ad := r.Context().Value(models.AuthVerifyCtxKey)
 	jd := json.NewDecoder(r.Body)
 	rv := validator.New(validator.WithRequiredStructEnabled())
 	ade := respositories.NewArticleRepository(h.db)
-	hr := respositories.NewArticleHistoryRepository(h.db)
+	pc := respositories.NewRoleRepository(h.db)
+	al := respositories.NewArticleLockRepository(h.db)
+
+	svc := services.NewPatchArticleServices(ad, jd, rv, ade, pc, ade, al, config.GetBool("REQUIRE_IF_MATCH", false))
+	code, res := svc.Patch(r.PathValue("uuid"), r.Header.Get("If-Match"))
+	setArticleETag(w, res)
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(res)
+}
+
+// Put replaces an article
+//
+//	@Summary		replaces an article
+//	@Description	replaces title, content and tags of an article, status is kept. The change is recorded in article history. With If-Match the article is only changed while it is at that version; If-Match is required when REQUIRE_IF_MATCH is set
+//	@Tags			article
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header		string					true	"Basic [token]. Token obtained from log in endpoint"
+//	@Param			If-Match		header		string					false	"ETag of the version the change is based on"
+//	@Param			request			body		models.PutArticleRequest	true	"Request of Replacing Article Object"
+//	@Param			uuid			path		string					true	"UUID of article"
+//	@Success		200				{object}	models.Response			"ok"
+//	@Header			200				{string}	ETag					"new version of the article"
+//	@Failure		400				{object}	models.Response			"bad request"
+//	@Failure		403				{object}	models.Response			"not the writer of the article"
+//	@Failure		404				{object}	models.Response			"not found"
+//	@Failure		412				{object}	models.Response			"article changed since the version of If-Match"
+//	@Failure		423				{object}	models.Response			"article locked by another user"
+//	@Failure		428				{object}	models.Response			"If-Match required"
+//	@Failure		500				{object}	models.Response			"internal server error"
+//	@Router			/articles/{uuid} [put]
+func (h ArticleHandler) Put(w http.ResponseWriter, r *http.Request) {
+	ad := r.Context().Value(models.AuthVerifyCtxKey)
+	jd := json.NewDecoder(r.Body)
+	rv := validator.New(validator.WithRequiredStructEnabled())
+	ade := respositories.NewArticleRepository(h.db)
+	pc := respositories.NewRoleRepository(h.db)
+	al := respositories.NewArticleLockRepository(h.db)
+
+	svc := services.NewPutArticleServices(ad, jd, rv, ade, pc, ade, al, config.GetBool("REQUIRE_IF_MATCH", false))
+	code, res := svc.Put(r.PathValue("uuid"), r.Header.Get("If-Match"))
+	setArticleETag(w, res)
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(res)
+}
+
+// Transition moves an article through the editorial workflow
+//
+//	@Summary		moves an article through the editorial workflow
+//	@Description	performs a workflow transition on an article: submit (DRAFT to IN_REVIEW, by its writer or an editor), approve (IN_REVIEW to APPROVED, needs article:review), reject (IN_REVIEW or APPROVED back to DRAFT with a required comment, needs article:review, clearing publish_at), publish (APPROVED to PUBLISHED, needs article:publish) and archive (PUBLISHED to ARCHIVED, needs article:publish). The transition is recorded in article history with its actor and comment
+//	@Tags			article
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header		string							true	"Basic [token]. Token obtained from log in endpoint"
+//	@Param			request			body		models.TransitionArticleRequest	true	"Request of Transitioning Article Object"
+//	@Param			uuid			path		string							true	"UUID of article"
+//	@Success		200				{object}	models.Response					"ok"
+//	@Failure		400				{object}	models.Response					"bad request"
+//	@Failure		403				{object}	models.Response					"not allowed to perform the transition"
+//	@Failure		404				{object}	models.Response					"not found"
+//	@Failure		409				{object}	models.Response					"transition not allowed from the current status"
+//	@Failure		423				{object}	models.Response					"article locked by another user"
+//	@Failure		500				{object}	models.Response					"internal server error"
+//	@Router			/articles/{uuid}/transitions [post]
+func (h ArticleHandler) Transition(w http.ResponseWriter, r *http.Request) {
+	ad := r.Context().Value(models.AuthVerifyCtxKey)
+	jd := json.NewDecoder(r.Body)
+	rv := validator.New(validator.WithRequiredStructEnabled())
+	ade := respositories.NewArticleRepository(h.db)
+	pc := respositories.NewRoleRepository(h.db)
+	al := respositories.NewArticleLockRepository(h.db)
+
+	svc := services.NewTransitionArticleServices(ad, jd, rv, ade, pc, ade, al)
+	code, res := svc.Transition(r.PathValue("uuid"))
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(res)
+}
+
+// Related lists articles related to an article
+//
+//	@Summary		lists articles related to an article
+//	@Description	lists other articles ranked by how related they are to an article by their tags, sharing a rare tag counting more than sharing a common one. Each article carries its score as TagRelationshipScore
+//	@Tags			article
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization		header		string			true	"Basic [token]. Token obtained from log in endpoint"
+//	@Param			uuid				path		string			true	"UUID of article"
+//	@Param			status				query		string			false	"only articles of this status"	default(PUBLISHED)
+//	@Param			exclude_same_writer	query		bool			false	"leave out articles of the same writer"
+//	@Param			limit				query		int				false	"number of articles, at most 50"	default(10)
+//	@Success		200					{object}	models.Response	"ok"
+//	@Failure		400					{object}	models.Response	"bad request"
+//	@Failure		403					{object}	models.Response	"not allowed to read the article"
+//	@Failure		404					{object}	models.Response	"not found"
+//	@Failure		500					{object}	models.Response	"internal server error"
+//	@Router			/articles/{uuid}/related [get]
+func (h ArticleHandler) Related(w http.ResponseWriter, r *http.Request) {
+	ad := r.Context().Value(models.AuthVerifyCtxKey)
+	ar := respositories.NewArticleRepository(h.db)
+	pc := respositories.NewRoleRepository(h.db)
+	rl := respositories.NewArticleRelationRepository(h.db)
+
+	svc := services.NewRelatedArticleServices(ad, ar, pc, rl)
+	code, res := svc.List(r.PathValue("uuid"), r.URL.Query())
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(res)
+}
+
+// ListTransitions lists transitions available on an article
+//
+//	@Summary		lists transitions available on an article
+//	@Description	lists the workflow transitions the user may perform on an article from its current status
+//	@Tags			article
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header		string			true	"Basic [token]. Token obtained from log in endpoint"
+//	@Param			uuid			path		string			true	"UUID of article"
+//	@Success		200				{object}	models.Response	"ok"
+//	@Failure		400				{object}	models.Response	"bad request"
+//	@Failure		403				{object}	models.Response	"not allowed to read the article"
+//	@Failure		404				{object}	models.Response	"not found"
+//	@Router			/articles/{uuid}/transitions [get]
+func (h ArticleHandler) ListTransitions(w http.ResponseWriter, r *http.Request) {
+	ad := r.Context().Value(models.AuthVerifyCtxKey)
+	ar := respositories.NewArticleRepository(h.db)
+	pc := respositories.NewRoleRepository(h.db)
 
-	svc := services.NewPatchArticleServices(ad, jd, rv, ade, hr)
-	id, _ := strconv.Atoi(r.PathValue("id"))
-	code, res := svc.Patch(int64(id))
+	svc := services.NewListArticleTransitionServices(ad, ar, pc)
+	code, res := svc.List(r.PathValue("uuid"))
 	w.WriteHeader(code)
 	json.NewEncoder(w).Encode(res)
 }
+
+// Schedule schedules publishing of an article
+//
+//	@Summary		schedules publishing of an article
+//	@Description	sets when an article is published and unpublished. An APPROVED article is published once publish_at has passed and a PUBLISHED article is archived once unpublish_at has passed, recorded in article history as scheduled_publish and scheduled_unpublish. Times left out clear the schedule. Needs article:publish
+//	@Tags			article
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header		string							true	"Basic [token]. Token obtained from log in endpoint"
+//	@Param			request			body		models.ScheduleArticleRequest	true	"Request of Scheduling Article Object"
+//	@Param			uuid			path		string							true	"UUID of article"
+//	@Success		200				{object}	models.Response					"ok"
+//	@Failure		400				{object}	models.Response					"bad request"
+//	@Failure		403				{object}	models.Response					"not allowed to schedule the article"
+//	@Failure		404				{object}	models.Response					"not found"
+//	@Failure		409				{object}	models.Response					"article is archived"
+//	@Failure		423				{object}	models.Response					"article locked by another user"
+//	@Failure		500				{object}	models.Response					"internal server error"
+//	@Router			/articles/{uuid}/schedule [put]
+func (h ArticleHandler) Schedule(w http.ResponseWriter, r *http.Request) {
+	ad := r.Context().Value(models.AuthVerifyCtxKey)
+	jd := json.NewDecoder(r.Body)
+	ade := respositories.NewArticleRepository(h.db)
+	pc := respositories.NewRoleRepository(h.db)
+	al := respositories.NewArticleLockRepository(h.db)
+
+	svc := services.NewScheduleArticleServices(ad, jd, ade, pc, ade, al)
+	code, res := svc.Schedule(r.PathValue("uuid"))
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(res)
+}
+
+// Lock locks an article for editing
+//
+//	@Summary		locks an article for editing
+//	@Description	locks an article so only the user may patch, replace or delete it until the lock expires after ARTICLE_LOCK_TTL. Locking it again renews the lock, clients send it as a heartbeat while editing. Users allowed to update the article may lock it
+//	@Tags			article
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header		string			true	"Basic [token]. Token obtained from log in endpoint"
+//	@Param			uuid			path		string			true	"UUID of article"
+//	@Success		200				{object}	models.Response	"ok"
+//	@Failure		400				{object}	models.Response	"bad request"
+//	@Failure		403				{object}	models.Response	"not allowed to update the article"
+//	@Failure		404				{object}	models.Response	"not found"
+//	@Failure		423				{object}	models.Response	"article locked by another user"
+//	@Failure		500				{object}	models.Response	"internal server error"
+//	@Router			/articles/{uuid}/lock [post]
+func (h ArticleHandler) Lock(w http.ResponseWriter, r *http.Request) {
+	ad := r.Context().Value(models.AuthVerifyCtxKey)
+	ar := respositories.NewArticleRepository(h.db)
+	pc := respositories.NewRoleRepository(h.db)
+	al := respositories.NewArticleLockRepository(h.db)
+
+	svc := services.NewLockArticleServices(ad, ar, pc, al, config.GetPositiveDuration("ARTICLE_LOCK_TTL", 5*time.Minute))
+	code, res := svc.Lock(r.PathValue("uuid"))
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(res)
+}
+
+// Unlock unlocks an article
+//
+//	@Summary		unlocks an article
+//	@Description	releases the lock of an article held by the user. The lock of another user is refused unless force is true, which needs article:unlock
+//	@Tags			article
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header		string			true	"Basic [token]. Token obtained from log in endpoint"
+//	@Param			uuid			path		string			true	"UUID of article"
+//	@Param			force			query		bool			false	"release the lock of another user"
+//	@Success		200				{object}	models.Response	"ok"
+//	@Failure		400				{object}	models.Response	"bad request"
+//	@Failure		403				{object}	models.Response	"not allowed to force unlock"
+//	@Failure		404				{object}	models.Response	"article not found or not locked"
+//	@Failure		423				{object}	models.Response	"article locked by another user"
+//	@Failure		500				{object}	models.Response	"internal server error"
+//	@Router			/articles/{uuid}/lock [delete]
+func (h ArticleHandler) Unlock(w http.ResponseWriter, r *http.Request) {
+	ad := r.Context().Value(models.AuthVerifyCtxKey)
+	ar := respositories.NewArticleRepository(h.db)
+	pc := respositories.NewRoleRepository(h.db)
+	al := respositories.NewArticleLockRepository(h.db)
+
+	svc := services.NewUnlockArticleServices(ad, ar, pc, al)
+	force, _ := strconv.ParseBool(r.URL.Query().Get("force"))
+	code, res := svc.Unlock(r.PathValue("uuid"), force)
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(res)
+}
+
+// setArticleETag sets the ETag header to the version of the article of a response, if it carries one
+func setArticleETag(w http.ResponseWriter, res models.Response) {
+	if article, ok := res.Data.(models.Article); ok {
+		w.Header().Set("ETag", article.ETag())
+	}
+}
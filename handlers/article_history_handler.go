@@ -3,7 +3,6 @@ package handlers
 import (
 	"encoding/json"
 	"net/http"
-	"strconv"
 
 	"github.com/herdiansc/go-cms/models"
 	"github.com/herdiansc/go-cms/respositories"
@@ -31,18 +30,19 @@ func NewArticleHistoryHandler(db *gorm.DB) ArticleHistoryHandler {
 //	@Accept			json
 //	@Produce		json
 //	@Param			Authorization	header		string			true	"Basic [token]. Token obtained from log in endpoint"
-//	@Param			id				path		integer			true	"ID of article history"
+//	@Param			uuid			path		string			true	"UUID of article history"
 //	@Success		200				{object}	models.Response	"ok"
 //	@Failure		400				{object}	models.Response	"bad request"
 //	@Failure		500				{object}	models.Response	"internal server error"
-//	@Router			/article-histories/{id} [get]
+//	@Router			/article-histories/{uuid} [get]
 func (h ArticleHistoryHandler) Detail(w http.ResponseWriter, r *http.Request) {
 	ad := r.Context().Value(models.AuthVerifyCtxKey)
+	ar := respositories.NewArticleRepository(h.db)
+	pc := respositories.NewRoleRepository(h.db)
 	ac := respositories.NewArticleHistoryRepository(h.db)
 
-	svc := services.NewDetailArticleHistoryServices(ad, ac)
-	id, _ := strconv.Atoi(r.PathValue("id"))
-	code, res := svc.GetDetailByUUID(int64(id))
+	svc := services.NewDetailArticleHistoryServices(ad, ar, pc, ac)
+	code, res := svc.GetDetailByUUID(r.PathValue("uuid"))
 	w.WriteHeader(code)
 	json.NewEncoder(w).Encode(res)
 }
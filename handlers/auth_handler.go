@@ -6,6 +6,7 @@ import (
 
 	"github.com/go-playground/validator/v10"
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/herdiansc/go-cms/config"
 	"github.com/herdiansc/go-cms/models"
 	"github.com/herdiansc/go-cms/respositories"
 	"github.com/herdiansc/go-cms/services"
@@ -15,20 +16,22 @@ import (
 
 // AuthHandler struct
 type AuthHandler struct {
-	db *gorm.DB
+	db   *gorm.DB
+	keys config.KeySet
 }
 
 // NewAuthHandler inits AuthHandler
-func NewAuthHandler(db *gorm.DB) AuthHandler {
+func NewAuthHandler(db *gorm.DB, keys config.KeySet) AuthHandler {
 	return AuthHandler{
-		db: db,
+		db:   db,
+		keys: keys,
 	}
 }
 
 // Register saves an auth
 //
 //	@Summary		Add a new auth to database
-//	@Description	Add a new auth to database
+//	@Description	Add a new auth to database. Only WRITER (default) and VIEWER roles can be self-assigned
 //	@Tags			auth
 //	@x-order		1
 //	@Accept			json
@@ -36,6 +39,7 @@ func NewAuthHandler(db *gorm.DB) AuthHandler {
 //	@Param			request	body		models.RegisterRequest	true	"Request body of registration"
 //	@Success		200		{object}	models.Response			"ok"
 //	@Failure		400		{object}	models.Response			"bad request"
+//	@Failure		403		{object}	models.Response			"role cannot be self-assigned"
 //	@Failure		500		{object}	models.Response			"internal server error"
 //	@Router			/auth/register [post]
 func (h AuthHandler) Register(w http.ResponseWriter, r *http.Request) {
@@ -69,18 +73,71 @@ func (h AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
 	ph := services.NewHashingCompareService(bcrypt.CompareHashAndPassword)
 	js := jwt.NewWithClaims
 	ac := respositories.NewAuthRepository(h.db)
+	tr := respositories.NewTokenRepository(h.db)
 
-	svc := services.NewLoginServices(jd, rv, ph, js, ac)
+	svc := services.NewLoginServices(jd, rv, ph, js, h.keys, ac, tr)
 	code, res := svc.Login()
 	w.WriteHeader(code)
 	json.NewEncoder(w).Encode(res)
 }
 
+// Refresh refreshes tokens
+//
+//	@Summary		Exchange a refresh token for new tokens
+//	@Description	Exchange a refresh token for a new access token and a rotated refresh token. Reusing a rotated refresh token revokes its whole family
+//	@x-order		3
+//	@Tags			auth
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		models.RefreshTokenRequest	true	"Request of refreshing token"
+//	@Success		200		{object}	models.Response				"ok"
+//	@Failure		400		{object}	models.Response				"bad request"
+//	@Failure		401		{object}	models.Response				"invalid refresh token"
+//	@Failure		500		{object}	models.Response				"internal server error"
+//	@Router			/auth/refresh [post]
+func (h AuthHandler) Refresh(w http.ResponseWriter, r *http.Request) {
+	jd := json.NewDecoder(r.Body)
+	rv := validator.New(validator.WithRequiredStructEnabled())
+	js := jwt.NewWithClaims
+	tr := respositories.NewTokenRepository(h.db)
+	af := respositories.NewAuthRepository(h.db)
+
+	svc := services.NewRefreshTokenServices(jd, rv, js, h.keys, tr, af)
+	code, res := svc.Refresh()
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(res)
+}
+
+// Logout logout
+//
+//	@Summary		Revoke the current access token
+//	@Description	Revoke the current access token and, when given, the family of the refresh token
+//	@x-order		4
+//	@Tags			auth
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header		string					true	"Basic [token]. Token obtained from log in endpoint"
+//	@Param			request			body		models.LogoutRequest	false	"Request of logout"
+//	@Success		200				{object}	models.Response			"ok"
+//	@Failure		400				{object}	models.Response			"bad request"
+//	@Failure		500				{object}	models.Response			"internal server error"
+//	@Router			/auth/logout [post]
+func (h AuthHandler) Logout(w http.ResponseWriter, r *http.Request) {
+	ad := r.Context().Value(models.AuthVerifyCtxKey)
+	jd := json.NewDecoder(r.Body)
+	tr := respositories.NewTokenRepository(h.db)
+
+	svc := services.NewLogoutServices(ad, jd, tr)
+	code, res := svc.Logout()
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(res)
+}
+
 // GetProfile   gets profile
 //
 //	@Summary		Get profile of currently logged in user
 //	@Description	Get profile of currently logged in user
-//	@x-order		3
+//	@x-order		5
 //	@Tags			auth
 //	@Accept			json
 //	@Produce		json
@@ -97,3 +154,19 @@ func (h AuthHandler) GetProfile(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(code)
 	json.NewEncoder(w).Encode(res)
 }
+
+// JWKS publishes the public verification keys
+//
+//	@Summary		Get public keys used to verify issued tokens
+//	@Description	Get the JSON Web Key Set of every asymmetric key accepted for verification, so other services can verify tokens without sharing a secret
+//	@x-order		6
+//	@Tags			auth
+//	@Produce		json
+//	@Success		200	{object}	models.JWKSet	"ok"
+//	@Router			/.well-known/jwks.json [get]
+func (h AuthHandler) JWKS(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "public, max-age=300")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(h.keys.JWKS())
+}
@@ -0,0 +1,105 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+
+	"github.com/herdiansc/go-cms/models"
+	"github.com/herdiansc/go-cms/respositories"
+	"github.com/herdiansc/go-cms/services"
+	"gorm.io/gorm"
+)
+
+// PublicHandler struct, it serves published content without authentication
+type PublicHandler struct {
+	db *gorm.DB
+}
+
+// NewPublicHandler inits PublicHandler
+func NewPublicHandler(db *gorm.DB) PublicHandler {
+	return PublicHandler{
+		db: db,
+	}
+}
+
+// ListArticles lists published articles
+//
+//	@Summary		lists published articles
+//	@Description	lists published articles without authentication, leaving out uuids, writer and workflow fields. Filter by title, created_at or updated_at with an optional operator, e.g. title=like:go, and by tag, tags_any, tags_all, category, created_from and created_to. Sort by title, created_at or updated_at. Responses can be cached for PUBLIC_CACHE_MAX_AGE and revalidated with their ETag
+//	@Tags			public
+//	@Accept			json
+//	@Produce		json
+//	@Param			page			query		int				false	"page number"		default(1)
+//	@Param			limit			query		int				false	"limit per page"	default(10)
+//	@Param			orderField		query		string			false	"order field"		default(created_at)
+//	@Param			orderDir		query		string			false	"order dir"			default(desc)
+//	@Param			cursor			query		string			false	"next_cursor of the previous page, empty for the first page, switches to cursor pagination"
+//	@Param			tag				query		string			false	"only articles having this tag, can be repeated to require each"
+//	@Param			category		query		string			false	"only articles having this tag or one of its descendants"
+//	@Param			If-None-Match	header		string			false	"ETag of a cached response"
+//	@Success		200				{object}	models.Response	"ok"
+//	@Success		304				{string}	string			"not modified"
+//	@Failure		400				{object}	models.Response	"bad request"
+//	@Failure		500				{object}	models.Response	"internal server error"
+//	@Router			/public/articles [get]
+func (h PublicHandler) ListArticles(w http.ResponseWriter, r *http.Request) {
+	al := respositories.NewArticleRepository(h.db)
+
+	svc := services.NewListPublicArticleServices(al)
+	code, res := svc.List(r.URL.Query())
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(res)
+}
+
+// DetailArticle details a published article
+//
+//	@Summary		details a published article
+//	@Description	details a published article by slug without authentication, leaving out ids, writer and workflow fields. A former slug redirects permanently to the current one. Responses can be cached for PUBLIC_CACHE_MAX_AGE and revalidated with their ETag
+//	@Tags			public
+//	@Accept			json
+//	@Produce		json
+//	@Param			slug			path		string			true	"slug of an article"
+//	@Param			If-None-Match	header		string			false	"ETag of a cached response"
+//	@Success		200				{object}	models.Response	"ok"
+//	@Success		301				{object}	models.Response	"moved to the slug in Location"
+//	@Success		304				{string}	string			"not modified"
+//	@Failure		404				{object}	models.Response	"not found"
+//	@Router			/public/articles/{slug} [get]
+func (h PublicHandler) DetailArticle(w http.ResponseWriter, r *http.Request) {
+	af := respositories.NewArticleRepository(h.db)
+
+	svc := services.NewDetailPublicArticleServices(af)
+	code, res := svc.GetDetailBySlug(r.PathValue("slug"))
+	if moved, ok := res.Data.(models.MovedArticle); ok {
+		w.Header().Set("Location", "/public/articles/"+url.PathEscape(moved.Slug))
+	}
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(res)
+}
+
+// ListTags lists the tags of published articles
+//
+//	@Summary		lists the tags of published articles
+//	@Description	lists the tags of published articles with the number of them without authentication. Filter by title with an optional operator, e.g. title=like:go. Sort by title or usage_count. Responses can be cached for PUBLIC_CACHE_MAX_AGE and revalidated with their ETag
+//	@Tags			public
+//	@Accept			json
+//	@Produce		json
+//	@Param			page			query		int				false	"page number"		default(1)
+//	@Param			limit			query		int				false	"limit per page"	default(10)
+//	@Param			orderField		query		string			false	"order field"		default(usage_count)
+//	@Param			orderDir		query		string			false	"order dir"			default(desc)
+//	@Param			If-None-Match	header		string			false	"ETag of a cached response"
+//	@Success		200				{object}	models.Response	"ok"
+//	@Success		304				{string}	string			"not modified"
+//	@Failure		400				{object}	models.Response	"bad request"
+//	@Failure		500				{object}	models.Response	"internal server error"
+//	@Router			/public/tags [get]
+func (h PublicHandler) ListTags(w http.ResponseWriter, r *http.Request) {
+	tl := respositories.NewTagRepository(h.db)
+
+	svc := services.NewListPublicTagServices(tl)
+	code, res := svc.List(r.URL.Query())
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(res)
+}
@@ -6,6 +6,7 @@ import (
 	"strconv"
 
 	"github.com/go-playground/validator/v10"
+	"github.com/herdiansc/go-cms/config"
 	"github.com/herdiansc/go-cms/models"
 	"github.com/herdiansc/go-cms/respositories"
 	"github.com/herdiansc/go-cms/services"
@@ -43,7 +44,7 @@ func (h TagHandler) Create(w http.ResponseWriter, r *http.Request) {
 	rv := validator.New(validator.WithRequiredStructEnabled())
 	ac := respositories.NewTagRepository(h.db)
 
-	svc := services.NewCreateTagServices(ad, jd, rv, ac)
+	svc := services.NewCreateTagServices(ad, jd, rv, ac, ac)
 	code, res := svc.Create()
 	w.WriteHeader(code)
 	json.NewEncoder(w).Encode(res)
@@ -52,14 +53,14 @@ func (h TagHandler) Create(w http.ResponseWriter, r *http.Request) {
 // List lists tags
 //
 //	@Summary		lists tags
-//	@Description	lists tags from the database
+//	@Description	lists tags from the database. Filter by uuid, title, parent (uuid of a tag) or created_at with an optional operator, e.g. title=like:go. Sort by title, created_at or usage_count. Invalid params are a bad request
 //	@Tags			tag
 //	@Accept			json
 //	@Produce		json
 //	@Param			Authorization	header		string			true	"Basic [token]. Token obtained from log in endpoint"
 //	@Param			page			query		int				false	"page number"		default(1)
 //	@Param			limit			query		int				false	"limit per page"	default(10)
-//	@Param			orderField		query		string			false	"order field"		default(id)
+//	@Param			orderField		query		string			false	"order field"		default(created_at)
 //	@Param			orderDir		query		string			false	"order dir"			default(desc)
 //	@Success		200				{object}	models.Response	"ok"
 //	@Failure		400				{object}	models.Response	"bad request"
@@ -75,6 +76,76 @@ func (h TagHandler) List(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(res)
 }
 
+// Trending lists trending tags
+//
+//	@Summary		lists trending tags
+//	@Description	lists the highest scored tags of a window. Scores are recomputed periodically from tagging and publishing of tagged articles within the window, recent events counting more
+//	@Tags			tag
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header		string			true	"Basic [token]. Token obtained from log in endpoint"
+//	@Param			window			query		string			false	"one of the configured windows, e.g. 1d, 7d or 30d, defaults to the first"
+//	@Param			limit			query		int				false	"number of tags, at most 100"	default(20)
+//	@Success		200				{object}	models.Response	"ok"
+//	@Failure		400				{object}	models.Response	"bad request"
+//	@Failure		500				{object}	models.Response	"internal server error"
+//	@Router			/tags/trending [get]
+func (h TagHandler) Trending(w http.ResponseWriter, r *http.Request) {
+	ad := r.Context().Value(models.AuthVerifyCtxKey)
+	tl := respositories.NewTagTrendingRepository(h.db)
+
+	svc := services.NewTrendingTagServices(ad, config.TrendingWindows(), tl)
+	code, res := svc.List(r.URL.Query())
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(res)
+}
+
+// Tree lists tags as a tree of categories
+//
+//	@Summary		lists tags as a tree of categories
+//	@Description	lists all tags nested under their parent tags, each with its usage count and subcategories sorted by title
+//	@Tags			tag
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header		string			true	"Basic [token]. Token obtained from log in endpoint"
+//	@Success		200				{object}	models.Response	"ok"
+//	@Failure		400				{object}	models.Response	"bad request"
+//	@Failure		500				{object}	models.Response	"internal server error"
+//	@Router			/tags/tree [get]
+func (h TagHandler) Tree(w http.ResponseWriter, r *http.Request) {
+	ad := r.Context().Value(models.AuthVerifyCtxKey)
+	tl := respositories.NewTagRepository(h.db)
+
+	svc := services.NewTreeTagServices(ad, tl)
+	code, res := svc.Tree()
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(res)
+}
+
+// Suggest suggests tags
+//
+//	@Summary		suggests tags
+//	@Description	suggests tags starting with q or similar to it, most used first. Meant for autocompleting tags while writing an article
+//	@Tags			tag
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header		string			true	"Basic [token]. Token obtained from log in endpoint"
+//	@Param			q				query		string			true	"typed tag"
+//	@Param			limit			query		int				false	"number of tags, at most 50"	default(10)
+//	@Success		200				{object}	models.Response	"ok"
+//	@Failure		400				{object}	models.Response	"bad request"
+//	@Failure		500				{object}	models.Response	"internal server error"
+//	@Router			/tags/suggest [get]
+func (h TagHandler) Suggest(w http.ResponseWriter, r *http.Request) {
+	ad := r.Context().Value(models.AuthVerifyCtxKey)
+	ts := respositories.NewTagRepository(h.db)
+
+	svc := services.NewSuggestTagServices(ad, ts)
+	code, res := svc.Suggest(r.URL.Query())
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(res)
+}
+
 // Detail details a tag
 //
 //	@Summary		details a tag
@@ -83,18 +154,127 @@ func (h TagHandler) List(w http.ResponseWriter, r *http.Request) {
 //	@Accept			json
 //	@Produce		json
 //	@Param			Authorization	header		string			true	"Basic [token]. Token obtained from log in endpoint"
-//	@Param			id				path		integer			true	"ID of a tag"
+//	@Param			uuid			path		string			true	"UUID of a tag"
 //	@Success		200				{object}	models.Response	"ok"
 //	@Failure		400				{object}	models.Response	"bad request"
 //	@Failure		500				{object}	models.Response	"internal server error"
-//	@Router			/tags/{id} [get]
+//	@Router			/tags/{uuid} [get]
 func (h TagHandler) Detail(w http.ResponseWriter, r *http.Request) {
 	ad := r.Context().Value(models.AuthVerifyCtxKey)
 	ac := respositories.NewTagRepository(h.db)
 
 	svc := services.NewDetailTagServices(ad, ac)
-	id, _ := strconv.Atoi(r.PathValue("id"))
-	code, res := svc.GetDetailByUUID(int64(id))
+	code, res := svc.GetDetailByUUID(r.PathValue("uuid"))
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(res)
+}
+
+// Rename renames a tag
+//
+//	@Summary		renames a tag
+//	@Description	renames a tag, the title is trimmed and lower-cased like on create and must not be taken by another tag
+//	@Tags			tag
+//	@Accept			json
+//	@Produce		json
+//	@Param			request			body		models.RenameTagRequest	true	"Request of Renaming Tag Object"
+//	@Param			Authorization	header		string					true	"Basic [token]. Token obtained from log in endpoint"
+//	@Param			uuid			path		string					true	"UUID of a tag"
+//	@Success		200				{object}	models.Response			"ok"
+//	@Failure		400				{object}	models.Response			"bad request"
+//	@Failure		404				{object}	models.Response			"not found"
+//	@Failure		409				{object}	models.Response			"title taken by another tag"
+//	@Failure		500				{object}	models.Response			"internal server error"
+//	@Router			/tags/{uuid} [patch]
+func (h TagHandler) Rename(w http.ResponseWriter, r *http.Request) {
+	ad := r.Context().Value(models.AuthVerifyCtxKey)
+	jd := json.NewDecoder(r.Body)
+	rv := validator.New(validator.WithRequiredStructEnabled())
+	tr := respositories.NewTagRepository(h.db)
+
+	svc := services.NewRenameTagServices(ad, jd, rv, tr, tr)
+	code, res := svc.Rename(r.PathValue("uuid"))
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(res)
+}
+
+// Move moves a tag under another
+//
+//	@Summary		moves a tag under another
+//	@Description	changes the parent category of a tag, its subcategories move along. A null parent_uuid makes it a root category. A tag cannot be moved under itself or its descendants
+//	@Tags			tag
+//	@Accept			json
+//	@Produce		json
+//	@Param			request			body		models.MoveTagRequest	true	"Request of Moving Tag Object"
+//	@Param			Authorization	header		string					true	"Basic [token]. Token obtained from log in endpoint"
+//	@Param			uuid			path		string					true	"UUID of a tag"
+//	@Success		200				{object}	models.Response			"ok"
+//	@Failure		400				{object}	models.Response			"bad request"
+//	@Failure		404				{object}	models.Response			"tag or parent not found"
+//	@Failure		409				{object}	models.Response			"parent is the tag or one of its descendants"
+//	@Failure		500				{object}	models.Response			"internal server error"
+//	@Router			/tags/{uuid}/parent [put]
+func (h TagHandler) Move(w http.ResponseWriter, r *http.Request) {
+	ad := r.Context().Value(models.AuthVerifyCtxKey)
+	jd := json.NewDecoder(r.Body)
+	tr := respositories.NewTagRepository(h.db)
+
+	svc := services.NewMoveTagServices(ad, jd, tr, tr)
+	code, res := svc.Move(r.PathValue("uuid"))
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(res)
+}
+
+// Merge merges a tag into another
+//
+//	@Summary		merges a tag into another
+//	@Description	moves the articles of a tag to the target tag and deletes the tag, articles already tagged with the target keep a single link. Each article of the tag gets a new history version
+//	@Tags			tag
+//	@Accept			json
+//	@Produce		json
+//	@Param			request			body		models.MergeTagRequest	true	"Request of Merging Tag Object"
+//	@Param			Authorization	header		string					true	"Basic [token]. Token obtained from log in endpoint"
+//	@Param			uuid			path		string					true	"UUID of the tag to merge"
+//	@Success		200				{object}	models.Response			"ok"
+//	@Failure		400				{object}	models.Response			"bad request"
+//	@Failure		404				{object}	models.Response			"not found"
+//	@Failure		409				{object}	models.Response			"tag or target merged or deleted meanwhile"
+//	@Failure		500				{object}	models.Response			"internal server error"
+//	@Router			/tags/{uuid}/merge [post]
+func (h TagHandler) Merge(w http.ResponseWriter, r *http.Request) {
+	ad := r.Context().Value(models.AuthVerifyCtxKey)
+	jd := json.NewDecoder(r.Body)
+	rv := validator.New(validator.WithRequiredStructEnabled())
+	tr := respositories.NewTagRepository(h.db)
+
+	svc := services.NewMergeTagServices(ad, jd, rv, tr, tr)
+	code, res := svc.Merge(r.PathValue("uuid"))
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(res)
+}
+
+// Delete deletes a tag
+//
+//	@Summary		deletes a tag
+//	@Description	deletes a tag. A tag still used by articles is refused unless force is true, which removes it from them with a new history version of each
+//	@Tags			tag
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header		string			true	"Basic [token]. Token obtained from log in endpoint"
+//	@Param			uuid			path		string			true	"UUID of a tag"
+//	@Param			force			query		bool			false	"delete even when used by articles"
+//	@Success		200				{object}	models.Response	"ok"
+//	@Failure		400				{object}	models.Response	"bad request"
+//	@Failure		404				{object}	models.Response	"not found"
+//	@Failure		409				{object}	models.Response	"tag in use"
+//	@Failure		500				{object}	models.Response	"internal server error"
+//	@Router			/tags/{uuid} [delete]
+func (h TagHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	ad := r.Context().Value(models.AuthVerifyCtxKey)
+	tr := respositories.NewTagRepository(h.db)
+
+	svc := services.NewDeleteTagServices(ad, tr, tr)
+	force, _ := strconv.ParseBool(r.URL.Query().Get("force"))
+	code, res := svc.Delete(r.PathValue("uuid"), force)
 	w.WriteHeader(code)
 	json.NewEncoder(w).Encode(res)
 }
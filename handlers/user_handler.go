@@ -0,0 +1,52 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/herdiansc/go-cms/models"
+	"github.com/herdiansc/go-cms/respositories"
+	"github.com/herdiansc/go-cms/services"
+	"gorm.io/gorm"
+)
+
+// UserHandler struct
+type UserHandler struct {
+	db *gorm.DB
+}
+
+// NewUserHandler inits UserHandler
+func NewUserHandler(db *gorm.DB) UserHandler {
+	return UserHandler{
+		db: db,
+	}
+}
+
+// ChangeRole changes role of a user
+//
+//	@Summary		changes role of a user
+//	@Description	changes role of a user, requires user:manage permission
+//	@Tags			user
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header		string						true	"Basic [token]. Token obtained from log in endpoint"
+//	@Param			request			body		models.ChangeRoleRequest	true	"Request of Changing Role"
+//	@Param			uuid			path		string						true	"UUID of user"
+//	@Success		200				{object}	models.Response				"ok"
+//	@Failure		400				{object}	models.Response				"bad request"
+//	@Failure		403				{object}	models.Response				"forbidden"
+//	@Failure		404				{object}	models.Response				"not found"
+//	@Router			/users/{uuid}/role [patch]
+func (h UserHandler) ChangeRole(w http.ResponseWriter, r *http.Request) {
+	ad := r.Context().Value(models.AuthVerifyCtxKey)
+	jd := json.NewDecoder(r.Body)
+	rv := validator.New(validator.WithRequiredStructEnabled())
+	rf := respositories.NewRoleRepository(h.db)
+	au := respositories.NewAuthRepository(h.db)
+
+	svc := services.NewChangeRoleServices(ad, jd, rv, rf, au)
+	code, res := svc.ChangeRole(r.PathValue("uuid"))
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(res)
+}
@@ -9,18 +9,22 @@ import (
 	"os"
 	"testing"
 
+	"github.com/herdiansc/go-cms/config"
 	"github.com/herdiansc/go-cms/handlers"
 	"github.com/herdiansc/go-cms/middlewares"
 	"github.com/herdiansc/go-cms/models"
+	"github.com/herdiansc/go-cms/routes"
 	"github.com/hhkbp2/testify/require"
 	"gorm.io/gorm"
 )
 
 var testDBInstance *gorm.DB
+var testKeySet config.KeySet
 
 func TestMain(m *testing.M) {
 	testDB := SetupTestDatabase()
 	testDBInstance = testDB.DB
+	testKeySet = config.SetupKeySet()
 	defer testDB.TearDown()
 
 	os.Exit(m.Run())
@@ -37,7 +41,7 @@ func TestAuthRegister(t *testing.T) {
 	require.NoError(t, err)
 
 	rr := httptest.NewRecorder()
-	handler := http.HandlerFunc(handlers.NewAuthHandler(testDBInstance).Register)
+	handler := http.HandlerFunc(handlers.NewAuthHandler(testDBInstance, testKeySet).Register)
 	handler.ServeHTTP(rr, req)
 
 	if rr.Code != http.StatusCreated {
@@ -55,7 +59,7 @@ func TestAuthLogin(t *testing.T) {
 	require.NoError(t, err)
 
 	rr := httptest.NewRecorder()
-	handler := http.HandlerFunc(handlers.NewAuthHandler(testDBInstance).Login)
+	handler := http.HandlerFunc(handlers.NewAuthHandler(testDBInstance, testKeySet).Login)
 	handler.ServeHTTP(rr, req)
 
 	if rr.Code != http.StatusOK {
@@ -73,7 +77,7 @@ func TestAuthProfile(t *testing.T) {
 	require.NoError(t, err)
 
 	rr := httptest.NewRecorder()
-	handler := http.HandlerFunc(handlers.NewAuthHandler(testDBInstance).Login)
+	handler := http.HandlerFunc(handlers.NewAuthHandler(testDBInstance, testKeySet).Login)
 	handler.ServeHTTP(rr, req)
 
 	if rr.Code != http.StatusOK {
@@ -91,7 +95,7 @@ func TestAuthProfile(t *testing.T) {
 	req.Header.Set("Authorization", fmt.Sprintf("Basic %s", token))
 
 	rr = httptest.NewRecorder()
-	privateHandler := middlewares.Authenticate(http.HandlerFunc(handlers.NewAuthHandler(testDBInstance).GetProfile))
+	privateHandler := middlewares.NewAuthMiddleware(testDBInstance, testKeySet).Authenticate(http.HandlerFunc(handlers.NewAuthHandler(testDBInstance, testKeySet).GetProfile))
 
 	privateHandler.ServeHTTP(rr, req)
 
@@ -111,7 +115,7 @@ func TestArticleCreate(t *testing.T) {
 	require.NoError(t, err)
 
 	rr := httptest.NewRecorder()
-	handler := http.HandlerFunc(handlers.NewAuthHandler(testDBInstance).Login)
+	handler := http.HandlerFunc(handlers.NewAuthHandler(testDBInstance, testKeySet).Login)
 	handler.ServeHTTP(rr, req)
 
 	if rr.Code != http.StatusOK {
@@ -137,7 +141,7 @@ func TestArticleCreate(t *testing.T) {
 	req.Header.Set("Authorization", fmt.Sprintf("Basic %s", token))
 
 	rr = httptest.NewRecorder()
-	privateHandler := middlewares.Authenticate(http.HandlerFunc(handlers.NewArticleHandler(testDBInstance).Create))
+	privateHandler := middlewares.NewAuthMiddleware(testDBInstance, testKeySet).Authenticate(http.HandlerFunc(handlers.NewArticleHandler(testDBInstance).Create))
 
 	privateHandler.ServeHTTP(rr, req)
 
@@ -156,7 +160,7 @@ func TestArticleList(t *testing.T) {
 	require.NoError(t, err)
 
 	rr := httptest.NewRecorder()
-	handler := http.HandlerFunc(handlers.NewAuthHandler(testDBInstance).Login)
+	handler := http.HandlerFunc(handlers.NewAuthHandler(testDBInstance, testKeySet).Login)
 	handler.ServeHTTP(rr, req)
 
 	if rr.Code != http.StatusOK {
@@ -174,7 +178,7 @@ func TestArticleList(t *testing.T) {
 	req.Header.Set("Authorization", fmt.Sprintf("Basic %s", token))
 
 	rr = httptest.NewRecorder()
-	privateHandler := middlewares.Authenticate(http.HandlerFunc(handlers.NewArticleHandler(testDBInstance).List))
+	privateHandler := middlewares.NewAuthMiddleware(testDBInstance, testKeySet).Authenticate(http.HandlerFunc(handlers.NewArticleHandler(testDBInstance).List))
 
 	privateHandler.ServeHTTP(rr, req)
 
@@ -182,3 +186,70 @@ func TestArticleList(t *testing.T) {
 		t.Errorf("Handler returned wrong status code: got %v want %v", rr.Code, http.StatusOK)
 	}
 }
+
+func TestArticlePatchLocked(t *testing.T) {
+	server := routes.LoadRoutes(testDBInstance, testKeySet)
+
+	body := []byte(`{
+		"password": "123",
+		"role": "WRITER",
+		"username": "editor"
+	}`)
+	req, err := http.NewRequest(http.MethodPost, "/auth/register", bytes.NewBuffer(body))
+	require.NoError(t, err)
+	rr := httptest.NewRecorder()
+	server.ServeHTTP(rr, req)
+	require.Equal(t, http.StatusCreated, rr.Code)
+	err = testDBInstance.Model(&models.Auth{}).Where("username = ?", "editor").Update("role_name", models.RoleEditor).Error
+	require.NoError(t, err)
+
+	login := func(username string) string {
+		body := []byte(fmt.Sprintf(`{"password": "123", "username": %q}`, username))
+		req, err := http.NewRequest(http.MethodPost, "/auth/login", bytes.NewBuffer(body))
+		require.NoError(t, err)
+		rr := httptest.NewRecorder()
+		server.ServeHTTP(rr, req)
+		require.Equal(t, http.StatusOK, rr.Code)
+
+		var response models.Response
+		json.NewDecoder(rr.Body).Decode(&response)
+		return fmt.Sprintf("Basic %s", response.Data.(map[string]interface{})["token"])
+	}
+	writerToken, editorToken := login("hdn"), login("editor")
+
+	articleBody := []byte(`{
+		"content": "content",
+		"status": "DRAFT",
+		"tags": [
+			"testing"
+		],
+		"title": "locked title"
+	}`)
+	req, err = http.NewRequest(http.MethodPost, "/articles", bytes.NewBuffer(articleBody))
+	require.NoError(t, err)
+	req.Header.Set("Authorization", writerToken)
+	rr = httptest.NewRecorder()
+	server.ServeHTTP(rr, req)
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	var response models.Response
+	json.NewDecoder(rr.Body).Decode(&response)
+	uuid := response.Data.(map[string]interface{})["UUID"]
+
+	req, err = http.NewRequest(http.MethodPost, fmt.Sprintf("/articles/%s/lock", uuid), nil)
+	require.NoError(t, err)
+	req.Header.Set("Authorization", writerToken)
+	rr = httptest.NewRecorder()
+	server.ServeHTTP(rr, req)
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	req, err = http.NewRequest(http.MethodPatch, fmt.Sprintf("/articles/%s", uuid), bytes.NewBuffer([]byte(`{"title": "new title"}`)))
+	require.NoError(t, err)
+	req.Header.Set("Authorization", editorToken)
+	rr = httptest.NewRecorder()
+	server.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusLocked {
+		t.Errorf("Handler returned wrong status code: got %v want %v", rr.Code, http.StatusLocked)
+	}
+}
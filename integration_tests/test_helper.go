@@ -40,10 +40,26 @@ func SetupTestDatabase() TestDatabase {
 
 	db.AutoMigrate(&models.Auth{})
 	db.AutoMigrate(&models.Article{})
+	db.AutoMigrate(&models.ArticleSlugRedirect{})
 	db.AutoMigrate(&models.ArticleTag{})
 	db.AutoMigrate(&models.Tag{})
 	db.AutoMigrate(&models.TagTrendingScore{})
+	db.AutoMigrate(&models.ArticleRelation{})
 	db.AutoMigrate(&models.ArticleHistory{})
+	db.AutoMigrate(&models.ArticleLock{})
+	db.AutoMigrate(&models.RefreshToken{})
+	db.AutoMigrate(&models.RevokedToken{})
+	db.AutoMigrate(&models.Role{})
+	db.AutoMigrate(&models.RolePermission{})
+	if err := config.SetupSearch(db); err != nil {
+		log.Fatal("failed to set up search", err)
+	}
+	if err := config.SetupTagSearch(db); err != nil {
+		log.Fatal("failed to set up tag search", err)
+	}
+	if err := config.SeedRoles(db); err != nil {
+		log.Fatal("failed to seed roles", err)
+	}
 
 	return TestDatabase{
 		Port:      port,
@@ -94,7 +110,7 @@ func createContainer(ctx context.Context) (testcontainers.Container, *gorm.DB, s
 		"host=%s user=%s password=%s dbname=%s port=%s sslmode=disable TimeZone=Asia/Jakarta",
 		os.Getenv("DB_HOST"), os.Getenv("DB_USER"), os.Getenv("DB_PASSWORD"), os.Getenv("DB_NAME"), p.Port())
 
-	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{TranslateError: true})
 	if err != nil {
 		return container, nil, p.Port(), fmt.Errorf("failed to establish database connection: %v", err)
 	}
@@ -148,7 +164,8 @@ func createContainer(ctx context.Context) (testcontainers.Container, *gorm.DB, s
 func setupServer(dbPort string) http.Handler {
 	config.LoadEnv("../.env.integration.test")
 	DB := config.SetupDB(dbPort)
-	return routes.LoadRoutes(DB)
+	keys := config.SetupKeySet()
+	return routes.LoadRoutes(DB, keys)
 }
 
 func StartServer(dbPort string) {
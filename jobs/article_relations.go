@@ -0,0 +1,38 @@
+package jobs
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// ArticleRelationRebuilder defines function rescoring all article relations
+type ArticleRelationRebuilder interface {
+	Rebuild(ctx context.Context) (int64, error)
+}
+
+// ArticleRelationsJob rescores all article relations, catching up with tag weights shifted by tag
+// changes of other articles since the relations were scored
+type ArticleRelationsJob struct {
+	repo ArticleRelationRebuilder
+}
+
+// NewArticleRelationsJob inits ArticleRelationsJob
+func NewArticleRelationsJob(repo ArticleRelationRebuilder) ArticleRelationsJob {
+	return ArticleRelationsJob{repo: repo}
+}
+
+// Name returns the job name
+func (j ArticleRelationsJob) Name() string {
+	return "article-relations"
+}
+
+// Run rebuilds the relations
+func (j ArticleRelationsJob) Run(ctx context.Context, now time.Time) error {
+	count, err := j.repo.Rebuild(ctx)
+	if err != nil {
+		return err
+	}
+	log.Printf("Scored %d article relations\n", count)
+	return nil
+}
@@ -0,0 +1,53 @@
+package jobs
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/herdiansc/go-cms/models"
+)
+
+// ArticleScheduleProcessor defines functions applying due publishing schedules
+type ArticleScheduleProcessor interface {
+	PublishDue(ctx context.Context, now time.Time, limit int) ([]models.Article, error)
+	UnpublishDue(ctx context.Context, now time.Time, limit int) ([]models.Article, error)
+}
+
+// PublishScheduleJob publishes and unpublishes articles whose publish_at or unpublish_at has passed
+type PublishScheduleJob struct {
+	repo      ArticleScheduleProcessor
+	batchSize int
+}
+
+// NewPublishScheduleJob inits PublishScheduleJob
+func NewPublishScheduleJob(repo ArticleScheduleProcessor, batchSize int) PublishScheduleJob {
+	return PublishScheduleJob{
+		repo:      repo,
+		batchSize: batchSize,
+	}
+}
+
+// Name returns the job name
+func (j PublishScheduleJob) Name() string {
+	return "publish-schedule"
+}
+
+// Run processes due articles batch by batch until none is left
+func (j PublishScheduleJob) Run(ctx context.Context, now time.Time) error {
+	for _, process := range []func(context.Context, time.Time, int) ([]models.Article, error){j.repo.PublishDue, j.repo.UnpublishDue} {
+		for ctx.Err() == nil {
+			articles, err := process(ctx, now, j.batchSize)
+			if err != nil {
+				return err
+			}
+			for _, article := range articles {
+				log.Printf("Article %d is now %s by schedule\n", article.ID, article.Status)
+			}
+			if len(articles) < j.batchSize {
+				break
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package jobs
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+)
+
+// Job defines a unit of background work run periodically by Runner
+type Job interface {
+	Name() string
+	Run(ctx context.Context, now time.Time) error
+}
+
+type entry struct {
+	job      Job
+	interval time.Duration
+}
+
+// Runner runs jobs on their own interval until its context is cancelled
+type Runner struct {
+	entries []entry
+	wg      sync.WaitGroup
+}
+
+// NewRunner inits Runner
+func NewRunner() *Runner {
+	return &Runner{}
+}
+
+// Add registers a job to be run every interval, it must be called before Start
+func (r *Runner) Add(job Job, interval time.Duration) {
+	r.entries = append(r.entries, entry{job: job, interval: interval})
+}
+
+// Start runs every job once, then on each tick of its interval, in its own goroutine
+func (r *Runner) Start(ctx context.Context) {
+	for _, e := range r.entries {
+		r.wg.Add(1)
+		go func(e entry) {
+			defer r.wg.Done()
+			log.Printf("Job %s started, running every %s\n", e.job.Name(), e.interval)
+
+			ticker := time.NewTicker(e.interval)
+			defer ticker.Stop()
+			for {
+				if err := e.job.Run(ctx, time.Now()); err != nil {
+					log.Printf("Job %s failed: %+v\n", e.job.Name(), err.Error())
+				}
+				select {
+				case <-ctx.Done():
+					log.Printf("Job %s stopped\n", e.job.Name())
+					return
+				case <-ticker.C:
+				}
+			}
+		}(e)
+	}
+}
+
+// Wait blocks until every job has stopped after the context given to Start is cancelled
+func (r *Runner) Wait() {
+	r.wg.Wait()
+}
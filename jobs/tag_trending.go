@@ -0,0 +1,50 @@
+package jobs
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/herdiansc/go-cms/models"
+)
+
+// TagTrendingScorer defines function computing tag trending scores
+type TagTrendingScorer interface {
+	Compute(ctx context.Context, window models.TrendingWindow, now time.Time, halfLife time.Duration) (int64, error)
+}
+
+// TagTrendingJob recomputes the time-decayed trending scores of tags for each window
+type TagTrendingJob struct {
+	repo     TagTrendingScorer
+	windows  []models.TrendingWindow
+	halfLife time.Duration
+}
+
+// NewTagTrendingJob inits TagTrendingJob
+func NewTagTrendingJob(repo TagTrendingScorer, windows []models.TrendingWindow, halfLife time.Duration) TagTrendingJob {
+	return TagTrendingJob{
+		repo:     repo,
+		windows:  windows,
+		halfLife: halfLife,
+	}
+}
+
+// Name returns the job name
+func (j TagTrendingJob) Name() string {
+	return "tag-trending"
+}
+
+// Run computes the scores of every window
+func (j TagTrendingJob) Run(ctx context.Context, now time.Time) error {
+	for _, window := range j.windows {
+		if ctx.Err() != nil {
+			return nil
+		}
+		count, err := j.repo.Compute(ctx, window, now, j.halfLife)
+		if err != nil {
+			return err
+		}
+		log.Printf("Scored %d trending tags for window %s\n", count, window.Name)
+	}
+	return nil
+}
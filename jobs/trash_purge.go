@@ -0,0 +1,53 @@
+package jobs
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/herdiansc/go-cms/models"
+)
+
+// ArticleTrashPurger defines function permanently deleting articles trashed before a time
+type ArticleTrashPurger interface {
+	PurgeDeleted(ctx context.Context, before time.Time, limit int) ([]models.Article, error)
+}
+
+// TrashPurgeJob permanently deletes articles which stayed in the trash longer than the retention period
+type TrashPurgeJob struct {
+	repo      ArticleTrashPurger
+	retention time.Duration
+	batchSize int
+}
+
+// NewTrashPurgeJob inits TrashPurgeJob
+func NewTrashPurgeJob(repo ArticleTrashPurger, retention time.Duration, batchSize int) TrashPurgeJob {
+	return TrashPurgeJob{
+		repo:      repo,
+		retention: retention,
+		batchSize: batchSize,
+	}
+}
+
+// Name returns the job name
+func (j TrashPurgeJob) Name() string {
+	return "trash-purge"
+}
+
+// Run purges expired articles batch by batch until none is left
+func (j TrashPurgeJob) Run(ctx context.Context, now time.Time) error {
+	before := now.Add(-j.retention)
+	for ctx.Err() == nil {
+		articles, err := j.repo.PurgeDeleted(ctx, before, j.batchSize)
+		if err != nil {
+			return err
+		}
+		for _, article := range articles {
+			log.Printf("Article %d purged from the trash, deleted at %s\n", article.ID, article.DeletedAt.Time)
+		}
+		if len(articles) < j.batchSize {
+			break
+		}
+	}
+	return nil
+}
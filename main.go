@@ -1,27 +1,79 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"log"
 	"net/http"
 	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"github.com/herdiansc/go-cms/config"
 	_ "github.com/herdiansc/go-cms/docs"
+	"github.com/herdiansc/go-cms/jobs"
+	"github.com/herdiansc/go-cms/respositories"
 	"github.com/herdiansc/go-cms/routes"
+	"gorm.io/gorm"
 )
 
-func setupServer() http.Handler {
+func setupServer() (http.Handler, *gorm.DB) {
 	config.LoadEnv(".env")
 	DB := config.SetupDB("")
-	return routes.LoadRoutes(DB)
+	keys := config.SetupKeySet()
+	return routes.LoadRoutes(DB, keys), DB
+}
+
+func setupJobs(DB *gorm.DB) *jobs.Runner {
+	runner := jobs.NewRunner()
+	runner.Add(
+		jobs.NewPublishScheduleJob(respositories.NewArticleRepository(DB), 100),
+		config.GetPositiveDuration("PUBLISH_SCHEDULE_INTERVAL", time.Minute),
+	)
+	runner.Add(
+		jobs.NewTagTrendingJob(respositories.NewTagTrendingRepository(DB), config.TrendingWindows(), config.GetPositiveDuration("TRENDING_HALF_LIFE", 24*time.Hour)),
+		config.GetPositiveDuration("TRENDING_INTERVAL", 15*time.Minute),
+	)
+	runner.Add(
+		jobs.NewArticleRelationsJob(respositories.NewArticleRelationRepository(DB)),
+		config.GetPositiveDuration("RELATED_REBUILD_INTERVAL", time.Hour),
+	)
+	runner.Add(
+		jobs.NewTrashPurgeJob(respositories.NewArticleRepository(DB), config.GetPositiveDuration("TRASH_RETENTION", 30*24*time.Hour), 100),
+		config.GetPositiveDuration("TRASH_PURGE_INTERVAL", time.Hour),
+	)
+	return runner
 }
 
 func main() {
-	fmt.Println("Server Running")
-	httpServer := setupServer()
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	handler, DB := setupServer()
+	runner := setupJobs(DB)
+	runner.Start(ctx)
+
+	server := &http.Server{
+		Addr:    fmt.Sprintf(":%s", os.Getenv("SERVICE_PORT")),
+		Handler: handler,
+	}
+	go func() {
+		fmt.Println("Server Running")
+		err := server.ListenAndServe()
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			panic(err)
+		}
+	}()
+
+	<-ctx.Done()
+	log.Printf("Shutting down\n")
 
-	err := http.ListenAndServe(fmt.Sprintf(":%s", os.Getenv("SERVICE_PORT")), httpServer)
-	if err != nil {
-		panic(err)
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), config.GetDuration("SHUTDOWN_TIMEOUT", 10*time.Second))
+	defer cancel()
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		log.Printf("Failed to shut down server: %+v\n", err.Error())
 	}
+	runner.Wait()
 }
@@ -2,18 +2,39 @@ package middlewares
 
 import (
 	"context"
+	"encoding/json"
 	"log"
 	"net/http"
 
+	"github.com/herdiansc/go-cms/config"
 	"github.com/herdiansc/go-cms/models"
+	"github.com/herdiansc/go-cms/respositories"
 	"github.com/herdiansc/go-cms/services"
+	"gorm.io/gorm"
 )
 
-func Authenticate(next http.Handler) http.Handler {
+// AuthMiddleware struct
+type AuthMiddleware struct {
+	db   *gorm.DB
+	keys config.KeySet
+}
+
+// NewAuthMiddleware inits AuthMiddleware
+func NewAuthMiddleware(db *gorm.DB, keys config.KeySet) AuthMiddleware {
+	return AuthMiddleware{
+		db:   db,
+		keys: keys,
+	}
+}
+
+// Authenticate verifies the bearer token and rejects revoked tokens
+func (m AuthMiddleware) Authenticate(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		log.Printf("Verifying Token.....")
 
-		svc := services.NewTokenVerifyServices()
+		rc := respositories.NewTokenRepository(m.db)
+		af := respositories.NewAuthRepository(m.db)
+		svc := services.NewTokenVerifyServices(m.keys, rc, af)
 		code, res := svc.Verify(r.Header.Get("Authorization"))
 		if code != http.StatusOK {
 			log.Printf("Failed to verify token\n")
@@ -21,8 +42,11 @@ func Authenticate(next http.Handler) http.Handler {
 			return
 		}
 
+		verifyData, _ := res.Data.(models.VerifyData)
+		verifyData.RequestID, _ = r.Context().Value(models.RequestIDCtxKey).(string)
+
 		// Store the custom data in the request context
-		ctx := context.WithValue(r.Context(), models.AuthVerifyCtxKey, res.Data)
+		ctx := context.WithValue(r.Context(), models.AuthVerifyCtxKey, verifyData)
 		r = r.WithContext(ctx)
 
 		log.Printf("Auth Res: %+v\n", res)
@@ -30,3 +54,20 @@ func Authenticate(next http.Handler) http.Handler {
 		next.ServeHTTP(w, r)
 	})
 }
+
+// Authorize rejects requests of users whose role is not granted the permission, it must run after Authenticate
+func (m AuthMiddleware) Authorize(permission string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		pc := respositories.NewRoleRepository(m.db)
+		svc := services.NewAuthorizationServices(r.Context().Value(models.AuthVerifyCtxKey), pc)
+		code, res := svc.Authorize(permission)
+		if code != http.StatusOK {
+			log.Printf("Failed to authorize: %+v\n", res.Data)
+			w.WriteHeader(code)
+			json.NewEncoder(w).Encode(res)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
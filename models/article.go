@@ -1,41 +1,180 @@
 package models
 
-import "github.com/gosimple/slug"
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
 
-// Article struct
+	"github.com/gosimple/slug"
+	"gorm.io/gorm"
+)
+
+// Article struct. Its DeletedAt shadows the one of PublicBase so that deleting an article only moves
+// it to the trash, trashed articles are left out of every query unless it is unscoped. Version is the
+// version of its latest history, it is only written on create and by the repository recording history.
 type Article struct {
 	Base
-	Title                string `gorm:"not null"`
-	Content              string `gorm:"not null"`
-	Status               string `gorm:"not null"`
-	WriterID             int64  `gorm:"not null"`
-	Slug                 string `gorm:"not null"`
-	TagRelationshipScore int64
+	DeletedAt            gorm.DeletedAt `gorm:"index" json:"deleted_at"`
+	UUID                 string         `gorm:"type:uuid;not null;uniqueIndex;default:gen_random_uuid()"`
+	Title                string         `gorm:"not null"`
+	Content              string         `gorm:"not null"`
+	Status               string         `gorm:"not null"`
+	WriterID             int64          `gorm:"not null" json:"-"`
+	WriterUUID           string         `gorm:"-"`
+	Slug                 string         `gorm:"not null;uniqueIndex"`
+	TagRelationshipScore float64        `gorm:"-"`
+	PublishAt            *time.Time     `gorm:"index"`
+	UnpublishAt          *time.Time     `gorm:"index"`
+	Tags                 []string       `gorm:"-"`
+	Version              int64          `gorm:"not null;default:0;<-:create"`
+}
+
+// ErrArticleVersionMismatch is returned when an article changed since the version a change is based on
+var ErrArticleVersionMismatch = errors.New("article was changed since the expected version")
+
+// ETag formats the version of an article as a strong entity tag
+func (a Article) ETag() string {
+	return fmt.Sprintf(`"%d"`, a.Version)
+}
+
+// ParseArticleETag reads the version of an entity tag made by Article.ETag, weak tags never match
+func ParseArticleETag(etag string) (int64, bool) {
+	etag = strings.TrimSpace(etag)
+	if len(etag) < 3 || !strings.HasPrefix(etag, `"`) || !strings.HasSuffix(etag, `"`) {
+		return 0, false
+	}
+	version, err := strconv.ParseInt(etag[1:len(etag)-1], 10, 64)
+	if err != nil || version < 0 {
+		return 0, false
+	}
+	return version, true
 }
 
 // CreateArticleRequest struct
 type CreateArticleRequest struct {
 	Title   string   `json:"title" validate:"required"`
 	Content string   `json:"content" validate:"required"`
-	Status  string   `json:"status"`
 	Tags    []string `json:"tags"`
 }
 
-// Article converts CreateArticleRequest to Article
+// Article converts CreateArticleRequest to Article, new articles always start as DRAFT
 func (c CreateArticleRequest) Article() Article {
-	status := "DRAFT"
-	if c.Status != "" {
-		status = c.Status
-	}
 	return Article{
 		Title:   c.Title,
 		Content: c.Content,
-		Status:  status,
-		Slug:    slug.Make(c.Title),
+		Status:  ArticleStatusDraft,
+		Slug:    ArticleSlug(c.Title),
+	}
+}
+
+// ArticleSlug makes the slug of an article title, repositories suffix it with -2, -3 and so on when taken
+func ArticleSlug(title string) string {
+	s := slug.Make(title)
+	if s == "" {
+		return "article"
 	}
+	return s
+}
+
+// MovedArticle struct, the current slug of an article requested by a former slug
+type MovedArticle struct {
+	Slug string `json:"slug"`
 }
 
-// PatchArticleRequest struct
+// PatchArticleRequest struct, a JSON merge patch where fields left out are kept unchanged.
+// Status is changed through the workflow transitions only.
 type PatchArticleRequest struct {
-	Status string `json:"status"`
+	Title   *string   `json:"title" validate:"omitempty,min=1"`
+	Content *string   `json:"content" validate:"omitempty,min=1"`
+	Tags    *[]string `json:"tags"`
+}
+
+// IsEmpty checks whether the patch changes nothing
+func (p PatchArticleRequest) IsEmpty() bool {
+	return p.Title == nil && p.Content == nil && p.Tags == nil
+}
+
+// Apply applies the patch to an article, tags are handled by the repository
+func (p PatchArticleRequest) Apply(a *Article) {
+	if p.Title != nil {
+		a.Title = *p.Title
+	}
+	if p.Content != nil {
+		a.Content = *p.Content
+	}
+}
+
+// PutArticleRequest struct
+type PutArticleRequest struct {
+	Title   string   `json:"title" validate:"required"`
+	Content string   `json:"content" validate:"required"`
+	Tags    []string `json:"tags"`
+}
+
+// Patch converts PutArticleRequest to a patch replacing title, content and tags
+func (p PutArticleRequest) Patch() PatchArticleRequest {
+	tags := p.Tags
+	if tags == nil {
+		tags = []string{}
+	}
+	return PatchArticleRequest{
+		Title:   &p.Title,
+		Content: &p.Content,
+		Tags:    &tags,
+	}
+}
+
+// ScheduleArticleRequest struct, times left out clear the schedule
+type ScheduleArticleRequest struct {
+	PublishAt   *time.Time `json:"publish_at"`
+	UnpublishAt *time.Time `json:"unpublish_at"`
+}
+
+// Apply sets the schedule of an article
+func (s ScheduleArticleRequest) Apply(a *Article) {
+	a.PublishAt = s.PublishAt
+	a.UnpublishAt = s.UnpublishAt
+}
+
+// RestoreSnapshot copies title, content and status of a history snapshot into an article,
+// tags are handled by the repository
+func (a *Article) RestoreSnapshot(snapshot Article) {
+	a.Title = snapshot.Title
+	a.Content = snapshot.Content
+	a.Status = snapshot.Status
+}
+
+// ArticleSearchResult struct, an article matching a search with its rank and highlighted snippet
+type ArticleSearchResult struct {
+	Article  `gorm:"embedded"`
+	Rank     float64 `json:"rank"`
+	Headline string  `json:"headline"`
+}
+
+// PublicArticle struct, a published article as exposed without authentication
+type PublicArticle struct {
+	Title     string    `json:"title"`
+	Slug      string    `json:"slug"`
+	Content   string    `json:"content"`
+	Tags      []string  `json:"tags"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Public converts Article to PublicArticle, leaving out its UUID, writer and workflow fields
+func (a Article) Public() PublicArticle {
+	tags := a.Tags
+	if tags == nil {
+		tags = []string{}
+	}
+	return PublicArticle{
+		Title:     a.Title,
+		Slug:      a.Slug,
+		Content:   a.Content,
+		Tags:      tags,
+		CreatedAt: a.CreatedAt,
+		UpdatedAt: a.UpdatedAt,
+	}
 }
@@ -0,0 +1,38 @@
+package models
+
+// Diff operations
+const (
+	DiffEqual  = "equal"
+	DiffDelete = "delete"
+	DiffInsert = "insert"
+)
+
+// DiffSegment struct, a run of words of a changed line
+type DiffSegment struct {
+	Op   string `json:"op"`
+	Text string `json:"text"`
+}
+
+// DiffLine struct, a line of a line-level diff. Words is set on lines changed in place.
+type DiffLine struct {
+	Op    string        `json:"op"`
+	Text  string        `json:"text"`
+	Words []DiffSegment `json:"words,omitempty"`
+}
+
+// FieldDiff struct, a changed field. Content values are left out, see ArticleDiff.Content.
+type FieldDiff struct {
+	Field string `json:"field"`
+	From  any    `json:"from,omitempty"`
+	To    any    `json:"to,omitempty"`
+}
+
+// ArticleDiff struct
+type ArticleDiff struct {
+	ArticleUUID string      `json:"article_uuid"`
+	From        int64       `json:"from"`
+	To          int64       `json:"to"`
+	Fields      []FieldDiff `json:"fields"`
+	Content     []DiffLine  `json:"content"`
+	Unified     string      `json:"unified"`
+}
@@ -1,11 +1,26 @@
 package models
 
+import "encoding/json"
+
 // ArticleHistory struct
 type ArticleHistory struct {
 	Base
-	Article   string `gorm:"not null"`
-	Version   int64  `gorm:"not null"`
-	Status    string `gorm:"not null"`
-	ArticleID int64  `gorm:"not null"`
-	Action    string `gorm:"not null"`
+	UUID        string `gorm:"type:uuid;not null;uniqueIndex;default:gen_random_uuid()"`
+	Article     string `gorm:"not null"`
+	Version     int64  `gorm:"not null;uniqueIndex:idx_article_history_version,priority:2"`
+	Status      string `gorm:"not null"`
+	ArticleID   int64  `gorm:"not null;uniqueIndex:idx_article_history_version,priority:1" json:"-"`
+	ArticleUUID string `gorm:"-"`
+	Action      string `gorm:"not null"`
+	ActorID     int64  `gorm:"not null;default:0" json:"-"`
+	ActorUUID   string `gorm:"-" json:",omitempty"`
+	Comment     string
+	RequestID   string
+}
+
+// Snapshot decodes the article as it was at this version
+func (h ArticleHistory) Snapshot() (Article, error) {
+	var article Article
+	err := json.Unmarshal([]byte(h.Article), &article)
+	return article, err
 }
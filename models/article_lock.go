@@ -0,0 +1,23 @@
+package models
+
+import (
+	"errors"
+	"time"
+)
+
+// ArticleLock struct, a lease letting its owner edit an article alone until ExpiresAt. The owner keeps
+// it by locking the article again before it expires, an expired lock may be taken by anyone.
+type ArticleLock struct {
+	Base
+	ArticleID   int64     `gorm:"not null;uniqueIndex" json:"-"`
+	ArticleUUID string    `gorm:"-"`
+	OwnerID     int64     `gorm:"not null" json:"-"`
+	OwnerUUID   string    `gorm:"-"`
+	ExpiresAt   time.Time `gorm:"not null;index"`
+}
+
+// ErrArticleLocked is returned when an article is locked by another user
+var ErrArticleLocked = errors.New("article is locked by another user")
+
+// ErrArticleNotLocked is returned when an article has no lock which has not expired
+var ErrArticleNotLocked = errors.New("article is not locked")
@@ -0,0 +1,16 @@
+package models
+
+// ArticleRelation struct, the precomputed score of how related an article is to another by their tags
+type ArticleRelation struct {
+	Base
+	ArticleID int64   `gorm:"uniqueIndex:idx_article_relation,priority:1"`
+	RelatedID int64   `gorm:"uniqueIndex:idx_article_relation,priority:2;index"`
+	Score     float64 `gorm:"not null"`
+}
+
+// RelatedArticlesFilter struct, an empty Status or a zero ExcludeWriterID does not filter
+type RelatedArticlesFilter struct {
+	Status          string
+	ExcludeWriterID int64
+	Limit           int
+}
@@ -0,0 +1,8 @@
+package models
+
+// ArticleSlugRedirect struct, a former slug of a published article permanently redirecting to it
+type ArticleSlugRedirect struct {
+	Base
+	Slug      string `gorm:"not null;uniqueIndex"`
+	ArticleID int64  `gorm:"not null;index"`
+}
@@ -3,6 +3,6 @@ package models
 // ArticleTag struct
 type ArticleTag struct {
 	Base
-	ArticleID int64
-	TagID     int64
+	ArticleID int64 `gorm:"index"`
+	TagID     int64 `gorm:"index"`
 }
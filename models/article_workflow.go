@@ -0,0 +1,99 @@
+package models
+
+import (
+	"errors"
+	"slices"
+)
+
+// Article statuses
+const (
+	ArticleStatusDraft     = "DRAFT"
+	ArticleStatusInReview  = "IN_REVIEW"
+	ArticleStatusApproved  = "APPROVED"
+	ArticleStatusPublished = "PUBLISHED"
+	ArticleStatusArchived  = "ARCHIVED"
+)
+
+// ArticleStatuses lists the article statuses in workflow order
+var ArticleStatuses = []string{
+	ArticleStatusDraft,
+	ArticleStatusInReview,
+	ArticleStatusApproved,
+	ArticleStatusPublished,
+	ArticleStatusArchived,
+}
+
+// Article transitions
+const (
+	ArticleTransitionSubmit  = "submit"
+	ArticleTransitionApprove = "approve"
+	ArticleTransitionReject  = "reject"
+	ArticleTransitionPublish = "publish"
+	ArticleTransitionArchive = "archive"
+)
+
+// Article history actions other than the workflow transitions
+const (
+	ArticleHistoryCreate             = "create"
+	ArticleHistoryPatch              = "patch"
+	ArticleHistoryPut                = "put"
+	ArticleHistoryRestore            = "restore"
+	ArticleHistorySchedule           = "schedule"
+	ArticleHistoryScheduledPublish   = "scheduled_publish"
+	ArticleHistoryScheduledUnpublish = "scheduled_unpublish"
+	ArticleHistoryDelete             = "delete"
+	ArticleHistoryUndelete           = "undelete"
+	ArticleHistoryTagMerge           = "tag_merge"
+	ArticleHistoryTagDelete          = "tag_delete"
+)
+
+// ErrArticleTransitionNotAllowed is returned when a transition does not start from the article's current status
+var ErrArticleTransitionNotAllowed = errors.New("transition is not allowed from the current status")
+
+// ArticleTransition struct, a step of the editorial workflow
+type ArticleTransition struct {
+	Name            string   `json:"name"`
+	From            []string `json:"from"`
+	To              string   `json:"to"`
+	RequiresComment bool     `json:"requires_comment"`
+}
+
+// AllowedFrom checks whether the transition can start from status
+func (t ArticleTransition) AllowedFrom(status string) bool {
+	return slices.Contains(t.From, status)
+}
+
+// Apply moves an article to the target status. Reject also clears publish_at, so an article approved
+// again is not published by the schedule of the version which was rejected.
+func (t ArticleTransition) Apply(a *Article) {
+	a.Status = t.To
+	if t.Name == ArticleTransitionReject {
+		a.PublishAt = nil
+	}
+}
+
+// ArticleTransitions lists the editorial workflow:
+// DRAFT -> IN_REVIEW -> APPROVED -> PUBLISHED -> ARCHIVED, with reject back to DRAFT
+var ArticleTransitions = []ArticleTransition{
+	{Name: ArticleTransitionSubmit, From: []string{ArticleStatusDraft}, To: ArticleStatusInReview},
+	{Name: ArticleTransitionApprove, From: []string{ArticleStatusInReview}, To: ArticleStatusApproved},
+	{Name: ArticleTransitionReject, From: []string{ArticleStatusInReview, ArticleStatusApproved}, To: ArticleStatusDraft, RequiresComment: true},
+	{Name: ArticleTransitionPublish, From: []string{ArticleStatusApproved}, To: ArticleStatusPublished},
+	{Name: ArticleTransitionArchive, From: []string{ArticleStatusPublished}, To: ArticleStatusArchived},
+}
+
+// FindArticleTransition finds a transition by name
+func FindArticleTransition(name string) (ArticleTransition, bool) {
+	for _, transition := range ArticleTransitions {
+		if transition.Name == name {
+			return transition, true
+		}
+	}
+	return ArticleTransition{}, false
+}
+
+// TransitionArticleRequest struct
+type TransitionArticleRequest struct {
+	Action  string `json:"action" validate:"required"`
+	Comment string `json:"comment"`
+}
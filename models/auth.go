@@ -3,6 +3,7 @@ package models
 // Auth struct
 type Auth struct {
 	Base
+	UUID     string `gorm:"type:uuid;not null;uniqueIndex;default:gen_random_uuid()"`
 	Username string `gorm:"not null;unique"`
 	Password string `gorm:"not null"`
 	RoleName string `gorm:"not null"`
@@ -11,6 +12,7 @@ type Auth struct {
 // ProfileResponse struct
 type ProfileResponse struct {
 	PublicBase
+	UUID     string
 	Username string `gorm:"not null;unique"`
 	RoleName string `gorm:"not null"`
 }
@@ -22,6 +24,7 @@ func (a Auth) ProfileResponse() ProfileResponse {
 			UpdatedAt: a.UpdatedAt,
 			DeletedAt: a.DeletedAt,
 		},
+		UUID:     a.UUID,
 		Username: a.Username,
 		RoleName: a.RoleName,
 	}
@@ -11,18 +11,32 @@ type PublicBase struct {
 	DeletedAt *time.Time `sql:"index" json:"deleted_at"`
 }
 
-// Base struct
+// Base struct, the id is internal and never exposed, resources are identified by their UUID
 type Base struct {
-	ID int64 `gorm:"autoIncrement"`
+	ID int64 `gorm:"autoIncrement" json:"-"`
 	PublicBase
 }
 
-// Response struct
+// Response struct, Warnings carries issues that did not prevent the request from succeeding
 type Response struct {
-	Message string      `json:"message"`
-	Data    interface{} `json:"data"`
+	Message  string       `json:"message"`
+	Data     interface{}  `json:"data"`
+	Meta     *PageMeta    `json:"meta,omitempty"`
+	Warnings []TagWarning `json:"warnings,omitempty"`
+}
+
+// PageMeta describes a page of a list. Total and Page are only set when paginating by page,
+// NextCursor only when paginating by cursor and there is a next page.
+type PageMeta struct {
+	Total      *int64 `json:"total,omitempty"`
+	Page       int    `json:"page,omitempty"`
+	Limit      int    `json:"limit"`
+	HasNext    bool   `json:"has_next"`
+	NextCursor string `json:"next_cursor,omitempty"`
 }
 
 type authVerifyCtxType string
 
 const AuthVerifyCtxKey authVerifyCtxType = "authVerifyCtxKey"
+
+const RequestIDCtxKey authVerifyCtxType = "requestIDCtxKey"
@@ -0,0 +1,33 @@
+package models
+
+// Permissions
+const (
+	PermissionArticleRead      = "article:read"
+	PermissionArticleCreate    = "article:create"
+	PermissionArticleUpdateOwn = "article:update:own"
+	PermissionArticleUpdateAny = "article:update:any"
+	PermissionArticleDeleteOwn = "article:delete:own"
+	PermissionArticleDeleteAny = "article:delete:any"
+	PermissionArticlePurge     = "article:purge"
+	PermissionArticleUnlock    = "article:unlock"
+	PermissionArticleReview    = "article:review"
+	PermissionArticlePublish   = "article:publish"
+	PermissionTagManage        = "tag:manage"
+	PermissionUserManage       = "user:manage"
+)
+
+// AllPermissions lists every known permission
+var AllPermissions = []string{
+	PermissionArticleRead,
+	PermissionArticleCreate,
+	PermissionArticleUpdateOwn,
+	PermissionArticleUpdateAny,
+	PermissionArticleDeleteOwn,
+	PermissionArticleDeleteAny,
+	PermissionArticlePurge,
+	PermissionArticleUnlock,
+	PermissionArticleReview,
+	PermissionArticlePublish,
+	PermissionTagManage,
+	PermissionUserManage,
+}
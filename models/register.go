@@ -9,7 +9,7 @@ type RegisterRequest struct {
 
 // Auth creates auth struct from RegisterRequest
 func (m RegisterRequest) Auth() Auth {
-	roleName := "WRITER"
+	roleName := RoleWriter
 	if m.Role != "" {
 		roleName = m.Role
 	}
@@ -1,8 +1,65 @@
 package models
 
+// Role names
+const (
+	RoleAdmin  = "ADMIN"
+	RoleEditor = "EDITOR"
+	RoleWriter = "WRITER"
+	RoleViewer = "VIEWER"
+)
+
 // Role struct
 type Role struct {
 	Base
-	Name  string `gorm:"not null;unique"`
-	Title string `gorm:"not null;unique"`
+	Name        string           `gorm:"not null;unique"`
+	Title       string           `gorm:"not null;unique"`
+	Permissions []RolePermission `gorm:"foreignKey:RoleID"`
+}
+
+// RolePermission struct
+type RolePermission struct {
+	Base
+	RoleID     int64  `gorm:"not null;uniqueIndex:idx_role_permission"`
+	Permission string `gorm:"not null;uniqueIndex:idx_role_permission"`
+}
+
+// DefaultRoles lists the roles seeded on startup
+var DefaultRoles = []Role{
+	{Name: RoleAdmin, Title: "Administrator"},
+	{Name: RoleEditor, Title: "Editor"},
+	{Name: RoleWriter, Title: "Writer"},
+	{Name: RoleViewer, Title: "Viewer"},
+}
+
+// DefaultRolePermissions lists the permissions seeded for each default role
+var DefaultRolePermissions = map[string][]string{
+	RoleAdmin: AllPermissions,
+	RoleEditor: {
+		PermissionArticleRead,
+		PermissionArticleCreate,
+		PermissionArticleUpdateOwn,
+		PermissionArticleUpdateAny,
+		PermissionArticleDeleteOwn,
+		PermissionArticleDeleteAny,
+		PermissionArticleReview,
+		PermissionArticlePublish,
+		PermissionTagManage,
+	},
+	RoleWriter: {
+		PermissionArticleRead,
+		PermissionArticleCreate,
+		PermissionArticleUpdateOwn,
+		PermissionArticleDeleteOwn,
+	},
+	RoleViewer: {
+		PermissionArticleRead,
+	},
+}
+
+// SelfRegistrableRoles lists the roles a user may pick when registering
+var SelfRegistrableRoles = []string{RoleWriter, RoleViewer}
+
+// ChangeRoleRequest struct
+type ChangeRoleRequest struct {
+	Role string `json:"role" validate:"required"`
 }
@@ -1,25 +1,67 @@
 package models
 
-import "strings"
+import (
+	"errors"
+	"strings"
+)
 
-// Tag struct
+// ErrTagTitleTaken is returned when renaming a tag to the title of another tag
+var ErrTagTitleTaken = errors.New("tag title is taken by another tag")
+
+// ErrTagInUse is returned when deleting a tag still used by articles without forcing it
+var ErrTagInUse = errors.New("tag is still used by articles")
+
+// ErrTagMergeConflict is returned when a tag or the target of a merge was merged or deleted meanwhile
+var ErrTagMergeConflict = errors.New("tag or target was merged or deleted meanwhile")
+
+// ErrTagParentNotFound is returned when the parent of a tag does not exist
+var ErrTagParentNotFound = errors.New("parent tag not found")
+
+// ErrTagCycle is returned when moving a tag under itself or one of its descendants
+var ErrTagCycle = errors.New("tag cannot be moved under itself or its descendants")
+
+// Tag struct, a tag with a parent is a subcategory of it
 type Tag struct {
 	Base
-	Title string `gorm:"not null;unique"`
+	UUID       string  `gorm:"type:uuid;not null;uniqueIndex;default:gen_random_uuid()"`
+	Title      string  `gorm:"not null;unique"`
+	ParentID   *int64  `gorm:"index" json:"-"`
+	ParentUUID *string `gorm:"-"`
 }
 
 // CreateTagRequest struct
 type CreateTagRequest struct {
-	Title string `json:"title" validate:"required"`
+	Title      string  `json:"title" validate:"required"`
+	ParentUUID *string `json:"parent_uuid" validate:"omitempty,uuid"`
 }
 
-// Tag converts CreateTagRequest to Tag
+// Tag converts CreateTagRequest to Tag, the parent is set by id once its UUID is resolved
 func (c CreateTagRequest) Tag() Tag {
 	return Tag{
-		Title: strings.ToLower(c.Title),
+		Title: NormalizeTagTitle(c.Title),
 	}
 }
 
+// NormalizeTagTitle trims and lower-cases a tag title, tag titles are unique in this form
+func NormalizeTagTitle(title string) string {
+	return strings.ToLower(strings.TrimSpace(title))
+}
+
+// RenameTagRequest struct
+type RenameTagRequest struct {
+	Title string `json:"title" validate:"required"`
+}
+
+// MergeTagRequest struct
+type MergeTagRequest struct {
+	TargetUUID string `json:"target_uuid" validate:"required,uuid"`
+}
+
+// MoveTagRequest struct, a null parent makes the tag a root category
+type MoveTagRequest struct {
+	ParentUUID *string `json:"parent_uuid"`
+}
+
 // TagUsageQueryResult struct
 type TagUsageQueryResult struct {
 	ID    int64
@@ -28,11 +70,43 @@ type TagUsageQueryResult struct {
 
 // TagListItem struct
 type TagListItem struct {
-	ID         int64  `json:"id"`
+	ID         int64   `json:"-"`
+	UUID       string  `json:"uuid"`
+	Title      string  `json:"title"`
+	ParentID   *int64  `json:"-"`
+	ParentUUID *string `json:"parentUuid"`
+	UsageCount int64   `json:"usageCount"`
+}
+
+// PublicTag struct, a tag of published articles with the number of them as exposed without authentication
+type PublicTag struct {
 	Title      string `json:"title"`
 	UsageCount int64  `json:"usageCount"`
 }
 
+// TagNode struct, a tag of the category tree with its subcategories
+type TagNode struct {
+	UUID       string    `json:"uuid"`
+	Title      string    `json:"title"`
+	UsageCount int64     `json:"usageCount"`
+	Children   []TagNode `json:"children"`
+}
+
+// TagSuggestion struct
+type TagSuggestion struct {
+	UUID       string  `json:"uuid"`
+	Title      string  `json:"title"`
+	UsageCount int64   `json:"usageCount"`
+	Similarity float64 `json:"similarity"`
+}
+
+// TagWarning struct, a submitted tag that does not exist yet but looks like existing ones
+type TagWarning struct {
+	Tag     string   `json:"tag"`
+	Similar []string `json:"similar"`
+	Message string   `json:"message"`
+}
+
 // TagDetail struct
 type TagDetail struct {
 	Tag        Tag
@@ -1,8 +1,50 @@
 package models
 
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
 // TagTrendingScore struct
 type TagTrendingScore struct {
 	Base
-	TagID int64
-	Score int64
+	TagID  int64   `gorm:"uniqueIndex:idx_tag_trending_window,priority:1"`
+	Window string  `gorm:"column:time_window;not null;uniqueIndex:idx_tag_trending_window,priority:2"`
+	Score  float64 `gorm:"not null"`
+}
+
+// TrendingWindow is a period tag trending scores are computed over, named like "7d" or "12h"
+type TrendingWindow struct {
+	Name     string
+	Duration time.Duration
+}
+
+// ParseTrendingWindow parses a window name, a number of days like "7d" or a duration like "12h"
+func ParseTrendingWindow(name string) (TrendingWindow, error) {
+	var d time.Duration
+	if days, ok := strings.CutSuffix(name, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil {
+			return TrendingWindow{}, fmt.Errorf("invalid trending window %q", name)
+		}
+		d = time.Duration(n) * 24 * time.Hour
+	} else {
+		var err error
+		if d, err = time.ParseDuration(name); err != nil {
+			return TrendingWindow{}, fmt.Errorf("invalid trending window %q", name)
+		}
+	}
+	if d <= 0 {
+		return TrendingWindow{}, fmt.Errorf("invalid trending window %q", name)
+	}
+	return TrendingWindow{Name: name, Duration: d}, nil
+}
+
+// TrendingTag struct
+type TrendingTag struct {
+	UUID  string  `json:"uuid"`
+	Title string  `json:"title"`
+	Score float64 `json:"score"`
 }
@@ -0,0 +1,37 @@
+package models
+
+import "time"
+
+// RefreshToken struct
+type RefreshToken struct {
+	Base
+	AuthID    int64     `gorm:"not null;index"`
+	FamilyID  string    `gorm:"not null;index"`
+	TokenHash string    `gorm:"not null;unique"`
+	ExpiresAt time.Time `gorm:"not null"`
+	RevokedAt *time.Time
+}
+
+// RevokedToken struct
+type RevokedToken struct {
+	Base
+	JTI       string    `gorm:"not null;unique"`
+	ExpiresAt time.Time `gorm:"not null;index"`
+}
+
+// TokenResponse struct
+type TokenResponse struct {
+	Token        string `json:"token"`
+	RefreshToken string `json:"refresh_token"`
+	ExpiresIn    int64  `json:"expires_in"`
+}
+
+// RefreshTokenRequest struct
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token" validate:"required"`
+}
+
+// LogoutRequest struct
+type LogoutRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
@@ -2,7 +2,22 @@ package models
 
 // VerifyData struct
 type VerifyData struct {
-	ID       int64  `json:"id"`
-	UUID     string `json:"uuid"`
-	Username string `json:"username"`
+	ID        int64  `json:"-"`
+	UUID      string `json:"uuid"`
+	Username  string `json:"username"`
+	RoleName  string `json:"role_name"`
+	JTI       string `json:"jti"`
+	ExpiresAt int64  `json:"expires_at"`
+	RequestID string `json:"-"`
+}
+
+// Actor identifies the user making a change and the request it was made in
+type Actor struct {
+	ID        int64
+	RequestID string
+}
+
+// Actor returns the acting user of a verified request
+func (v VerifyData) Actor() Actor {
+	return Actor{ID: v.ID, RequestID: v.RequestID}
 }
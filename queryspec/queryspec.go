@@ -0,0 +1,464 @@
+// Package queryspec parses list query params into whitelisted filters, sorting and pagination.
+//
+// Each resource declares a Spec naming the params it accepts. A filter param holds an optional
+// operator and a value, e.g. status=in:DRAFT,PUBLISHED, created_at=gte:2026-01-01 or title=like:go,
+// and may be repeated to combine conditions. orderField, orderDir, page, limit and cursor are reserved.
+//
+// Lists are paginated by page and limit, or, for specs with a Key, by an opaque cursor: a cursor param
+// (empty for the first page) switches to keyset pagination which continues after the last row of the
+// previous page instead of skipping rows with OFFSET.
+package queryspec
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/herdiansc/go-cms/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Operators
+const (
+	OpEq   = "eq"
+	OpNe   = "ne"
+	OpGt   = "gt"
+	OpGte  = "gte"
+	OpLt   = "lt"
+	OpLte  = "lte"
+	OpLike = "like"
+	OpIn   = "in"
+)
+
+// Reserved params
+const (
+	ParamOrderField = "orderField"
+	ParamOrderDir   = "orderDir"
+	ParamPage       = "page"
+	ParamLimit      = "limit"
+	ParamCursor     = "cursor"
+)
+
+var sqlOperators = map[string]string{
+	OpEq:  "=",
+	OpNe:  "<>",
+	OpGt:  ">",
+	OpGte: ">=",
+	OpLt:  "<",
+	OpLte: "<=",
+}
+
+// Kind is the type of a field's values
+type Kind int
+
+// Kinds
+const (
+	String Kind = iota
+	Int
+	Time
+	UUID
+)
+
+// operators lists the operators allowed on each kind
+var operators = map[Kind][]string{
+	String: {OpEq, OpNe, OpLike, OpIn},
+	Int:    {OpEq, OpNe, OpGt, OpGte, OpLt, OpLte, OpIn},
+	Time:   {OpEq, OpGt, OpGte, OpLt, OpLte},
+	UUID:   {OpEq, OpNe, OpIn},
+}
+
+// Field declares a param of a resource and the column it maps to. Nullable fields cannot be
+// sorted by in cursor mode. Operator fixes the operator of a filter param so its values are taken
+// as is, e.g. a created_from param filtering created_at with gte.
+type Field struct {
+	Column     string
+	Kind       Kind
+	Filterable bool
+	Sortable   bool
+	Nullable   bool
+	Operator   string
+}
+
+// Spec declares the fields of a resource that can be filtered and sorted by. Key names the unique
+// field breaking ties between rows, cursor mode is only available when it is set.
+type Spec struct {
+	Fields       map[string]Field
+	Key          string
+	DefaultSort  string
+	DefaultDir   string
+	DefaultLimit int
+	MaxLimit     int
+}
+
+// Error is an invalid query param
+type Error struct {
+	Param  string
+	Reason string
+}
+
+func (e Error) Error() string {
+	return fmt.Sprintf("invalid parameter %s: %s", e.Param, e.Reason)
+}
+
+// Filter is a condition on a column
+type Filter struct {
+	Column   string
+	Operator string
+	Value    any
+}
+
+// Query is a parsed and validated list query. In cursor mode Page is 0.
+type Query struct {
+	Filters []Filter
+	Sort    string
+	Desc    bool
+	Page    int
+	Limit   int
+	Cursor  bool
+
+	sortField string
+	key       string
+	keyColumn string
+	after     *position
+}
+
+// position is the sort and key values of the row a cursor page continues after
+type position struct {
+	Value any
+	Key   any
+}
+
+// cursor is the encoded position, along with the sort it was made for
+type cursor struct {
+	Field string `json:"f"`
+	Desc  bool   `json:"d"`
+	Value string `json:"v"`
+	Key   string `json:"k"`
+}
+
+// Parse validates q against the spec, returning an Error naming the first bad param.
+// Params listed in ignore are left for the caller.
+func (s Spec) Parse(q url.Values, ignore ...string) (Query, error) {
+	query := Query{
+		Page:  1,
+		Limit: s.DefaultLimit,
+		Desc:  s.DefaultDir != "asc",
+	}
+
+	sortField := s.DefaultSort
+	if v := q.Get(ParamOrderField); v != "" {
+		sortField = v
+	}
+	field, ok := s.Fields[sortField]
+	if !ok || !field.Sortable {
+		return Query{}, Error{Param: ParamOrderField, Reason: fmt.Sprintf("cannot sort by %q, allowed: %s", sortField, strings.Join(s.names(true), ", "))}
+	}
+	query.Sort = field.Column
+	query.sortField = sortField
+	if s.Key != "" && s.Key != sortField {
+		query.key = s.Key
+		query.keyColumn = s.Fields[s.Key].Column
+	}
+
+	switch strings.ToLower(q.Get(ParamOrderDir)) {
+	case "":
+	case "asc":
+		query.Desc = false
+	case "desc":
+		query.Desc = true
+	default:
+		return Query{}, Error{Param: ParamOrderDir, Reason: "must be asc or desc"}
+	}
+
+	var err error
+	if query.Page, err = positiveInt(q, ParamPage, 1, 0); err != nil {
+		return Query{}, err
+	}
+	if query.Limit, err = positiveInt(q, ParamLimit, s.DefaultLimit, s.MaxLimit); err != nil {
+		return Query{}, err
+	}
+	if _, ok := q[ParamCursor]; ok {
+		if err := s.parseCursor(&query, q); err != nil {
+			return Query{}, err
+		}
+	}
+
+	params := make([]string, 0, len(q))
+	for param := range q {
+		params = append(params, param)
+	}
+	slices.Sort(params)
+	for _, param := range params {
+		if param == ParamOrderField || param == ParamOrderDir || param == ParamPage || param == ParamLimit || param == ParamCursor || slices.Contains(ignore, param) {
+			continue
+		}
+		field, ok := s.Fields[param]
+		if !ok || !field.Filterable {
+			return Query{}, Error{Param: param, Reason: fmt.Sprintf("unknown filter, allowed: %s", strings.Join(s.names(false), ", "))}
+		}
+		for _, raw := range q[param] {
+			filter, err := parseFilter(param, field, raw)
+			if err != nil {
+				return Query{}, err
+			}
+			query.Filters = append(query.Filters, filter)
+		}
+	}
+
+	return query, nil
+}
+
+// parseCursor switches the query to cursor mode, continuing after the position of a non-empty cursor
+func (s Spec) parseCursor(query *Query, q url.Values) error {
+	if s.Key == "" {
+		return Error{Param: ParamCursor, Reason: "cursor pagination is not supported here, use page"}
+	}
+	if q.Get(ParamPage) != "" {
+		return Error{Param: ParamCursor, Reason: "cannot be combined with page"}
+	}
+	if s.Fields[query.sortField].Nullable {
+		return Error{Param: ParamCursor, Reason: fmt.Sprintf("cannot paginate by cursor sorted by %s", query.sortField)}
+	}
+	query.Cursor = true
+	query.Page = 0
+
+	raw := q.Get(ParamCursor)
+	if raw == "" {
+		return nil
+	}
+	var c cursor
+	decoded, err := base64.RawURLEncoding.DecodeString(raw)
+	if err == nil {
+		err = json.Unmarshal(decoded, &c)
+	}
+	if err != nil {
+		return Error{Param: ParamCursor, Reason: "malformed cursor"}
+	}
+	if c.Field != query.sortField || c.Desc != query.Desc {
+		return Error{Param: ParamCursor, Reason: "cursor was made for another orderField or orderDir"}
+	}
+
+	value, err := parseValue(ParamCursor, s.Fields[c.Field].Kind, c.Value)
+	if err != nil {
+		return Error{Param: ParamCursor, Reason: "malformed cursor"}
+	}
+	after := &position{Value: value}
+	if query.key != "" {
+		if after.Key, err = parseValue(ParamCursor, s.Fields[query.key].Kind, c.Key); err != nil {
+			return Error{Param: ParamCursor, Reason: "malformed cursor"}
+		}
+	}
+	query.after = after
+	return nil
+}
+
+// names lists the sortable or filterable params
+func (s Spec) names(sortable bool) []string {
+	var names []string
+	for name, field := range s.Fields {
+		if (sortable && field.Sortable) || (!sortable && field.Filterable) {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+	return names
+}
+
+// parseFilter parses "operator:value", a value without a known operator prefix is matched for equality
+func parseFilter(param string, field Field, raw string) (Filter, error) {
+	operator, value := OpEq, raw
+	if field.Operator != "" {
+		operator = field.Operator
+	} else if prefix, rest, found := strings.Cut(raw, ":"); found && isOperator(prefix) {
+		operator, value = prefix, rest
+	}
+	if !slices.Contains(operators[field.Kind], operator) {
+		return Filter{}, Error{Param: param, Reason: fmt.Sprintf("operator %s not allowed, allowed: %s", operator, strings.Join(operators[field.Kind], ", "))}
+	}
+
+	filter := Filter{Column: field.Column, Operator: operator}
+	switch operator {
+	case OpIn:
+		var values []any
+		for _, v := range strings.Split(value, ",") {
+			parsed, err := parseValue(param, field.Kind, v)
+			if err != nil {
+				return Filter{}, err
+			}
+			values = append(values, parsed)
+		}
+		filter.Value = values
+	case OpLike:
+		if value == "" {
+			return Filter{}, Error{Param: param, Reason: "like needs a value"}
+		}
+		filter.Value = "%" + EscapeLike(value) + "%"
+	default:
+		parsed, err := parseValue(param, field.Kind, value)
+		if err != nil {
+			return Filter{}, err
+		}
+		filter.Value = parsed
+	}
+	return filter, nil
+}
+
+func isOperator(s string) bool {
+	_, ok := sqlOperators[s]
+	return ok || s == OpLike || s == OpIn
+}
+
+// parseValue converts a param value to the kind of its field. Times are RFC 3339 or a date.
+func parseValue(param string, kind Kind, value string) (any, error) {
+	switch kind {
+	case Int:
+		v, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return nil, Error{Param: param, Reason: fmt.Sprintf("%q is not a number", value)}
+		}
+		return v, nil
+	case Time:
+		for _, layout := range []string{time.RFC3339, time.DateOnly} {
+			if v, err := time.Parse(layout, value); err == nil {
+				return v, nil
+			}
+		}
+		return nil, Error{Param: param, Reason: fmt.Sprintf("%q is not a date (YYYY-MM-DD) or RFC 3339 time", value)}
+	case UUID:
+		v, err := uuid.Parse(value)
+		if err != nil {
+			return nil, Error{Param: param, Reason: fmt.Sprintf("%q is not a UUID", value)}
+		}
+		return v.String(), nil
+	default:
+		return value, nil
+	}
+}
+
+// positiveInt reads an int param of at least 1 and, when max is set, at most max
+func positiveInt(q url.Values, param string, fallback int, max int) (int, error) {
+	raw := q.Get(param)
+	if raw == "" {
+		return fallback, nil
+	}
+	v, err := strconv.Atoi(raw)
+	if err != nil || v < 1 {
+		return 0, Error{Param: param, Reason: "must be a positive number"}
+	}
+	if max > 0 && v > max {
+		return 0, Error{Param: param, Reason: fmt.Sprintf("must be at most %d", max)}
+	}
+	return v, nil
+}
+
+// EscapeLike escapes the wildcards of a LIKE pattern
+func EscapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
+}
+
+// Where applies the filters
+func (q Query) Where(db *gorm.DB) *gorm.DB {
+	for _, f := range q.Filters {
+		switch f.Operator {
+		case OpIn:
+			db = db.Where(fmt.Sprintf("%s IN ?", f.Column), f.Value)
+		case OpLike:
+			db = db.Where(fmt.Sprintf("%s ILIKE ?", f.Column), f.Value)
+		default:
+			db = db.Where(fmt.Sprintf("%s %s ?", f.Column, sqlOperators[f.Operator]), f.Value)
+		}
+	}
+	return db
+}
+
+// Order applies the sorting, ties are broken by the key
+func (q Query) Order(db *gorm.DB) *gorm.DB {
+	db = db.Order(clause.OrderByColumn{Column: clause.Column{Name: q.Sort, Raw: true}, Desc: q.Desc})
+	if q.keyColumn != "" {
+		db = db.Order(clause.OrderByColumn{Column: clause.Column{Name: q.keyColumn, Raw: true}, Desc: q.Desc})
+	}
+	return db
+}
+
+// Paginate applies the page, or the cursor position, and the limit. One row more than the limit
+// is fetched so Page can tell whether there is a next page.
+func (q Query) Paginate(db *gorm.DB) *gorm.DB {
+	db = db.Limit(q.Limit + 1)
+	if !q.Cursor {
+		return db.Offset(q.Limit * (q.Page - 1))
+	}
+	if q.after == nil {
+		return db
+	}
+	operator := ">"
+	if q.Desc {
+		operator = "<"
+	}
+	if q.keyColumn == "" {
+		return db.Where(fmt.Sprintf("%s %s ?", q.Sort, operator), q.after.Value)
+	}
+	return db.Where(fmt.Sprintf("(%s, %s) %s (?, ?)", q.Sort, q.keyColumn, operator), q.after.Value, q.after.Key)
+}
+
+// Apply applies the filters, sorting and pagination
+func (q Query) Apply(db *gorm.DB) *gorm.DB {
+	return q.Paginate(q.Order(q.Where(db)))
+}
+
+// Page trims the extra row fetched by Paginate off rows and describes the page. In page mode the total
+// is counted from count, which should be filtered by Where only. In cursor mode value reads the value of
+// a field of a row to make the next cursor.
+func Page[T any](q Query, rows []T, count *gorm.DB, value func(row T, field string) any) ([]T, models.PageMeta, error) {
+	meta := models.PageMeta{Page: q.Page, Limit: q.Limit, HasNext: len(rows) > q.Limit}
+	if meta.HasNext {
+		rows = rows[:q.Limit]
+	}
+	if rows == nil {
+		rows = []T{}
+	}
+
+	if q.Cursor {
+		if meta.HasNext {
+			last := rows[len(rows)-1]
+			c := cursor{Field: q.sortField, Desc: q.Desc, Value: formatValue(value(last, q.sortField))}
+			if q.key != "" {
+				c.Key = formatValue(value(last, q.key))
+			}
+			encoded, err := json.Marshal(c)
+			if err != nil {
+				return nil, models.PageMeta{}, err
+			}
+			meta.NextCursor = base64.RawURLEncoding.EncodeToString(encoded)
+		}
+		return rows, meta, nil
+	}
+
+	var total int64
+	if err := count.Count(&total).Error; err != nil {
+		return nil, models.PageMeta{}, err
+	}
+	meta.Total = &total
+	return rows, meta, nil
+}
+
+// formatValue formats a value of a row the way parseValue reads it back
+func formatValue(v any) string {
+	switch v := v.(type) {
+	case time.Time:
+		return v.Format(time.RFC3339Nano)
+	case *time.Time:
+		if v == nil {
+			return ""
+		}
+		return v.Format(time.RFC3339Nano)
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,193 @@
+package queryspec
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+)
+
+var testSpec = Spec{
+	Fields: map[string]Field{
+		"id":         {Column: "t.id", Kind: Int, Filterable: true, Sortable: true},
+		"title":      {Column: "t.title", Kind: String, Filterable: true, Sortable: true},
+		"status":     {Column: "t.status", Kind: String, Filterable: true},
+		"created_at": {Column: "t.created_at", Kind: Time, Filterable: true, Sortable: true},
+		"score":      {Column: "score", Sortable: true},
+		"from":       {Column: "t.created_at", Kind: Time, Filterable: true, Operator: OpGte},
+		"due_at":     {Column: "t.due_at", Kind: Time, Sortable: true, Nullable: true},
+		"owner":      {Column: "t.owner_uuid", Kind: UUID, Filterable: true},
+	},
+	Key:          "id",
+	DefaultSort:  "id",
+	DefaultDir:   "desc",
+	DefaultLimit: 10,
+	MaxLimit:     100,
+}
+
+func TestSpec_Parse(t *testing.T) {
+	tests := []struct {
+		name      string
+		q         url.Values
+		want      Query
+		wantParam string
+	}{
+		{
+			name: "Defaults",
+			q:    url.Values{},
+			want: Query{Sort: "t.id", Desc: true, Page: 1, Limit: 10, sortField: "id"},
+		},
+		{
+			name: "Sort and paginate",
+			q:    url.Values{"orderField": {"score"}, "orderDir": {"ASC"}, "page": {"3"}, "limit": {"20"}},
+			want: Query{Sort: "score", Desc: false, Page: 3, Limit: 20, sortField: "score", key: "id", keyColumn: "t.id"},
+		},
+		{
+			name: "Operators",
+			q: url.Values{
+				"status":     {"in:DRAFT,PUBLISHED"},
+				"created_at": {"gte:2026-01-01", "lt:2026-02-01T00:00:00Z"},
+				"title":      {"like:go_1%"},
+				"id":         {"5"},
+			},
+			want: Query{
+				Filters: []Filter{
+					{Column: "t.created_at", Operator: OpGte, Value: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)},
+					{Column: "t.created_at", Operator: OpLt, Value: time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)},
+					{Column: "t.id", Operator: OpEq, Value: int64(5)},
+					{Column: "t.status", Operator: OpIn, Value: []any{"DRAFT", "PUBLISHED"}},
+					{Column: "t.title", Operator: OpLike, Value: `%go\_1\%%`},
+				},
+				Sort: "t.id", Desc: true, Page: 1, Limit: 10, sortField: "id",
+			},
+		},
+		{
+			name: "Value with colon but no operator",
+			q:    url.Values{"title": {"note: hello"}},
+			want: Query{
+				Filters: []Filter{{Column: "t.title", Operator: OpEq, Value: "note: hello"}},
+				Sort:    "t.id", Desc: true, Page: 1, Limit: 10, sortField: "id",
+			},
+		},
+		{
+			name: "Fixed operator",
+			q:    url.Values{"from": {"2026-01-01T10:00:00Z"}},
+			want: Query{
+				Filters: []Filter{{Column: "t.created_at", Operator: OpGte, Value: time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)}},
+				Sort:    "t.id", Desc: true, Page: 1, Limit: 10, sortField: "id",
+			},
+		},
+		{
+			name: "UUID",
+			q:    url.Values{"owner": {"in:0E0A7E2C-8C3B-4F4B-9C53-7D1F3A6B2E10,5b7f0a51-2d0e-4a8e-9b43-3c2a6f1e8d77"}},
+			want: Query{
+				Filters: []Filter{{Column: "t.owner_uuid", Operator: OpIn, Value: []any{"0e0a7e2c-8c3b-4f4b-9c53-7d1f3a6b2e10", "5b7f0a51-2d0e-4a8e-9b43-3c2a6f1e8d77"}}},
+				Sort:    "t.id", Desc: true, Page: 1, Limit: 10, sortField: "id",
+			},
+		},
+		{
+			name: "Ignored param",
+			q:    url.Values{"q": {"search"}},
+			want: Query{Sort: "t.id", Desc: true, Page: 1, Limit: 10, sortField: "id"},
+		},
+		{
+			name: "First cursor page",
+			q:    url.Values{"cursor": {""}, "orderField": {"created_at"}},
+			want: Query{Sort: "t.created_at", Desc: true, Limit: 10, Cursor: true, sortField: "created_at", key: "id", keyColumn: "t.id"},
+		},
+		{
+			name: "Next cursor page",
+			q:    url.Values{"cursor": {encodeCursor(cursor{Field: "created_at", Desc: true, Value: "2026-01-02T03:04:05.123456Z", Key: "7"})}, "orderField": {"created_at"}},
+			want: Query{
+				Sort: "t.created_at", Desc: true, Limit: 10, Cursor: true, sortField: "created_at", key: "id", keyColumn: "t.id",
+				after: &position{Value: time.Date(2026, 1, 2, 3, 4, 5, 123456000, time.UTC), Key: int64(7)},
+			},
+		},
+		{name: "Malformed cursor", q: url.Values{"cursor": {"!!"}}, wantParam: "cursor"},
+		{name: "Cursor of another sort", q: url.Values{"cursor": {encodeCursor(cursor{Field: "title", Desc: true, Value: "a", Key: "1"})}}, wantParam: "cursor"},
+		{name: "Cursor with page", q: url.Values{"cursor": {""}, "page": {"2"}}, wantParam: "cursor"},
+		{name: "Cursor sorted by nullable", q: url.Values{"cursor": {""}, "orderField": {"due_at"}}, wantParam: "cursor"},
+		{name: "Unknown filter", q: url.Values{"password": {"x"}}, wantParam: "password"},
+		{name: "Sort only field", q: url.Values{"score": {"1"}}, wantParam: "score"},
+		{name: "Unknown sort", q: url.Values{"orderField": {"id; drop table t"}}, wantParam: "orderField"},
+		{name: "Filter only field sort", q: url.Values{"orderField": {"status"}}, wantParam: "orderField"},
+		{name: "Bad dir", q: url.Values{"orderDir": {"sideways"}}, wantParam: "orderDir"},
+		{name: "Bad page", q: url.Values{"page": {"0"}}, wantParam: "page"},
+		{name: "Limit too big", q: url.Values{"limit": {"1000"}}, wantParam: "limit"},
+		{name: "Operator not allowed", q: url.Values{"status": {"gte:DRAFT"}}, wantParam: "status"},
+		{name: "Bad number", q: url.Values{"id": {"in:1,x"}}, wantParam: "id"},
+		{name: "Bad time", q: url.Values{"created_at": {"gte:yesterday"}}, wantParam: "created_at"},
+		{name: "Bad UUID", q: url.Values{"owner": {"12"}}, wantParam: "owner"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := testSpec.Parse(tt.q, "q")
+			if tt.wantParam != "" {
+				var queryErr Error
+				if !errors.As(err, &queryErr) || queryErr.Param != tt.wantParam {
+					t.Fatalf("Spec.Parse() error = %v, want error on %s", err, tt.wantParam)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Spec.Parse() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Spec.Parse() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func encodeCursor(c cursor) string {
+	encoded, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(encoded)
+}
+
+type row struct {
+	id    int64
+	title string
+}
+
+func rowValue(r row, field string) any {
+	if field == "title" {
+		return r.title
+	}
+	return r.id
+}
+
+func TestPage_Cursor(t *testing.T) {
+	q := url.Values{"cursor": {""}, "orderField": {"title"}, "orderDir": {"asc"}, "limit": {"2"}}
+	query, err := testSpec.Parse(q)
+	if err != nil {
+		t.Fatalf("Spec.Parse() error = %v", err)
+	}
+
+	rows, meta, err := Page(query, []row{{1, "a"}, {2, "b"}, {3, "c"}}, nil, rowValue)
+	if err != nil {
+		t.Fatalf("Page() error = %v", err)
+	}
+	if len(rows) != 2 || !meta.HasNext || meta.Total != nil || meta.NextCursor == "" {
+		t.Fatalf("Page() = %+v, %+v", rows, meta)
+	}
+
+	q.Set("cursor", meta.NextCursor)
+	next, err := testSpec.Parse(q)
+	if err != nil {
+		t.Fatalf("Spec.Parse() next error = %v", err)
+	}
+	if want := (&position{Value: "b", Key: int64(2)}); !reflect.DeepEqual(next.after, want) {
+		t.Errorf("next position = %+v, want %+v", next.after, want)
+	}
+
+	rows, meta, err = Page(next, []row{{3, "c"}}, nil, rowValue)
+	if err != nil {
+		t.Fatalf("Page() error = %v", err)
+	}
+	if len(rows) != 1 || meta.HasNext || meta.NextCursor != "" {
+		t.Errorf("last Page() = %+v, %+v", rows, meta)
+	}
+}
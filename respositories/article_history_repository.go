@@ -2,10 +2,13 @@ package respositories
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
-	"strconv"
+	"log"
+	"net/url"
 
 	"github.com/herdiansc/go-cms/models"
+	"github.com/herdiansc/go-cms/queryspec"
 	"gorm.io/gorm"
 )
 
@@ -19,69 +22,131 @@ func NewArticleHistoryRepository(db *gorm.DB) ArticleHistoryRepository {
 	return ArticleHistoryRepository{db: db}
 }
 
-// Create saves an article data
-func (repo ArticleHistoryRepository) Create(action string, data models.Article) error {
-	return repo.db.Transaction(func(tx *gorm.DB) error {
-		lastArticleHistory := models.ArticleHistory{}
-		result := repo.db.Where("article_id = ?", data.ID).Order("id desc").First(&lastArticleHistory)
-		var version int64 = 1
-		if result.Error == nil {
-			version = lastArticleHistory.Version + 1
-		}
-		articleJson, _ := json.Marshal(data)
-		newArticleHistory := models.ArticleHistory{
-			Article:   string(articleJson),
-			Version:   version,
-			Status:    data.Status,
-			ArticleID: data.ID,
-			Action:    action,
-		}
+// maxHistoryAttempts bounds the attempts of a transaction racing another one for a history version or slug
+const maxHistoryAttempts = 3
 
-		if err := tx.Create(&newArticleHistory).Error; err != nil {
+// transactionWithHistory runs fn, which records article history, in a transaction. Versions are unique
+// per article and slugs are unique, so when a concurrent transaction took the same version or slug the
+// whole transaction is retried.
+func transactionWithHistory(db *gorm.DB, fn func(tx *gorm.DB) error) error {
+	var err error
+	for attempt := 1; attempt <= maxHistoryAttempts; attempt++ {
+		err = db.Transaction(fn)
+		if !errors.Is(err, gorm.ErrDuplicatedKey) {
 			return err
 		}
+		log.Printf("History version or slug taken, retrying %d/%d: %+v\n", attempt, maxHistoryAttempts, err.Error())
+	}
+	return err
+}
+
+// newArticleHistory inits the history entry of a change made by actor
+func newArticleHistory(action string, actor models.Actor, comment string) models.ArticleHistory {
+	return models.ArticleHistory{
+		Action:    action,
+		ActorID:   actor.ID,
+		RequestID: actor.RequestID,
+		Comment:   comment,
+	}
+}
+
+// createArticleHistory saves a snapshot of an article, with its tags and writer set, as its next version
+// within tx. entry carries the action, actor, request and comment of the change. The version of the
+// article is bumped by an UPDATE applying only while the article is still at data.Version, so a change
+// based on a stale version fails with models.ErrArticleVersionMismatch.
+func createArticleHistory(tx *gorm.DB, entry models.ArticleHistory, data *models.Article) error {
+	version := data.Version + 1
+	result := tx.Exec("UPDATE articles SET version = ? WHERE id = ? AND version = ?", version, data.ID, data.Version)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return models.ErrArticleVersionMismatch
+	}
+	data.Version = version
+	if err := withTagsAndWriters(tx, data); err != nil {
+		return err
+	}
+
+	articleJson, _ := json.Marshal(data)
+	entry.Article = string(articleJson)
+	entry.Version = version
+	entry.Status = data.Status
+	entry.ArticleID = data.ID
 
-		return nil
-	})
+	return tx.Create(&entry).Error
 }
 
-// List lists of all article histories by filter
-func (repo ArticleHistoryRepository) List(params map[string]interface{}) ([]models.ArticleHistory, error) {
+// List lists a page of article histories of an article by filter, see articleHistoryQuerySpec for the accepted params
+func (repo ArticleHistoryRepository) List(articleID int64, q url.Values) ([]models.ArticleHistory, models.PageMeta, error) {
+	query, err := articleHistoryQuerySpec.Parse(q)
+	if err != nil {
+		return nil, models.PageMeta{}, err
+	}
+
+	db := query.Where(repo.db.Debug().Model(&models.ArticleHistory{}).Where("article_id = ?", articleID)).Session(&gorm.Session{})
 	var data []models.ArticleHistory
-	limit := 10
-	if _, ok := params["limit"]; ok {
-		limitStr, _ := params["limit"].(string)
-		limit, _ = strconv.Atoi(limitStr)
-		delete(params, "limit")
-	}
-	page := 1
-	if _, ok := params["page"]; ok {
-		pageStr, _ := params["page"].(string)
-		page, _ = strconv.Atoi(pageStr)
-		delete(params, "page")
-	}
-	orderField := "id"
-	if _, ok := params["orderField"]; ok {
-		orderField, _ = params["orderField"].(string)
-		delete(params, "orderField")
-	}
-	orderDir := "desc"
-	if _, ok := params["orderDir"]; ok {
-		orderDir, _ = params["orderDir"].(string)
-		delete(params, "orderDir")
-	}
-
-	result := repo.db.Debug().Where(params).
-		Order(fmt.Sprintf("%s %s", orderField, orderDir)).
-		Limit(limit).
-		Offset(limit * (page - 1)).
-		Find(&data)
-	return data, result.Error
+	if err := query.Paginate(query.Order(db)).Find(&data).Error; err != nil {
+		return nil, models.PageMeta{}, err
+	}
+	data, meta, err := queryspec.Page(query, data, db, articleHistoryValue)
+	if err != nil {
+		return nil, models.PageMeta{}, err
+	}
+
+	histories := make([]*models.ArticleHistory, len(data))
+	for i := range data {
+		histories[i] = &data[i]
+	}
+	if err := withHistoryUUIDs(repo.db, histories...); err != nil {
+		return nil, models.PageMeta{}, err
+	}
+	return data, meta, nil
 }
 
-// FindByParam finds an article by a specific param
+// FindByParam finds an article history by a specific param
 func (repo ArticleHistoryRepository) FindByParam(param string, value any) (models.ArticleHistory, error) {
 	var data models.ArticleHistory
 	result := repo.db.Where(fmt.Sprintf("%s = ?", param), value).First(&data)
-	return data, result.Error
+	if result.Error != nil {
+		return data, result.Error
+	}
+	return data, withHistoryUUIDs(repo.db, &data)
+}
+
+// FindVersion finds a version of an article history
+func (repo ArticleHistoryRepository) FindVersion(articleID int64, version int64) (models.ArticleHistory, error) {
+	var data models.ArticleHistory
+	result := repo.db.Where("article_id = ? AND version = ?", articleID, version).First(&data)
+	if result.Error != nil {
+		return data, result.Error
+	}
+	return data, withHistoryUUIDs(repo.db, &data)
+}
+
+// withHistoryUUIDs sets the uuids of the article and actor of article histories, changes made by
+// the scheduler have no actor
+func withHistoryUUIDs(db *gorm.DB, histories ...*models.ArticleHistory) error {
+	articleIDs := make([]int64, len(histories))
+	actorIDs := make([]int64, 0, len(histories))
+	for i, history := range histories {
+		articleIDs[i] = history.ArticleID
+		if history.ActorID != 0 {
+			actorIDs = append(actorIDs, history.ActorID)
+		}
+	}
+
+	articles, err := uuidsByID(db, &models.Article{}, articleIDs)
+	if err != nil {
+		return err
+	}
+	actors, err := uuidsByID(db, &models.Auth{}, actorIDs)
+	if err != nil {
+		return err
+	}
+	for _, history := range histories {
+		history.ArticleUUID = articles[history.ArticleID]
+		history.ActorUUID = actors[history.ActorID]
+	}
+	return nil
 }
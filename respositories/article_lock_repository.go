@@ -0,0 +1,110 @@
+package respositories
+
+import (
+	"errors"
+	"time"
+
+	"github.com/herdiansc/go-cms/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ArticleLockRepository struct
+type ArticleLockRepository struct {
+	db *gorm.DB
+}
+
+// NewArticleLockRepository inits ArticleLockRepository
+func NewArticleLockRepository(db *gorm.DB) ArticleLockRepository {
+	return ArticleLockRepository{db: db}
+}
+
+// Acquire locks an article for owner until ttl from now, renewing the lock when owner already holds it.
+// The lock is taken in a single upsert which only overwrites a lock of the same owner or an expired one,
+// otherwise it returns the lock of the other user with models.ErrArticleLocked.
+func (repo ArticleLockRepository) Acquire(articleID int64, ownerID int64, ttl time.Duration) (models.ArticleLock, error) {
+	now := time.Now()
+	data := models.ArticleLock{ArticleID: articleID, OwnerID: ownerID, ExpiresAt: now.Add(ttl)}
+	result := repo.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "article_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"owner_id", "expires_at", "updated_at"}),
+		Where: clause.Where{Exprs: []clause.Expression{
+			clause.Expr{SQL: "article_locks.owner_id = excluded.owner_id OR article_locks.expires_at <= ?", Vars: []any{now}},
+		}},
+	}).Create(&data)
+	if result.Error != nil {
+		return models.ArticleLock{}, result.Error
+	}
+	if result.RowsAffected == 0 {
+		current, err := repo.FindActive(articleID)
+		if errors.Is(err, models.ErrArticleNotLocked) {
+			// the other lock expired in between, try again
+			return repo.Acquire(articleID, ownerID, ttl)
+		}
+		if err != nil {
+			return models.ArticleLock{}, err
+		}
+		return current, models.ErrArticleLocked
+	}
+
+	return repo.FindActive(articleID)
+}
+
+// FindActive finds the lock of an article which has not expired, with its article and owner uuids set.
+// It returns models.ErrArticleNotLocked when there is none.
+func (repo ArticleLockRepository) FindActive(articleID int64) (models.ArticleLock, error) {
+	var data models.ArticleLock
+	result := repo.db.Where("article_id = ? AND expires_at > ?", articleID, time.Now()).First(&data)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return data, models.ErrArticleNotLocked
+	}
+	if result.Error != nil {
+		return data, result.Error
+	}
+	return data, withLockUUIDs(repo.db, &data)
+}
+
+// Release deletes the lock of an article held by owner
+func (repo ArticleLockRepository) Release(articleID int64, ownerID int64) error {
+	result := repo.db.Where("article_id = ? AND owner_id = ?", articleID, ownerID).Delete(&models.ArticleLock{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return models.ErrArticleNotLocked
+	}
+	return nil
+}
+
+// checkArticleLock returns models.ErrArticleLocked when a user other than actor holds a lock on an article
+// which has not expired. It runs within tx after the article is locked, the lock found is share locked so
+// it cannot be taken over before tx ends.
+func checkArticleLock(tx *gorm.DB, articleID int64, actor models.Actor) error {
+	var data models.ArticleLock
+	result := tx.Clauses(clause.Locking{Strength: "SHARE"}).
+		Where("article_id = ? AND expires_at > ?", articleID, time.Now()).
+		Limit(1).
+		Find(&data)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected > 0 && data.OwnerID != actor.ID {
+		return models.ErrArticleLocked
+	}
+	return nil
+}
+
+// withLockUUIDs sets the uuid of the article and of the owner of a lock
+func withLockUUIDs(db *gorm.DB, data *models.ArticleLock) error {
+	articles, err := uuidsByID(db.Unscoped(), &models.Article{}, []int64{data.ArticleID})
+	if err != nil {
+		return err
+	}
+	owners, err := uuidsByID(db, &models.Auth{}, []int64{data.OwnerID})
+	if err != nil {
+		return err
+	}
+	data.ArticleUUID = articles[data.ArticleID]
+	data.OwnerUUID = owners[data.OwnerID]
+	return nil
+}
@@ -0,0 +1,155 @@
+package respositories
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/herdiansc/go-cms/models"
+	"gorm.io/gorm"
+)
+
+// maxArticleRelations is the number of relations kept per article, the most GET /articles/{uuid}/related lists
+const maxArticleRelations = 50
+
+// articleRelationsQuery scores the relations of seed articles, given by a query selecting their article_id,
+// by weighted Jaccard similarity: the weight of their shared tags over the weight of all their tags. A tag
+// weighs more the rarer it is (inverse document frequency), so sharing a niche tag relates articles more
+// than sharing a common one. Only the articles sharing a tag with a seed article and the weights of their
+// tags are read. Scores are symmetric: the top maxArticleRelations of each seed article are stored, and
+// each of them is stored in the other direction too unless it is a seed article itself. Trashed articles
+// are left out.
+const articleRelationsQuery = `WITH seed AS (
+	%s
+), candidates AS (
+	SELECT DISTINCT other.article_id
+	FROM article_tags own
+	JOIN article_tags other ON other.tag_id = own.tag_id
+	WHERE own.article_id IN (SELECT article_id FROM seed)
+), live_tags AS (
+	SELECT article_tags.article_id, article_tags.tag_id
+	FROM article_tags
+	JOIN articles ON articles.id = article_tags.article_id AND articles.deleted_at IS NULL
+	WHERE article_tags.article_id IN (SELECT article_id FROM candidates)
+), idf AS (
+	SELECT article_tags.tag_id, ln(1 + (SELECT count(*) FROM articles WHERE deleted_at IS NULL)::float / count(*)) AS weight
+	FROM article_tags
+	JOIN articles ON articles.id = article_tags.article_id AND articles.deleted_at IS NULL
+	WHERE article_tags.tag_id IN (SELECT tag_id FROM live_tags)
+	GROUP BY article_tags.tag_id
+), totals AS (
+	SELECT live_tags.article_id, sum(idf.weight) AS weight
+	FROM live_tags
+	JOIN idf ON idf.tag_id = live_tags.tag_id
+	GROUP BY live_tags.article_id
+), shared AS (
+	SELECT a.article_id, b.article_id AS related_id, sum(idf.weight) AS weight
+	FROM live_tags a
+	JOIN live_tags b ON b.tag_id = a.tag_id AND b.article_id <> a.article_id
+	JOIN idf ON idf.tag_id = a.tag_id
+	WHERE a.article_id IN (SELECT article_id FROM seed)
+	GROUP BY a.article_id, b.article_id
+), ranked AS (
+	SELECT shared.article_id, shared.related_id, shared.weight / (ta.weight + tb.weight - shared.weight) AS score,
+		row_number() OVER (PARTITION BY shared.article_id ORDER BY shared.weight / (ta.weight + tb.weight - shared.weight) DESC, shared.related_id DESC) AS rank
+	FROM shared
+	JOIN totals ta ON ta.article_id = shared.article_id
+	JOIN totals tb ON tb.article_id = shared.related_id
+)
+INSERT INTO article_relations (article_id, related_id, score, created_at, updated_at)
+SELECT article_id, related_id, score, now(), now() FROM ranked WHERE rank <= ?
+UNION ALL
+SELECT related_id, article_id, score, now(), now() FROM ranked
+WHERE rank <= ? AND related_id NOT IN (SELECT article_id FROM seed)`
+
+// trimArticleRelationsQuery deletes the relations beyond the top maxArticleRelations of the articles related
+// to articles matching a condition
+const trimArticleRelationsQuery = `DELETE FROM article_relations WHERE id IN (
+	SELECT id FROM (
+		SELECT id, row_number() OVER (PARTITION BY article_id ORDER BY score DESC, related_id DESC) AS rank
+		FROM article_relations
+		WHERE article_id IN (SELECT related_id FROM article_relations WHERE article_id IN ?)
+	) AS ranked
+	WHERE rank > ?
+)`
+
+// refreshArticleRelations rescores the relations of articles within tx, after their tags changed. Articles
+// they relate to may go over maxArticleRelations and are trimmed. Scores between other articles keep their
+// tag weights until the next Rebuild.
+func refreshArticleRelations(tx *gorm.DB, articleIDs ...int64) error {
+	if len(articleIDs) == 0 {
+		return nil
+	}
+	if err := deleteArticleRelations(tx, articleIDs...); err != nil {
+		return err
+	}
+	seed := "SELECT id AS article_id FROM articles WHERE id IN ?"
+	if err := tx.Exec(fmt.Sprintf(articleRelationsQuery, seed), articleIDs, maxArticleRelations, maxArticleRelations).Error; err != nil {
+		return err
+	}
+	return tx.Exec(trimArticleRelationsQuery, articleIDs, maxArticleRelations).Error
+}
+
+// deleteArticleRelations deletes the relations from and to articles within tx
+func deleteArticleRelations(tx *gorm.DB, articleIDs ...int64) error {
+	return tx.Where("article_id IN ? OR related_id IN ?", articleIDs, articleIDs).Delete(&models.ArticleRelation{}).Error
+}
+
+// ArticleRelationRepository struct
+type ArticleRelationRepository struct {
+	db *gorm.DB
+}
+
+// NewArticleRelationRepository inits ArticleRelationRepository
+func NewArticleRelationRepository(db *gorm.DB) ArticleRelationRepository {
+	return ArticleRelationRepository{db: db}
+}
+
+// Rebuild rescores the relations of all articles, keeping the top maxArticleRelations of each, returning
+// the number of stored relations. Cancelling ctx aborts the rebuild.
+func (repo ArticleRelationRepository) Rebuild(ctx context.Context) (int64, error) {
+	var count int64
+	err := repo.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("1 = 1").Delete(&models.ArticleRelation{}).Error; err != nil {
+			return err
+		}
+		seed := "SELECT id AS article_id FROM articles WHERE deleted_at IS NULL"
+		result := tx.Exec(fmt.Sprintf(articleRelationsQuery, seed), maxArticleRelations, maxArticleRelations)
+		count = result.RowsAffected
+		return result.Error
+	})
+	return count, err
+}
+
+// List finds the articles most related to an article, with their tags and TagRelationshipScore set
+func (repo ArticleRelationRepository) List(articleID int64, filter models.RelatedArticlesFilter) ([]models.Article, error) {
+	var rows []struct {
+		models.Article `gorm:"embedded"`
+		Score          float64
+	}
+	db := repo.db.Model(&models.ArticleRelation{}).
+		Select("articles.*, article_relations.score").
+		Joins("JOIN articles ON articles.id = article_relations.related_id").
+		Where("article_relations.article_id = ?", articleID)
+	if filter.Status != "" {
+		db = db.Where("articles.status = ?", filter.Status)
+	}
+	if filter.ExcludeWriterID != 0 {
+		db = db.Where("articles.writer_id <> ?", filter.ExcludeWriterID)
+	}
+	result := db.Order("article_relations.score DESC, articles.id DESC").Limit(filter.Limit).Scan(&rows)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	data := make([]models.Article, len(rows))
+	articles := make([]*models.Article, len(rows))
+	for i, row := range rows {
+		data[i] = row.Article
+		data[i].TagRelationshipScore = row.Score
+		articles[i] = &data[i]
+	}
+	if err := withTagsAndWriters(repo.db, articles...); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
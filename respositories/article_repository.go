@@ -1,13 +1,20 @@
 package respositories
 
 import (
-	"errors"
+	"context"
 	"fmt"
+	"html"
+	"net/url"
+	"slices"
 	"strconv"
 	"strings"
+	"time"
 
+	"github.com/herdiansc/go-cms/config"
 	"github.com/herdiansc/go-cms/models"
+	"github.com/herdiansc/go-cms/queryspec"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // ArticleRepository struct
@@ -20,127 +27,777 @@ func NewArticleRepository(db *gorm.DB) ArticleRepository {
 	return ArticleRepository{db: db}
 }
 
-// List finds list of all articles by filter
-func (repo ArticleRepository) List(params map[string]interface{}) ([]models.Article, error) {
-	fmt.Println(params)
+// List finds a page of articles with their tags by filter, see articleQuerySpec and whereTags for the accepted params
+func (repo ArticleRepository) List(q url.Values) ([]models.Article, models.PageMeta, error) {
+	return repo.list(articleQuerySpec, repo.db.Debug().Model(&models.Article{}), q)
+}
+
+// ListPublished finds a page of published articles with their tags by filter, see publicArticleQuerySpec
+// and whereTags for the accepted params
+func (repo ArticleRepository) ListPublished(q url.Values) ([]models.Article, models.PageMeta, error) {
+	return repo.list(publicArticleQuerySpec, published(repo.db.Model(&models.Article{})), q)
+}
+
+// FindPublishedBySlug finds a published article with its tags by its slug or a former slug redirecting to it
+func (repo ArticleRepository) FindPublishedBySlug(slug string) (models.Article, error) {
+	return repo.findBySlug(published(repo.db), slug)
+}
+
+// FindBySlug finds an article with its tags by its slug or a former slug redirecting to it
+func (repo ArticleRepository) FindBySlug(slug string) (models.Article, error) {
+	return repo.findBySlug(repo.db, slug)
+}
+
+// findBySlug finds an article of db with its tags by its slug or a former slug redirecting to it
+func (repo ArticleRepository) findBySlug(db *gorm.DB, slug string) (models.Article, error) {
+	var data models.Article
+	redirected := repo.db.Model(&models.ArticleSlugRedirect{}).Select("article_id").Where("slug = ?", slug)
+	result := db.Where("articles.slug = ? OR articles.id IN (?)", slug, redirected).First(&data)
+	if result.Error != nil {
+		return data, result.Error
+	}
+	return data, withTagsAndWriters(repo.db, &data)
+}
+
+// published keeps the articles visible to the public: published and not deleted
+func published(db *gorm.DB) *gorm.DB {
+	return db.Where("articles.status = ? AND articles.deleted_at IS NULL", models.ArticleStatusPublished)
+}
+
+// list finds a page of the articles of db with their tags by the filter of spec
+func (repo ArticleRepository) list(spec queryspec.Spec, db *gorm.DB, q url.Values) ([]models.Article, models.PageMeta, error) {
+	query, err := spec.Parse(q, articleTagParams...)
+	if err != nil {
+		return nil, models.PageMeta{}, err
+	}
+	db, err = whereTags(query.Where(db), q)
+	if err != nil {
+		return nil, models.PageMeta{}, err
+	}
+	db = db.Session(&gorm.Session{})
+
 	var data []models.Article
-	limit := 10
-	if _, ok := params["limit"]; ok {
-		limitStr, _ := params["limit"].(string)
-		limit, _ = strconv.Atoi(limitStr)
-		delete(params, "limit")
-	}
-	page := 1
-	if _, ok := params["page"]; ok {
-		pageStr, _ := params["page"].(string)
-		page, _ = strconv.Atoi(pageStr)
-		delete(params, "page")
-	}
-	orderField := "id"
-	if _, ok := params["orderField"]; ok {
-		orderField, _ = params["orderField"].(string)
-		delete(params, "orderField")
-	}
-	orderDir := "desc"
-	if _, ok := params["orderDir"]; ok {
-		orderDir, _ = params["orderDir"].(string)
-		delete(params, "orderDir")
-	}
-
-	result := repo.db.Debug().Where(params).
-		Order(fmt.Sprintf("%s %s", orderField, orderDir)).
-		Limit(limit).
-		Offset(limit * (page - 1)).
-		Find(&data)
-	return data, result.Error
-}
-
-// Create saves an article data
-func (repo ArticleRepository) Create(writerID int64, data models.CreateArticleRequest) (models.Article, error) {
-	tx := repo.db.Begin()
-
-	article := data.Article()
-	article.WriterID = writerID
-	if err := tx.Create(&article).Error; err != nil {
-		tx.Rollback()
-		return models.Article{}, err
+	if err := query.Paginate(query.Order(db)).Find(&data).Error; err != nil {
+		return nil, models.PageMeta{}, err
+	}
+	data, meta, err := queryspec.Page(query, data, db, articleValue)
+	if err != nil {
+		return nil, models.PageMeta{}, err
 	}
 
-	for _, reqTag := range data.Tags {
-		var tag models.Tag
-		result := tx.Where("lower(title) = ?", reqTag).First(&tag)
-		tagID := tag.ID
-		if result.Error != nil {
-			newTag := models.Tag{
-				Title: strings.ToLower(reqTag),
+	articles := make([]*models.Article, len(data))
+	for i := range data {
+		articles[i] = &data[i]
+	}
+	if err := withTagsAndWriters(repo.db, articles...); err != nil {
+		return nil, models.PageMeta{}, err
+	}
+	return data, meta, nil
+}
+
+// articleTagParams are the tag filters of articles, see whereTags
+var articleTagParams = []string{"tag", "tags_any", "tags_all", "category"}
+
+// whereTags filters articles by tag title. Each tag param keeps articles having that tag, tags_any
+// articles having any of its comma separated tags and tags_all articles having all of them. Each
+// category param keeps articles having that tag or any of its descendants.
+func whereTags(db *gorm.DB, q url.Values) (*gorm.DB, error) {
+	const tagged = "FROM article_tags JOIN tags ON tags.id = article_tags.tag_id " +
+		"WHERE article_tags.article_id = articles.id AND tags.title IN ?"
+	const categorized = "FROM article_tags " +
+		"WHERE article_tags.article_id = articles.id AND article_tags.tag_id IN (" + tagDescendantsQuery + ")"
+
+	for _, param := range articleTagParams {
+		for _, raw := range q[param] {
+			titles := []string{raw}
+			if param == "tags_any" || param == "tags_all" {
+				titles = strings.Split(raw, ",")
 			}
-			_ = tx.Create(&newTag)
-			tagID = newTag.ID
+			for i, title := range titles {
+				titles[i] = models.NormalizeTagTitle(title)
+				if titles[i] == "" {
+					return nil, queryspec.Error{Param: param, Reason: "tag titles cannot be empty"}
+				}
+			}
+
+			switch param {
+			case "tags_all":
+				titles = slices.Compact(slices.Sorted(slices.Values(titles)))
+				db = db.Where("(SELECT count(DISTINCT tags.id) "+tagged+") = ?", titles, len(titles))
+			case "category":
+				db = db.Where("EXISTS (SELECT 1 "+categorized+")", titles)
+			default:
+				db = db.Where("EXISTS (SELECT 1 "+tagged+")", titles)
+			}
+		}
+	}
+	return db, nil
+}
+
+// withTags sets the tags of articles, sorted by title
+func withTags(db *gorm.DB, articles ...*models.Article) error {
+	if len(articles) == 0 {
+		return nil
+	}
+	ids := make([]int64, len(articles))
+	for i, article := range articles {
+		ids[i] = article.ID
+	}
+
+	var rows []struct {
+		ArticleID int64
+		Title     string
+	}
+	result := db.Model(&models.Tag{}).
+		Select("article_tags.article_id, tags.title").
+		Joins("JOIN article_tags ON article_tags.tag_id = tags.id").
+		Where("article_tags.article_id IN ?", ids).
+		Order("tags.title").
+		Scan(&rows)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	tags := make(map[int64][]string, len(articles))
+	for _, row := range rows {
+		tags[row.ArticleID] = append(tags[row.ArticleID], row.Title)
+	}
+	for _, article := range articles {
+		article.Tags = tags[article.ID]
+		if article.Tags == nil {
+			article.Tags = []string{}
 		}
-		_ = tx.Create(&models.ArticleTag{
-			ArticleID: article.ID,
-			TagID:     tagID,
-		})
 	}
+	return nil
+}
+
+// withWriters sets the uuid of the writers of articles
+func withWriters(db *gorm.DB, articles ...*models.Article) error {
+	ids := make([]int64, len(articles))
+	for i, article := range articles {
+		ids[i] = article.WriterID
+	}
+	uuids, err := uuidsByID(db, &models.Auth{}, ids)
+	if err != nil {
+		return err
+	}
+	for _, article := range articles {
+		article.WriterUUID = uuids[article.WriterID]
+	}
+	return nil
+}
+
+// withTagsAndWriters sets the tags and the uuid of the writers of articles
+func withTagsAndWriters(db *gorm.DB, articles ...*models.Article) error {
+	if err := withTags(db, articles...); err != nil {
+		return err
+	}
+	return withWriters(db, articles...)
+}
+
+// uuidsByID maps ids of a model to their uuids
+func uuidsByID(db *gorm.DB, model any, ids []int64) (map[int64]string, error) {
+	uuids := make(map[int64]string, len(ids))
+	if len(ids) == 0 {
+		return uuids, nil
+	}
+
+	var rows []struct {
+		ID   int64
+		UUID string
+	}
+	if err := db.Model(model).Select("id, uuid").Where("id IN ?", ids).Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		uuids[row.ID] = row.UUID
+	}
+	return uuids, nil
+}
+
+// headlineStart and headlineStop delimit the matches in the snippets of search results. They are
+// private use characters, stripped from the content beforehand, so they cannot come from an article.
+const (
+	headlineStart = "\uE000"
+	headlineStop  = "\uE001"
+)
+
+// headlineOptions configures the snippets of search results
+const headlineOptions = "StartSel=" + headlineStart + ", StopSel=" + headlineStop + ", MaxFragments=2, MaxWords=30, MinWords=10"
+
+// markHeadline escapes a snippet returned by ts_headline as HTML and wraps its matches in <mark>,
+// so markup stored in an article is shown as text rather than rendered
+func markHeadline(headline string) string {
+	return strings.NewReplacer(headlineStart, "<mark>", headlineStop, "</mark>").Replace(html.EscapeString(headline))
+}
+
+// Search finds articles matching a web search style query (quoted phrases, OR, -word), ranked by relevance
+// with title matches above content matches, and filtered and paginated like List. The q param is the query.
+func (repo ArticleRepository) Search(q url.Values) ([]models.ArticleSearchResult, models.PageMeta, error) {
+	query, err := articleSearchQuerySpec.Parse(q, append([]string{"q"}, articleTagParams...)...)
+	if err != nil {
+		return nil, models.PageMeta{}, err
+	}
+	language := config.SearchLanguage()
+
+	db, err := whereTags(query.Where(repo.db.Model(&models.Article{}).
+		Joins("CROSS JOIN websearch_to_tsquery(CAST(? AS regconfig), ?) search_query", language, q.Get("q")).
+		Where("articles.search_vector @@ search_query")), q)
+	if err != nil {
+		return nil, models.PageMeta{}, err
+	}
+	db = db.Session(&gorm.Session{})
+
+	var data []models.ArticleSearchResult
+	result := query.Paginate(query.Order(db.
+		Select("articles.*, ts_rank(articles.search_vector, search_query) AS rank, "+
+			"ts_headline(CAST(? AS regconfig), translate(articles.content, ?, ''), search_query, ?) AS headline",
+			language, headlineStart+headlineStop, headlineOptions)).
+		Order("articles.id desc")).Find(&data)
+	if result.Error != nil {
+		return nil, models.PageMeta{}, result.Error
+	}
+	for i := range data {
+		data[i].Headline = markHeadline(data[i].Headline)
+	}
+	data, meta, err := queryspec.Page(query, data, db, nil)
+	if err != nil {
+		return nil, models.PageMeta{}, err
+	}
+
+	articles := make([]*models.Article, len(data))
+	for i := range data {
+		articles[i] = &data[i].Article
+	}
+	if err := withTagsAndWriters(repo.db, articles...); err != nil {
+		return nil, models.PageMeta{}, err
+	}
+	return data, meta, nil
+}
 
-	tx.Commit()
+// Create saves an article data written by actor and records it as the first history version
+func (repo ArticleRepository) Create(actor models.Actor, data models.CreateArticleRequest) (models.Article, error) {
+	var article models.Article
+	err := transactionWithHistory(repo.db, func(tx *gorm.DB) error {
+		article = data.Article()
+		article.WriterID = actor.ID
+		slug, err := uniqueSlug(tx, article.Slug, 0)
+		if err != nil {
+			return err
+		}
+		article.Slug = slug
+		if err := tx.Create(&article).Error; err != nil {
+			return err
+		}
+
+		if err := attachTags(tx, article.ID, data.Tags); err != nil {
+			return err
+		}
+		if err := refreshArticleRelations(tx, article.ID); err != nil {
+			return err
+		}
+
+		return createArticleHistory(tx, newArticleHistory(models.ArticleHistoryCreate, actor, ""), &article)
+	})
+	if err != nil {
+		return models.Article{}, err
+	}
 
 	return article, nil
 }
 
-// FindByParam finds an article by a specific param
+// uniqueSlug suffixes base with -2, -3 and so on until no other article than articleID has the slug,
+// currently or as a former slug. A concurrent transaction taking the same slug fails on its unique index
+// and is retried by transactionWithHistory.
+func uniqueSlug(tx *gorm.DB, base string, articleID int64) (string, error) {
+	pattern := queryspec.EscapeLike(base) + "-%"
+	var taken []string
+	result := tx.Raw("SELECT slug FROM articles WHERE (slug = ? OR slug LIKE ?) AND id <> ? "+
+		"UNION SELECT slug FROM article_slug_redirects WHERE (slug = ? OR slug LIKE ?) AND article_id <> ?",
+		base, pattern, articleID, base, pattern, articleID).Scan(&taken)
+	if result.Error != nil {
+		return "", result.Error
+	}
+
+	slugs := make(map[string]bool, len(taken))
+	for _, s := range taken {
+		slugs[s] = true
+	}
+	slug := base
+	for n := 2; slugs[slug]; n++ {
+		slug = fmt.Sprintf("%s-%d", base, n)
+	}
+	return slug, nil
+}
+
+// reslug regenerates the slug of an article whose title changed from oldSlug within tx. The slug is kept
+// while the title still makes it, suffix aside. A published article keeps its former slug as a redirect,
+// so its published URLs never break, the slugs of articles never published are freed.
+func reslug(tx *gorm.DB, data *models.Article, oldSlug string) error {
+	base := models.ArticleSlug(data.Title)
+	if oldSlug == base || isSuffixedSlug(oldSlug, base) {
+		data.Slug = oldSlug
+		return nil
+	}
+
+	slug, err := uniqueSlug(tx, base, data.ID)
+	if err != nil {
+		return err
+	}
+	data.Slug = slug
+
+	if err := tx.Where("article_id = ? AND slug = ?", data.ID, slug).Delete(&models.ArticleSlugRedirect{}).Error; err != nil {
+		return err
+	}
+	var published int64
+	err = tx.Model(&models.ArticleHistory{}).
+		Where("article_id = ? AND status = ?", data.ID, models.ArticleStatusPublished).
+		Count(&published).Error
+	if err != nil || published == 0 {
+		return err
+	}
+	return tx.Create(&models.ArticleSlugRedirect{Slug: oldSlug, ArticleID: data.ID}).Error
+}
+
+// isSuffixedSlug checks whether slug is base with a -2, -3 or further suffix
+func isSuffixedSlug(slug string, base string) bool {
+	suffix, ok := strings.CutPrefix(slug, base+"-")
+	if !ok {
+		return false
+	}
+	n, err := strconv.Atoi(suffix)
+	return err == nil && n >= 2 && strconv.Itoa(n) == suffix
+}
+
+// attachTags links an article to tags by title, creating tags that do not exist yet
+func attachTags(tx *gorm.DB, articleID int64, titles []string) error {
+	tagIDs, err := findOrCreateTags(tx, titles)
+	if err != nil {
+		return err
+	}
+	for _, tagID := range tagIDs {
+		if err := tx.Create(&models.ArticleTag{ArticleID: articleID, TagID: tagID}).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// syncTags makes the tags of an article match titles, creating tags that do not exist yet. Only the links
+// which were added are inserted and only the ones which were removed are deleted, so the links which are
+// kept keep their creation time and are not counted again as tag usage.
+func syncTags(tx *gorm.DB, articleID int64, titles []string) error {
+	tagIDs, err := findOrCreateTags(tx, titles)
+	if err != nil {
+		return err
+	}
+
+	var linked []int64
+	if err := tx.Model(&models.ArticleTag{}).Where("article_id = ?", articleID).Pluck("tag_id", &linked).Error; err != nil {
+		return err
+	}
+	existing := make(map[int64]bool, len(linked))
+	for _, tagID := range linked {
+		existing[tagID] = true
+	}
+
+	keep := make(map[int64]bool, len(tagIDs))
+	for _, tagID := range tagIDs {
+		keep[tagID] = true
+		if existing[tagID] {
+			continue
+		}
+		if err := tx.Create(&models.ArticleTag{ArticleID: articleID, TagID: tagID}).Error; err != nil {
+			return err
+		}
+	}
+
+	var removed []int64
+	for _, tagID := range linked {
+		if !keep[tagID] {
+			removed = append(removed, tagID)
+		}
+	}
+	if len(removed) == 0 {
+		return nil
+	}
+	return tx.Where("article_id = ? AND tag_id IN ?", articleID, removed).Delete(&models.ArticleTag{}).Error
+}
+
+// findOrCreateTags returns the ids of the tags with titles, in order and without duplicates, creating
+// tags that do not exist yet
+func findOrCreateTags(tx *gorm.DB, titles []string) ([]int64, error) {
+	var tagIDs []int64
+	seen := make(map[string]bool)
+	for _, reqTag := range titles {
+		title := models.NormalizeTagTitle(reqTag)
+		if title == "" || seen[title] {
+			continue
+		}
+		seen[title] = true
+
+		// the share lock keeps the tag from being merged or deleted before the link is saved
+		var tag models.Tag
+		result := tx.Clauses(clause.Locking{Strength: "SHARE"}).Where("lower(title) = ?", title).First(&tag)
+		if result.Error != nil {
+			tag = models.Tag{
+				Title: title,
+			}
+			if err := tx.Create(&tag).Error; err != nil {
+				return nil, err
+			}
+		}
+		tagIDs = append(tagIDs, tag.ID)
+	}
+	return tagIDs, nil
+}
+
+// FindByParam finds an article with its tags by a specific param
 func (repo ArticleRepository) FindByParam(param string, value any) (models.Article, error) {
 	var data models.Article
 	result := repo.db.Where(fmt.Sprintf("%s = ?", param), value).First(&data)
-	return data, result.Error
+	if result.Error != nil {
+		return data, result.Error
+	}
+	return data, withTagsAndWriters(repo.db, &data)
 }
 
-// FindByParam finds an article by a specific param
-func (repo ArticleRepository) DeleteByParam(param string, value any) error {
+// FindByParamWithTrashed finds an article with its tags by a specific param, whether it is in the trash or not
+func (repo ArticleRepository) FindByParamWithTrashed(param string, value any) (models.Article, error) {
 	var data models.Article
-	result := repo.db.Where(fmt.Sprintf("%s = ?", param), value).First(&data)
+	result := repo.db.Unscoped().Where(fmt.Sprintf("%s = ?", param), value).First(&data)
 	if result.Error != nil {
-		return result.Error
+		return data, result.Error
 	}
+	return data, withTagsAndWriters(repo.db, &data)
+}
 
-	tx := repo.db.Begin()
-	result = tx.Delete(&data)
-	if result.Error != nil {
-		tx.Rollback()
-		return result.Error
+// ListTrash finds a page of trashed articles with their tags by filter, see articleTrashQuerySpec and
+// whereTags for the accepted params. A writerID other than 0 keeps the articles of that writer only.
+func (repo ArticleRepository) ListTrash(q url.Values, writerID int64) ([]models.Article, models.PageMeta, error) {
+	db := repo.db.Unscoped().Model(&models.Article{}).Where("articles.deleted_at IS NOT NULL")
+	if writerID != 0 {
+		db = db.Where("articles.writer_id = ?", writerID)
 	}
-	result = tx.Where("article_id = ?", data.ID).Delete(&models.ArticleTag{})
-	if result.Error != nil {
-		tx.Rollback()
-		return result.Error
+	return repo.list(articleTrashQuerySpec, db, q)
+}
+
+// Delete moves an article to the trash and records it as a new history version. Its tags, histories
+// and former slugs are kept so it can be restored, its relations are dropped until then. A version other
+// than nil makes it fail with models.ErrArticleVersionMismatch unless the article is at that version, a
+// lock of a user other than actor with models.ErrArticleLocked.
+func (repo ArticleRepository) Delete(id int64, actor models.Actor, version *int64) (models.Article, error) {
+	var data models.Article
+	err := transactionWithHistory(repo.db, func(tx *gorm.DB) error {
+		result := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", id).First(&data)
+		if result.Error != nil {
+			return result.Error
+		}
+		basedOn(&data, version)
+		if err := checkArticleLock(tx, data.ID, actor); err != nil {
+			return err
+		}
+
+		if err := tx.Delete(&data).Error; err != nil {
+			return err
+		}
+		if err := deleteArticleRelations(tx, data.ID); err != nil {
+			return err
+		}
+
+		return createArticleHistory(tx, newArticleHistory(models.ArticleHistoryDelete, actor, ""), &data)
+	})
+
+	return data, err
+}
+
+// Undelete restores an article from the trash, rescoring its relations, and records it as a new history version.
+// It fails with models.ErrArticleLocked while a user other than actor locks the article.
+func (repo ArticleRepository) Undelete(id int64, actor models.Actor) (models.Article, error) {
+	var data models.Article
+	err := transactionWithHistory(repo.db, func(tx *gorm.DB) error {
+		result := tx.Unscoped().Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ? AND deleted_at IS NOT NULL", id).
+			First(&data)
+		if result.Error != nil {
+			return result.Error
+		}
+		if err := checkArticleLock(tx, data.ID, actor); err != nil {
+			return err
+		}
+
+		if err := tx.Unscoped().Model(&data).Update("deleted_at", nil).Error; err != nil {
+			return err
+		}
+		data.DeletedAt = gorm.DeletedAt{}
+		if err := refreshArticleRelations(tx, data.ID); err != nil {
+			return err
+		}
+
+		return createArticleHistory(tx, newArticleHistory(models.ArticleHistoryUndelete, actor, ""), &data)
+	})
+
+	return data, err
+}
+
+// Purge permanently deletes an article, in the trash or not, with its tags, histories, former slugs and
+// relations. A version other than nil makes it fail with models.ErrArticleVersionMismatch unless the
+// article is at that version. It fails with models.ErrArticleLocked while a user other than actor locks it.
+func (repo ArticleRepository) Purge(id int64, actor models.Actor, version *int64) error {
+	return repo.db.Transaction(func(tx *gorm.DB) error {
+		var data models.Article
+		result := tx.Unscoped().Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", id).First(&data)
+		if result.Error != nil {
+			return result.Error
+		}
+		if err := checkArticleLock(tx, data.ID, actor); err != nil {
+			return err
+		}
+
+		return purgeArticle(tx, id, version)
+	})
+}
+
+// basedOn makes the change of an article apply only while it is at version, nil for any version.
+// The version is checked by the UPDATE of createArticleHistory.
+func basedOn(data *models.Article, version *int64) {
+	if version != nil {
+		data.Version = *version
+	}
+}
+
+// PurgeDeleted permanently deletes up to limit articles moved to the trash before the given time.
+// Rows are locked with SKIP LOCKED so replicas purging at the same time never process the same article.
+// Cancelling ctx aborts the batch.
+func (repo ArticleRepository) PurgeDeleted(ctx context.Context, before time.Time, limit int) ([]models.Article, error) {
+	var data []models.Article
+	err := repo.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		data = nil
+		result := tx.Unscoped().Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("deleted_at <= ?", before).
+			Order("deleted_at").
+			Limit(limit).
+			Find(&data)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		for _, article := range data {
+			if err := purgeArticle(tx, article.ID, nil); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
-	result = tx.Where("article_id = ?", data.ID).Delete(&models.ArticleHistory{})
+
+	return data, nil
+}
+
+// purgeArticle permanently deletes an article with its tags, histories, former slugs, lock and relations
+// within tx. With a version other than nil the article is only deleted while it is at that version.
+func purgeArticle(tx *gorm.DB, id int64, version *int64) error {
+	db := tx.Unscoped().Where("id = ?", id)
+	if version != nil {
+		db = db.Where("version = ?", *version)
+	}
+	result := db.Delete(&models.Article{})
 	if result.Error != nil {
-		tx.Rollback()
 		return result.Error
 	}
+	if result.RowsAffected == 0 && version != nil {
+		return models.ErrArticleVersionMismatch
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	for _, model := range []any{&models.ArticleTag{}, &models.ArticleHistory{}, &models.ArticleSlugRedirect{}, &models.ArticleLock{}} {
+		if err := tx.Where("article_id = ?", id).Delete(model).Error; err != nil {
+			return err
+		}
+	}
+	return deleteArticleRelations(tx, id)
+}
 
-	tx.Commit()
+// Update applies a patch to an article, replacing its tags and rescoring its relations when the patch
+// carries tags, and records the result as a new history version in the same transaction. A version
+// other than nil makes it fail with models.ErrArticleVersionMismatch unless the article is at that version, a
+// lock of a user other than actor with models.ErrArticleLocked.
+func (repo ArticleRepository) Update(id int64, actor models.Actor, action string, patch models.PatchArticleRequest, version *int64) (models.Article, error) {
+	var data models.Article
+	err := transactionWithHistory(repo.db, func(tx *gorm.DB) error {
+		result := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", id).First(&data)
+		if result.Error != nil {
+			return result.Error
+		}
+		basedOn(&data, version)
+		if err := checkArticleLock(tx, data.ID, actor); err != nil {
+			return err
+		}
 
-	return nil
+		oldTitle, oldSlug := data.Title, data.Slug
+		patch.Apply(&data)
+		if data.Title != oldTitle {
+			if err := reslug(tx, &data, oldSlug); err != nil {
+				return err
+			}
+		}
+		if err := tx.Save(&data).Error; err != nil {
+			return err
+		}
+
+		if patch.Tags != nil {
+			if err := syncTags(tx, data.ID, *patch.Tags); err != nil {
+				return err
+			}
+			if err := refreshArticleRelations(tx, data.ID); err != nil {
+				return err
+			}
+		}
+
+		return createArticleHistory(tx, newArticleHistory(action, actor, ""), &data)
+	})
+
+	return data, err
 }
 
-// PatchByParam patches an article by a specific param
-func (repo ArticleRepository) PatchByParam(id int64, param string, value any) (models.Article, error) {
+// Transition moves an article to the target status of transition and records it, with the actor
+// and comment, as a new history version. The current status, and that no user other than actor locks
+// the article, are checked while the row is locked.
+func (repo ArticleRepository) Transition(id int64, actor models.Actor, transition models.ArticleTransition, comment string) (models.Article, error) {
 	var data models.Article
-	result := repo.db.Where("id = ?", id).First(&data)
-	if result.Error != nil {
-		return models.Article{}, result.Error
-	}
+	err := transactionWithHistory(repo.db, func(tx *gorm.DB) error {
+		result := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", id).First(&data)
+		if result.Error != nil {
+			return result.Error
+		}
+		if err := checkArticleLock(tx, data.ID, actor); err != nil {
+			return err
+		}
+		if !transition.AllowedFrom(data.Status) {
+			return models.ErrArticleTransitionNotAllowed
+		}
 
-	switch param {
-	case "status":
-		data.Status = fmt.Sprintf("%s", value)
-	default:
-		return models.Article{}, errors.New("empty param")
-	}
+		transition.Apply(&data)
+		if err := tx.Save(&data).Error; err != nil {
+			return err
+		}
+
+		return createArticleHistory(tx, newArticleHistory(transition.Name, actor, comment), &data)
+	})
+
+	return data, err
+}
+
+// Restore copies a history snapshot back into an article, replacing its tags when the snapshot
+// carries tags, and records it as a new history version. It fails with models.ErrArticleLocked while a
+// user other than actor locks the article.
+func (repo ArticleRepository) Restore(id int64, actor models.Actor, version int64, snapshot models.Article) (models.Article, error) {
+	var data models.Article
+	err := transactionWithHistory(repo.db, func(tx *gorm.DB) error {
+		result := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", id).First(&data)
+		if result.Error != nil {
+			return result.Error
+		}
+		if err := checkArticleLock(tx, data.ID, actor); err != nil {
+			return err
+		}
+
+		oldTitle, oldSlug := data.Title, data.Slug
+		data.RestoreSnapshot(snapshot)
+		if data.Title != oldTitle {
+			if err := reslug(tx, &data, oldSlug); err != nil {
+				return err
+			}
+		}
+		if err := tx.Save(&data).Error; err != nil {
+			return err
+		}
+
+		if snapshot.Tags != nil {
+			if err := syncTags(tx, data.ID, snapshot.Tags); err != nil {
+				return err
+			}
+			if err := refreshArticleRelations(tx, data.ID); err != nil {
+				return err
+			}
+		}
+
+		return createArticleHistory(tx, newArticleHistory(models.ArticleHistoryRestore, actor, fmt.Sprintf("restored from version %d", version)), &data)
+	})
+
+	return data, err
+}
+
+// Schedule sets the publish and unpublish times of an article and records it as a new history version.
+// It fails with models.ErrArticleLocked while a user other than actor locks the article.
+func (repo ArticleRepository) Schedule(id int64, actor models.Actor, schedule models.ScheduleArticleRequest) (models.Article, error) {
+	var data models.Article
+	err := transactionWithHistory(repo.db, func(tx *gorm.DB) error {
+		result := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", id).First(&data)
+		if result.Error != nil {
+			return result.Error
+		}
+		if err := checkArticleLock(tx, data.ID, actor); err != nil {
+			return err
+		}
+
+		schedule.Apply(&data)
+		if err := tx.Save(&data).Error; err != nil {
+			return err
+		}
+
+		return createArticleHistory(tx, newArticleHistory(models.ArticleHistorySchedule, actor, ""), &data)
+	})
+
+	return data, err
+}
+
+// PublishDue publishes up to limit APPROVED articles whose publish_at has passed
+func (repo ArticleRepository) PublishDue(ctx context.Context, now time.Time, limit int) ([]models.Article, error) {
+	transition, _ := models.FindArticleTransition(models.ArticleTransitionPublish)
+	return repo.transitionDue(ctx, "publish_at", transition, models.ArticleHistoryScheduledPublish, now, limit)
+}
+
+// UnpublishDue archives up to limit PUBLISHED articles whose unpublish_at has passed
+func (repo ArticleRepository) UnpublishDue(ctx context.Context, now time.Time, limit int) ([]models.Article, error) {
+	transition, _ := models.FindArticleTransition(models.ArticleTransitionArchive)
+	return repo.transitionDue(ctx, "unpublish_at", transition, models.ArticleHistoryScheduledUnpublish, now, limit)
+}
 
-	result = repo.db.Save(&data)
+// transitionDue applies transition to articles whose column time has passed. Rows are locked with
+// SKIP LOCKED so replicas running the scheduler at the same time never process the same article,
+// and the status condition makes processing an article twice a no-op. Cancelling ctx aborts the batch.
+func (repo ArticleRepository) transitionDue(ctx context.Context, column string, transition models.ArticleTransition, action string, now time.Time, limit int) ([]models.Article, error) {
+	var data []models.Article
+	err := transactionWithHistory(repo.db.WithContext(ctx), func(tx *gorm.DB) error {
+		data = nil
+		result := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status IN ?", transition.From).
+			Where(fmt.Sprintf("%s <= ?", column), now).
+			Order(column).
+			Limit(limit).
+			Find(&data)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		for i := range data {
+			transition.Apply(&data[i])
+			if err := tx.Save(&data[i]).Error; err != nil {
+				return err
+			}
+			if err := createArticleHistory(tx, newArticleHistory(action, models.Actor{}, ""), &data[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
 
-	return data, result.Error
+	return data, nil
 }
@@ -27,3 +27,30 @@ func (repo AuthRepository) FindByUsername(username string) (models.Auth, error)
 	result := repo.db.Where("username = ?", username).First(&auth)
 	return auth, result.Error
 }
+
+// FindByID finds an auth by id
+func (repo AuthRepository) FindByID(id int64) (models.Auth, error) {
+	var auth models.Auth
+	result := repo.db.Where("id = ?", id).First(&auth)
+	return auth, result.Error
+}
+
+// FindByUUID finds an auth by uuid
+func (repo AuthRepository) FindByUUID(uuid string) (models.Auth, error) {
+	var auth models.Auth
+	result := repo.db.Where("uuid = ?", uuid).First(&auth)
+	return auth, result.Error
+}
+
+// UpdateRole updates role of an auth found by uuid
+func (repo AuthRepository) UpdateRole(uuid string, roleName string) (models.Auth, error) {
+	var auth models.Auth
+	result := repo.db.Where("uuid = ?", uuid).First(&auth)
+	if result.Error != nil {
+		return models.Auth{}, result.Error
+	}
+
+	auth.RoleName = roleName
+	result = repo.db.Save(&auth)
+	return auth, result.Error
+}
@@ -0,0 +1,151 @@
+package respositories
+
+import (
+	"github.com/herdiansc/go-cms/models"
+	"github.com/herdiansc/go-cms/queryspec"
+)
+
+// articleFields lists the params articles can be filtered and sorted by
+var articleFields = map[string]queryspec.Field{
+	"uuid":         {Column: "articles.uuid", Kind: queryspec.UUID, Filterable: true},
+	"title":        {Column: "articles.title", Kind: queryspec.String, Filterable: true, Sortable: true},
+	"status":       {Column: "articles.status", Kind: queryspec.String, Filterable: true, Sortable: true},
+	"slug":         {Column: "articles.slug", Kind: queryspec.String, Filterable: true},
+	"writer":       {Column: "(SELECT auths.uuid FROM auths WHERE auths.id = articles.writer_id)", Kind: queryspec.UUID, Filterable: true},
+	"writer_id":    {Column: "(SELECT auths.uuid FROM auths WHERE auths.id = articles.writer_id)", Kind: queryspec.UUID, Filterable: true},
+	"created_at":   {Column: "articles.created_at", Kind: queryspec.Time, Filterable: true, Sortable: true},
+	"created_from": {Column: "articles.created_at", Kind: queryspec.Time, Filterable: true, Operator: queryspec.OpGte},
+	"created_to":   {Column: "articles.created_at", Kind: queryspec.Time, Filterable: true, Operator: queryspec.OpLt},
+	"updated_at":   {Column: "articles.updated_at", Kind: queryspec.Time, Filterable: true, Sortable: true},
+	"publish_at":   {Column: "articles.publish_at", Kind: queryspec.Time, Filterable: true, Sortable: true, Nullable: true},
+	"unpublish_at": {Column: "articles.unpublish_at", Kind: queryspec.Time, Filterable: true, Sortable: true, Nullable: true},
+}
+
+var articleQuerySpec = queryspec.Spec{
+	Fields:       articleFields,
+	Key:          "uuid",
+	DefaultSort:  "created_at",
+	DefaultDir:   "desc",
+	DefaultLimit: 10,
+	MaxLimit:     100,
+}
+
+// publicArticleQuerySpec leaves out the fields of articles that are not exposed publicly, slug is the
+// cursor tie-breaker only so public cursors carry nothing but the sort value and a slug
+var publicArticleQuerySpec = queryspec.Spec{
+	Fields: map[string]queryspec.Field{
+		"slug":         {Column: "articles.slug", Kind: queryspec.String},
+		"title":        articleFields["title"],
+		"created_at":   articleFields["created_at"],
+		"created_from": articleFields["created_from"],
+		"created_to":   articleFields["created_to"],
+		"updated_at":   articleFields["updated_at"],
+	},
+	Key:          "slug",
+	DefaultSort:  "created_at",
+	DefaultDir:   "desc",
+	DefaultLimit: 10,
+	MaxLimit:     100,
+}
+
+// articleTrashQuerySpec adds the time articles were moved to the trash to the fields of articles
+var articleTrashQuerySpec = queryspec.Spec{
+	Fields:       withField(articleFields, "deleted_at", queryspec.Field{Column: "articles.deleted_at", Kind: queryspec.Time, Filterable: true, Sortable: true}),
+	Key:          "uuid",
+	DefaultSort:  "deleted_at",
+	DefaultDir:   "desc",
+	DefaultLimit: 10,
+	MaxLimit:     100,
+}
+
+var articleSearchQuerySpec = queryspec.Spec{
+	Fields:       withField(articleFields, "rank", queryspec.Field{Column: "rank", Sortable: true}),
+	DefaultSort:  "rank",
+	DefaultDir:   "desc",
+	DefaultLimit: 10,
+	MaxLimit:     100,
+}
+
+var articleHistoryQuerySpec = queryspec.Spec{
+	Fields: map[string]queryspec.Field{
+		"uuid":       {Column: "uuid", Kind: queryspec.UUID, Filterable: true},
+		"version":    {Column: "version", Kind: queryspec.Int, Filterable: true, Sortable: true},
+		"status":     {Column: "status", Kind: queryspec.String, Filterable: true},
+		"action":     {Column: "action", Kind: queryspec.String, Filterable: true},
+		"actor":      {Column: "(SELECT auths.uuid FROM auths WHERE auths.id = article_histories.actor_id)", Kind: queryspec.UUID, Filterable: true},
+		"request_id": {Column: "request_id", Kind: queryspec.String, Filterable: true},
+		"created_at": {Column: "created_at", Kind: queryspec.Time, Filterable: true, Sortable: true},
+	},
+	Key:          "uuid",
+	DefaultSort:  "version",
+	DefaultDir:   "desc",
+	DefaultLimit: 10,
+	MaxLimit:     100,
+}
+
+var tagQuerySpec = queryspec.Spec{
+	Fields: map[string]queryspec.Field{
+		"uuid":        {Column: "tags.uuid", Kind: queryspec.UUID, Filterable: true},
+		"title":       {Column: "tags.title", Kind: queryspec.String, Filterable: true, Sortable: true},
+		"parent":      {Column: "(SELECT parent.uuid FROM tags AS parent WHERE parent.id = tags.parent_id)", Kind: queryspec.UUID, Filterable: true},
+		"created_at":  {Column: "tags.created_at", Kind: queryspec.Time, Filterable: true, Sortable: true},
+		"usage_count": {Column: "usage_count", Sortable: true},
+	},
+	DefaultSort:  "created_at",
+	DefaultDir:   "desc",
+	DefaultLimit: 10,
+	MaxLimit:     100,
+}
+
+var publicTagQuerySpec = queryspec.Spec{
+	Fields: map[string]queryspec.Field{
+		"title":       {Column: "tags.title", Kind: queryspec.String, Filterable: true, Sortable: true},
+		"usage_count": {Column: "usage_count", Sortable: true},
+	},
+	DefaultSort:  "usage_count",
+	DefaultDir:   "desc",
+	DefaultLimit: 10,
+	MaxLimit:     100,
+}
+
+// articleValue reads a sortable field of an article for its cursor
+func articleValue(a models.Article, field string) any {
+	switch field {
+	case "title":
+		return a.Title
+	case "status":
+		return a.Status
+	case "slug":
+		return a.Slug
+	case "created_at":
+		return a.CreatedAt
+	case "updated_at":
+		return a.UpdatedAt
+	case "deleted_at":
+		return a.DeletedAt.Time
+	default:
+		return a.UUID
+	}
+}
+
+// articleHistoryValue reads a sortable field of an article history for its cursor
+func articleHistoryValue(h models.ArticleHistory, field string) any {
+	switch field {
+	case "version":
+		return h.Version
+	case "created_at":
+		return h.CreatedAt
+	default:
+		return h.UUID
+	}
+}
+
+// withField copies fields adding one more
+func withField(fields map[string]queryspec.Field, name string, field queryspec.Field) map[string]queryspec.Field {
+	copied := make(map[string]queryspec.Field, len(fields)+1)
+	for k, v := range fields {
+		copied[k] = v
+	}
+	copied[name] = field
+	return copied
+}
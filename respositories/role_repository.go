@@ -0,0 +1,33 @@
+package respositories
+
+import (
+	"github.com/herdiansc/go-cms/models"
+	"gorm.io/gorm"
+)
+
+// RoleRepository struct
+type RoleRepository struct {
+	db *gorm.DB
+}
+
+// NewRoleRepository inits RoleRepository
+func NewRoleRepository(db *gorm.DB) RoleRepository {
+	return RoleRepository{db: db}
+}
+
+// FindByName finds a role with its permissions by name
+func (repo RoleRepository) FindByName(name string) (models.Role, error) {
+	var data models.Role
+	result := repo.db.Preload("Permissions").Where("name = ?", name).First(&data)
+	return data, result.Error
+}
+
+// HasPermission checks whether a role is granted a permission
+func (repo RoleRepository) HasPermission(roleName, permission string) (bool, error) {
+	var count int64
+	result := repo.db.Model(&models.RolePermission{}).
+		Joins("join roles r on r.id = role_permissions.role_id").
+		Where("r.name = ? AND role_permissions.permission = ?", roleName, permission).
+		Count(&count)
+	return count > 0, result.Error
+}
@@ -1,11 +1,17 @@
 package respositories
 
 import (
+	"errors"
 	"fmt"
+	"net/url"
+	"slices"
 	"strconv"
+	"strings"
 
 	"github.com/herdiansc/go-cms/models"
+	"github.com/herdiansc/go-cms/queryspec"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // TagRepository struct
@@ -18,54 +24,237 @@ func NewTagRepository(db *gorm.DB) TagRepository {
 	return TagRepository{db: db}
 }
 
-// Create saves a tag data
+// Create saves a tag data, returning ErrTagParentNotFound when its parent does not exist
 func (repo TagRepository) Create(data models.Tag) (models.Tag, error) {
-	result := repo.db.Create(&data)
-	return data, result.Error
+	err := repo.db.Transaction(func(tx *gorm.DB) error {
+		if data.ParentID != nil {
+			var parent models.Tag
+			result := tx.Clauses(clause.Locking{Strength: "SHARE"}).Where("id = ?", *data.ParentID).First(&parent)
+			if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+				return models.ErrTagParentNotFound
+			}
+			if result.Error != nil {
+				return result.Error
+			}
+			data.ParentUUID = &parent.UUID
+		}
+		return tx.Create(&data).Error
+	})
+	return data, err
 }
 
-// List finds list of all tags by filter
-func (repo TagRepository) List(params map[string]interface{}) ([]models.TagListItem, error) {
-	limit := 10
-	if _, ok := params["limit"]; ok {
-		limitStr, _ := params["limit"].(string)
-		limit, _ = strconv.Atoi(limitStr)
-		delete(params, "limit")
-	}
-	page := 1
-	if _, ok := params["page"]; ok {
-		pageStr, _ := params["page"].(string)
-		page, _ = strconv.Atoi(pageStr)
-		delete(params, "page")
+// withParentUUID sets the uuid of the parent of a tag
+func withParentUUID(db *gorm.DB, data *models.Tag) error {
+	data.ParentUUID = nil
+	if data.ParentID == nil {
+		return nil
 	}
-	orderField := "id"
-	if _, ok := params["orderField"]; ok {
-		orderField, _ = params["orderField"].(string)
-		delete(params, "orderField")
+	var parent models.Tag
+	if err := db.Select("uuid").Where("id = ?", *data.ParentID).First(&parent).Error; err != nil {
+		return err
 	}
-	orderDir := "desc"
-	if _, ok := params["orderDir"]; ok {
-		orderDir, _ = params["orderDir"].(string)
-		delete(params, "orderDir")
+	data.ParentUUID = &parent.UUID
+	return nil
+}
+
+// List finds a page of tags by filter, see tagQuerySpec for the accepted params
+func (repo TagRepository) List(q url.Values) ([]models.TagListItem, models.PageMeta, error) {
+	query, err := tagQuerySpec.Parse(q)
+	if err != nil {
+		return []models.TagListItem{}, models.PageMeta{}, err
 	}
 
 	var data []models.TagListItem
-	result := repo.db.Debug().
-		Model(&models.Tag{}).
-		Select("tags.id, tags.title, count(at.*) as usage_count").
+	result := query.Apply(withUsageCount(repo.db.Debug())).Find(&data)
+
+	if result.Error != nil {
+		return []models.TagListItem{}, models.PageMeta{}, result.Error
+	}
+
+	return queryspec.Page(query, data, query.Where(repo.db.Model(&models.Tag{})), nil)
+}
+
+// ListPublished finds a page of the tags of published articles with the number of them by filter, see
+// publicTagQuerySpec for the accepted params
+func (repo TagRepository) ListPublished(q url.Values) ([]models.PublicTag, models.PageMeta, error) {
+	query, err := publicTagQuerySpec.Parse(q)
+	if err != nil {
+		return []models.PublicTag{}, models.PageMeta{}, err
+	}
+
+	var data []models.PublicTag
+	result := query.Apply(repo.db.Model(&models.Tag{}).
+		Select("tags.title, count(*) as usage_count").
+		Joins("JOIN article_tags at ON at.tag_id = tags.id").
+		Joins("JOIN articles ON articles.id = at.article_id AND articles.status = ? AND articles.deleted_at IS NULL", models.ArticleStatusPublished).
+		Group("tags.id, tags.title")).Find(&data)
+	if result.Error != nil {
+		return []models.PublicTag{}, models.PageMeta{}, result.Error
+	}
+
+	count := query.Where(repo.db.Model(&models.Tag{}).
+		Where("EXISTS (?)", published(repo.db.Table("article_tags at").
+			Select("1").
+			Joins("JOIN articles ON articles.id = at.article_id").
+			Where("at.tag_id = tags.id"))))
+	return queryspec.Page(query, data, count, nil)
+}
+
+// withUsageCount selects tags with the uuid of their parent and the number of articles using them as usage_count
+func withUsageCount(db *gorm.DB) *gorm.DB {
+	return db.Model(&models.Tag{}).
+		Select("tags.id, tags.uuid, tags.title, tags.parent_id, parent.uuid as parent_uuid, count(at.*) as usage_count").
+		Joins("left join tags parent on parent.id = tags.parent_id").
 		Joins("left join article_tags at on at.tag_id = tags.id").
-		Where(params).
-		Group("tags.id, tags.title").
-		Order(fmt.Sprintf("%s %s", orderField, orderDir)).
+		Group("tags.id, tags.uuid, tags.title, tags.parent_id, parent.uuid")
+}
+
+// All finds every tag with its parent and usage count, sorted by title
+func (repo TagRepository) All() ([]models.TagListItem, error) {
+	data := []models.TagListItem{}
+	result := withUsageCount(repo.db).Order("tags.title").Find(&data)
+	return data, result.Error
+}
+
+// tagTreeLockKey is the transaction-level advisory lock serializing moves of tags, so that two
+// concurrent moves cannot each pass the cycle check and together make a cycle
+const tagTreeLockKey = 7019
+
+// tagDescendantsQuery selects the ids of the tags with a title in ? and of all their descendants
+const tagDescendantsQuery = "WITH RECURSIVE descendants AS (" +
+	"SELECT id FROM tags WHERE title IN ? " +
+	"UNION SELECT tags.id FROM tags JOIN descendants ON tags.parent_id = descendants.id" +
+	") SELECT id FROM descendants"
+
+// Move changes the parent of a tag, a nil parent makes it a root. Returns ErrTagParentNotFound when
+// the parent does not exist and ErrTagCycle when the parent is the tag or one of its descendants.
+func (repo TagRepository) Move(id int64, parentID *int64) (models.Tag, error) {
+	var data models.Tag
+	err := repo.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(?)", tagTreeLockKey).Error; err != nil {
+			return err
+		}
+		result := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", id).First(&data)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if parentID != nil {
+			var parent models.Tag
+			result := tx.Clauses(clause.Locking{Strength: "SHARE"}).Where("id = ?", *parentID).First(&parent)
+			if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+				return models.ErrTagParentNotFound
+			}
+			if result.Error != nil {
+				return result.Error
+			}
+
+			var cycles int64
+			descendants := tx.Raw(tagDescendantsQuery, []string{data.Title})
+			if err := tx.Model(&models.Tag{}).Where("id = ? AND id IN (?)", *parentID, descendants).Count(&cycles).Error; err != nil {
+				return err
+			}
+			if cycles > 0 {
+				return models.ErrTagCycle
+			}
+			data.ParentUUID = &parent.UUID
+		} else {
+			data.ParentUUID = nil
+		}
+
+		data.ParentID = parentID
+		return tx.Model(&data).Update("parent_id", parentID).Error
+	})
+
+	return data, err
+}
+
+// Thresholds of similar tags
+const (
+	// tagWarningSimilarity is the trigram similarity from which a new tag warns about an existing one
+	tagWarningSimilarity = 0.5
+	// tagWarningLimit is the number of similar tags a warning names
+	tagWarningLimit = 3
+)
+
+// Suggest finds tags starting with q or similar to it by trigrams, most used first
+func (repo TagRepository) Suggest(q string, limit int) ([]models.TagSuggestion, error) {
+	data := []models.TagSuggestion{}
+	result := withUsageCount(repo.db).
+		Select("tags.uuid, tags.title, count(at.*) as usage_count, similarity(tags.title, ?) as similarity", q).
+		Where("tags.title LIKE ? OR tags.title % ?", queryspec.EscapeLike(q)+"%", q).
+		Order("usage_count DESC, similarity DESC, tags.title").
 		Limit(limit).
-		Offset(limit * (page - 1)).
-		Find(&data)
+		Scan(&data)
+	return data, result.Error
+}
+
+// Similar finds, for each title that is not a tag yet, existing tags that look like it: sharing most
+// trigrams, or a typo or two away (one for titles shorter than 6 characters). All titles are looked up
+// in a single query.
+func (repo TagRepository) Similar(titles []string) ([]models.TagWarning, error) {
+	var values []string
+	var vars []any
+	seen := make(map[string]bool)
+	for _, reqTitle := range titles {
+		title := models.NormalizeTagTitle(reqTitle)
+		if title == "" || seen[title] {
+			continue
+		}
+		seen[title] = true
+
+		maxDistance := 2
+		if len([]rune(title)) < 6 {
+			maxDistance = 1
+		}
+		values = append(values, "(CAST(? AS integer), CAST(? AS text), CAST(? AS integer))")
+		vars = append(vars, len(values), title, maxDistance)
+	}
+	if len(values) == 0 {
+		return nil, nil
+	}
 
+	var rows []struct {
+		Tag          string
+		SimilarTitle string
+	}
+	vars = append(vars, tagWarningSimilarity, tagWarningLimit)
+	result := repo.db.Raw(fmt.Sprintf(`SELECT submitted.title AS tag, candidate.title AS similar_title
+		FROM (VALUES %s) AS submitted(position, title, max_distance)
+		CROSS JOIN LATERAL (
+			SELECT tags.title FROM tags
+			WHERE similarity(tags.title, submitted.title) >= ? OR levenshtein(tags.title, submitted.title) <= submitted.max_distance
+			ORDER BY levenshtein(tags.title, submitted.title), similarity(tags.title, submitted.title) DESC, tags.title
+			LIMIT ?
+		) AS candidate
+		WHERE NOT EXISTS (SELECT 1 FROM tags WHERE tags.title = submitted.title)
+		ORDER BY submitted.position, levenshtein(candidate.title, submitted.title), similarity(candidate.title, submitted.title) DESC, candidate.title`,
+		strings.Join(values, ", ")), vars...).Scan(&rows)
 	if result.Error != nil {
-		return []models.TagListItem{}, result.Error
+		return nil, result.Error
 	}
 
-	return data, result.Error
+	var warnings []models.TagWarning
+	for _, row := range rows {
+		if n := len(warnings); n > 0 && warnings[n-1].Tag == row.Tag {
+			warnings[n-1].Similar = append(warnings[n-1].Similar, row.SimilarTitle)
+			continue
+		}
+		warnings = append(warnings, models.TagWarning{Tag: row.Tag, Similar: []string{row.SimilarTitle}})
+	}
+	for i, warning := range warnings {
+		warnings[i].Message = fmt.Sprintf("tag %q is new, did you mean %s?", warning.Tag, strings.Join(quoteAll(warning.Similar), " or "))
+	}
+	return warnings, nil
+}
+
+// quoteAll quotes each string
+func quoteAll(values []string) []string {
+	quoted := make([]string, len(values))
+	for i, v := range values {
+		quoted[i] = strconv.Quote(v)
+	}
+	return quoted
 }
 
 // FindByParam finds a tag by a specific param
@@ -76,6 +265,10 @@ func (repo TagRepository) FindByParam(param string, value any) (models.TagDetail
 		return models.TagDetail{}, result.Error
 	}
 
+	if err := withParentUUID(repo.db, &data); err != nil {
+		return models.TagDetail{}, err
+	}
+
 	var usageCount int64
 	result = repo.db.Debug().
 		Model(&models.ArticleTag{}).
@@ -88,3 +281,193 @@ func (repo TagRepository) FindByParam(param string, value any) (models.TagDetail
 		UsageCount: usageCount,
 	}, result.Error
 }
+
+// Rename changes the title of a tag, returning ErrTagTitleTaken when another tag has the title
+func (repo TagRepository) Rename(id int64, title string) (models.Tag, error) {
+	var data models.Tag
+	err := repo.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", id).First(&data)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		var taken int64
+		if err := tx.Model(&models.Tag{}).Where("lower(title) = ? AND id <> ?", title, id).Count(&taken).Error; err != nil {
+			return err
+		}
+		if taken > 0 {
+			return models.ErrTagTitleTaken
+		}
+
+		data.Title = title
+		err := tx.Save(&data).Error
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return models.ErrTagTitleTaken
+		}
+		if err != nil {
+			return err
+		}
+		return withParentUUID(tx, &data)
+	})
+
+	return data, err
+}
+
+// Merge moves the articles of a tag to target and deletes the tag. Articles already tagged with target
+// keep a single link to it. Each article of the tag gets a new history version recorded for actor in the
+// same transaction. Returns the target tag, or models.ErrTagMergeConflict when either tag is gone.
+func (repo TagRepository) Merge(id int64, targetID int64, actor models.Actor) (models.Tag, error) {
+	var target models.Tag
+	err := transactionWithHistory(repo.db, func(tx *gorm.DB) error {
+		var tag models.Tag
+		articles, err := lockTagArticles(tx, id, func() error {
+			var tags []models.Tag
+			result := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id IN ?", []int64{id, targetID}).Order("id").Find(&tags)
+			if result.Error != nil {
+				return result.Error
+			}
+			if len(tags) != 2 {
+				return models.ErrTagMergeConflict
+			}
+			for _, t := range tags {
+				if t.ID == targetID {
+					target = t
+				} else {
+					tag = t
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		tagged := tx.Table("article_tags AS target").Select("target.article_id").Where("target.tag_id = ?", targetID)
+		result := tx.Model(&models.ArticleTag{}).
+			Where("tag_id = ? AND article_id NOT IN (?)", id, tagged).
+			Update("tag_id", targetID)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if err := deleteTag(tx, id, articles); err != nil {
+			return err
+		}
+		comment := fmt.Sprintf("tag %q merged into %q", tag.Title, target.Title)
+		if err := createTagChangeHistories(tx, articles, models.ArticleHistoryTagMerge, actor, comment); err != nil {
+			return err
+		}
+
+		// the target moves up when it was a subcategory of the merged tag
+		if err := tx.Where("id = ?", targetID).First(&target).Error; err != nil {
+			return err
+		}
+		return withParentUUID(tx, &target)
+	})
+
+	return target, err
+}
+
+// Delete deletes a tag. While articles use it, it returns ErrTagInUse unless force is set, which
+// removes the tag from them and records a new history version of each for actor.
+func (repo TagRepository) Delete(id int64, force bool, actor models.Actor) error {
+	return transactionWithHistory(repo.db, func(tx *gorm.DB) error {
+		var data models.Tag
+		articles, err := lockTagArticles(tx, id, func() error {
+			return tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", id).First(&data).Error
+		})
+		if err != nil {
+			return err
+		}
+		if len(articles) > 0 && !force {
+			return models.ErrTagInUse
+		}
+
+		if err := deleteTag(tx, id, articles); err != nil {
+			return err
+		}
+		return createTagChangeHistories(tx, articles, models.ArticleHistoryTagDelete, actor, fmt.Sprintf("tag %q deleted", data.Title))
+	})
+}
+
+// lockTagArticles locks the articles of a tag within tx, then the tags with lockTags. Articles are locked
+// first, in the order article changes take their locks, and the articles tagged while lockTags waited are
+// locked after it. It returns the articles of the tag once it is locked.
+func lockTagArticles(tx *gorm.DB, tagID int64, lockTags func() error) ([]models.Article, error) {
+	var articleIDs []int64
+	if err := tx.Model(&models.ArticleTag{}).Where("tag_id = ?", tagID).Pluck("article_id", &articleIDs).Error; err != nil {
+		return nil, err
+	}
+	locked, err := lockArticles(tx, articleIDs)
+	if err != nil {
+		return nil, err
+	}
+	if err := lockTags(); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Model(&models.ArticleTag{}).Where("tag_id = ?", tagID).Pluck("article_id", &articleIDs).Error; err != nil {
+		return nil, err
+	}
+	var articles []models.Article
+	var untracked []int64
+	for _, articleID := range articleIDs {
+		i := slices.IndexFunc(locked, func(a models.Article) bool { return a.ID == articleID })
+		if i < 0 {
+			untracked = append(untracked, articleID)
+			continue
+		}
+		articles = append(articles, locked[i])
+	}
+	more, err := lockArticles(tx, untracked)
+	if err != nil {
+		return nil, err
+	}
+	return append(articles, more...), nil
+}
+
+// lockArticles locks articles by id within tx, including those in the trash, in id order
+func lockArticles(tx *gorm.DB, ids []int64) ([]models.Article, error) {
+	var articles []models.Article
+	if len(ids) == 0 {
+		return articles, nil
+	}
+	result := tx.Unscoped().Clauses(clause.Locking{Strength: "UPDATE"}).Where("id IN ?", ids).Order("id").Find(&articles)
+	return articles, result.Error
+}
+
+// createTagChangeHistories records a new history version of each article whose tags changed with a tag
+// within tx
+func createTagChangeHistories(tx *gorm.DB, articles []models.Article, action string, actor models.Actor, comment string) error {
+	for i := range articles {
+		if err := createArticleHistory(tx, newArticleHistory(action, actor, comment), &articles[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deleteTag deletes a tag with its remaining article links and trending scores within tx, and rescores
+// the relations of the articles that were tagged with it at once. Its subcategories move up to its parent.
+func deleteTag(tx *gorm.DB, id int64, articles []models.Article) error {
+	result := tx.Model(&models.Tag{}).
+		Where("parent_id = ?", id).
+		Update("parent_id", gorm.Expr("(SELECT parent.parent_id FROM tags AS parent WHERE parent.id = ?)", id))
+	if result.Error != nil {
+		return result.Error
+	}
+	if err := tx.Where("tag_id = ?", id).Delete(&models.ArticleTag{}).Error; err != nil {
+		return err
+	}
+	if err := tx.Where("tag_id = ?", id).Delete(&models.TagTrendingScore{}).Error; err != nil {
+		return err
+	}
+	if err := tx.Where("id = ?", id).Delete(&models.Tag{}).Error; err != nil {
+		return err
+	}
+	articleIDs := make([]int64, len(articles))
+	for i, article := range articles {
+		articleIDs[i] = article.ID
+	}
+	return refreshArticleRelations(tx, articleIDs...)
+}
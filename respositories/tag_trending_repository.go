@@ -0,0 +1,75 @@
+package respositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/herdiansc/go-cms/models"
+	"gorm.io/gorm"
+)
+
+// Weights of the events making a tag trend
+const (
+	trendingUsageWeight   = 1.0
+	trendingPublishWeight = 3.0
+)
+
+// TagTrendingRepository struct
+type TagTrendingRepository struct {
+	db *gorm.DB
+}
+
+// NewTagTrendingRepository inits TagTrendingRepository
+func NewTagTrendingRepository(db *gorm.DB) TagTrendingRepository {
+	return TagTrendingRepository{db: db}
+}
+
+// Compute replaces the trending scores of window with scores of the events within it before now:
+// tagging an article and publishing a tagged article. Each event adds its weight halved for every
+// halfLife it is old, so recent events count more. Returns the number of scored tags. Cancelling ctx
+// aborts the computation.
+func (repo TagTrendingRepository) Compute(ctx context.Context, window models.TrendingWindow, now time.Time, halfLife time.Duration) (int64, error) {
+	since := now.Add(-window.Duration)
+	publishActions := []string{models.ArticleTransitionPublish, models.ArticleHistoryScheduledPublish}
+
+	var count int64
+	err := repo.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("time_window = ?", window.Name).Delete(&models.TagTrendingScore{}).Error; err != nil {
+			return err
+		}
+
+		result := tx.Exec(`INSERT INTO tag_trending_scores (tag_id, time_window, score, created_at, updated_at)
+SELECT tag_id, ?, SUM(weight * power(0.5, EXTRACT(EPOCH FROM (CAST(? AS timestamptz) - happened_at)) / ?)), ?, ?
+FROM (
+	SELECT tag_id, created_at AS happened_at, CAST(? AS double precision) AS weight
+	FROM article_tags
+	WHERE created_at > ? AND created_at <= ?
+	UNION ALL
+	SELECT article_tags.tag_id, article_histories.created_at, CAST(? AS double precision)
+	FROM article_histories
+	JOIN article_tags ON article_tags.article_id = article_histories.article_id
+	WHERE article_histories.action IN ? AND article_histories.created_at > ? AND article_histories.created_at <= ?
+) events
+GROUP BY tag_id`,
+			window.Name, now, halfLife.Seconds(), now, now,
+			trendingUsageWeight, since, now,
+			trendingPublishWeight, publishActions, since, now)
+		count = result.RowsAffected
+		return result.Error
+	})
+
+	return count, err
+}
+
+// List finds the limit highest scored tags of a window
+func (repo TagTrendingRepository) List(window string, limit int) ([]models.TrendingTag, error) {
+	data := []models.TrendingTag{}
+	result := repo.db.Model(&models.TagTrendingScore{}).
+		Select("tags.uuid, tags.title, tag_trending_scores.score").
+		Joins("JOIN tags ON tags.id = tag_trending_scores.tag_id").
+		Where("tag_trending_scores.time_window = ?", window).
+		Order("tag_trending_scores.score DESC, tags.id").
+		Limit(limit).
+		Scan(&data)
+	return data, result.Error
+}
@@ -0,0 +1,111 @@
+package respositories
+
+import (
+	"errors"
+	"time"
+
+	"github.com/herdiansc/go-cms/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	// ErrRefreshTokenReused is returned when an already rotated refresh token is presented again
+	ErrRefreshTokenReused = errors.New("refresh token reused")
+	// ErrRefreshTokenExpired is returned when a refresh token is past its expiry
+	ErrRefreshTokenExpired = errors.New("refresh token expired")
+)
+
+// TokenRepository struct
+type TokenRepository struct {
+	db *gorm.DB
+}
+
+// NewTokenRepository inits TokenRepository
+func NewTokenRepository(db *gorm.DB) TokenRepository {
+	return TokenRepository{db: db}
+}
+
+// CreateRefreshToken saves a refresh token
+func (repo TokenRepository) CreateRefreshToken(data models.RefreshToken) error {
+	return repo.db.Create(&data).Error
+}
+
+// RotateRefreshToken revokes the refresh token identified by hash and stores next in the same family.
+// Presenting a token that was already rotated revokes the whole family.
+func (repo TokenRepository) RotateRefreshToken(hash string, next models.RefreshToken) (models.RefreshToken, error) {
+	var current models.RefreshToken
+	tx := repo.db.Begin()
+	result := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("token_hash = ?", hash).First(&current)
+	if result.Error != nil {
+		tx.Rollback()
+		return models.RefreshToken{}, result.Error
+	}
+
+	now := time.Now()
+	if current.RevokedAt != nil {
+		result = tx.Model(&models.RefreshToken{}).
+			Where("family_id = ? AND revoked_at IS NULL", current.FamilyID).
+			Update("revoked_at", now)
+		if result.Error != nil {
+			tx.Rollback()
+			return models.RefreshToken{}, result.Error
+		}
+		tx.Commit()
+		return models.RefreshToken{}, ErrRefreshTokenReused
+	}
+	if current.ExpiresAt.Before(now) {
+		tx.Rollback()
+		return models.RefreshToken{}, ErrRefreshTokenExpired
+	}
+
+	current.RevokedAt = &now
+	if err := tx.Save(&current).Error; err != nil {
+		tx.Rollback()
+		return models.RefreshToken{}, err
+	}
+
+	next.AuthID = current.AuthID
+	next.FamilyID = current.FamilyID
+	if err := tx.Create(&next).Error; err != nil {
+		tx.Rollback()
+		return models.RefreshToken{}, err
+	}
+
+	tx.Commit()
+
+	return current, nil
+}
+
+// RevokeRefreshFamily revokes every token in the family of the given refresh token owned by authID
+func (repo TokenRepository) RevokeRefreshFamily(authID int64, hash string) error {
+	var data models.RefreshToken
+	result := repo.db.Where("token_hash = ? AND auth_id = ?", hash, authID).First(&data)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	return repo.db.Model(&models.RefreshToken{}).
+		Where("family_id = ? AND revoked_at IS NULL", data.FamilyID).
+		Update("revoked_at", time.Now()).Error
+}
+
+// RevokeAccessToken blacklists an access token by its jti until it expires
+func (repo TokenRepository) RevokeAccessToken(jti string, expiresAt time.Time) error {
+	return repo.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("expires_at < ?", time.Now()).Delete(&models.RevokedToken{}).Error; err != nil {
+			return err
+		}
+		return tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&models.RevokedToken{
+			JTI:       jti,
+			ExpiresAt: expiresAt,
+		}).Error
+	})
+}
+
+// IsRevoked checks whether an access token jti has been revoked
+func (repo TokenRepository) IsRevoked(jti string) (bool, error) {
+	var count int64
+	result := repo.db.Model(&models.RevokedToken{}).Where("jti = ?", jti).Count(&count)
+	return count > 0, result.Error
+}
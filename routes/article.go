@@ -5,15 +5,45 @@ import (
 
 	"github.com/herdiansc/go-cms/handlers"
 	"github.com/herdiansc/go-cms/middlewares"
+	"github.com/herdiansc/go-cms/models"
 	"gorm.io/gorm"
 )
 
-func ArticleRoutes(mux *http.ServeMux, DB *gorm.DB) {
+func ArticleRoutes(mux *http.ServeMux, DB *gorm.DB, mw middlewares.AuthMiddleware) {
 	handlerFuncs := handlers.NewArticleHandler(DB)
-	mux.Handle("POST /articles", middlewares.Authenticate(http.HandlerFunc(handlerFuncs.Create)))
-	mux.Handle("GET /articles", middlewares.Authenticate(http.HandlerFunc(handlerFuncs.List)))
-	mux.Handle("GET /articles/{uuid}", middlewares.Authenticate(http.HandlerFunc(handlerFuncs.Detail)))
-	mux.Handle("GET /articles/{uuid}/histories", middlewares.Authenticate(http.HandlerFunc(handlerFuncs.ListHistories)))
-	mux.Handle("DELETE /articles/{uuid}", middlewares.Authenticate(http.HandlerFunc(handlerFuncs.Delete)))
-	mux.Handle("PATCH /articles/{uuid}", middlewares.Authenticate(http.HandlerFunc(handlerFuncs.Patch)))
+	mux.Handle("POST /articles", mw.Authenticate(mw.Authorize(models.PermissionArticleCreate, http.HandlerFunc(handlerFuncs.Create))))
+	mux.Handle("GET /articles", mw.Authenticate(mw.Authorize(models.PermissionArticleRead, http.HandlerFunc(handlerFuncs.List))))
+	mux.Handle("GET /articles/by-slug/{slug}", mw.Authenticate(mw.Authorize(models.PermissionArticleRead, http.HandlerFunc(handlerFuncs.DetailBySlug))))
+	mux.Handle("GET /articles/trash", mw.Authenticate(mw.Authorize(models.PermissionArticleDeleteOwn, http.HandlerFunc(handlerFuncs.ListTrash))))
+	mux.Handle("GET /articles/search", mw.Authenticate(mw.Authorize(models.PermissionArticleRead, http.HandlerFunc(handlerFuncs.Search))))
+	mux.Handle("GET /articles/{uuid}", mw.Authenticate(mw.Authorize(models.PermissionArticleRead, http.HandlerFunc(handlerFuncs.Detail))))
+	mux.Handle("GET /articles/{uuid}/{resource}", mw.Authenticate(mw.Authorize(models.PermissionArticleRead, articleResources(map[string]http.HandlerFunc{
+		"related":     handlerFuncs.Related,
+		"histories":   handlerFuncs.ListHistories,
+		"transitions": handlerFuncs.ListTransitions,
+	}))))
+	mux.Handle("GET /articles/{uuid}/histories/diff", mw.Authenticate(mw.Authorize(models.PermissionArticleRead, http.HandlerFunc(handlerFuncs.DiffHistories))))
+	mux.Handle("POST /articles/{uuid}/histories/{version}/restore", mw.Authenticate(mw.Authorize(models.PermissionArticleUpdateOwn, http.HandlerFunc(handlerFuncs.RestoreHistory))))
+	mux.Handle("POST /articles/{uuid}/restore", mw.Authenticate(mw.Authorize(models.PermissionArticleDeleteOwn, http.HandlerFunc(handlerFuncs.Undelete))))
+	mux.Handle("DELETE /articles/{uuid}", mw.Authenticate(mw.Authorize(models.PermissionArticleDeleteOwn, http.HandlerFunc(handlerFuncs.Delete))))
+	mux.Handle("PATCH /articles/{uuid}", mw.Authenticate(mw.Authorize(models.PermissionArticleUpdateOwn, http.HandlerFunc(handlerFuncs.Patch))))
+	mux.Handle("PUT /articles/{uuid}", mw.Authenticate(mw.Authorize(models.PermissionArticleUpdateOwn, http.HandlerFunc(handlerFuncs.Put))))
+	mux.Handle("POST /articles/{uuid}/lock", mw.Authenticate(mw.Authorize(models.PermissionArticleUpdateOwn, http.HandlerFunc(handlerFuncs.Lock))))
+	mux.Handle("DELETE /articles/{uuid}/lock", mw.Authenticate(mw.Authorize(models.PermissionArticleUpdateOwn, http.HandlerFunc(handlerFuncs.Unlock))))
+	mux.Handle("PUT /articles/{uuid}/schedule", mw.Authenticate(mw.Authorize(models.PermissionArticlePublish, http.HandlerFunc(handlerFuncs.Schedule))))
+	mux.Handle("POST /articles/{uuid}/transitions", mw.Authenticate(mw.Authorize(models.PermissionArticleRead, http.HandlerFunc(handlerFuncs.Transition))))
+}
+
+// articleResources serves GET /articles/{uuid}/{resource} with the handler of the resource. These routes
+// share one pattern because ServeMux refuses GET /articles/{uuid}/related next to GET /articles/by-slug/{slug},
+// neither being more specific than the other.
+func articleResources(resources map[string]http.HandlerFunc) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		handler, ok := resources[r.PathValue("resource")]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		handler(w, r)
+	})
 }
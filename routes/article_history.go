@@ -5,10 +5,11 @@ import (
 
 	"github.com/herdiansc/go-cms/handlers"
 	"github.com/herdiansc/go-cms/middlewares"
+	"github.com/herdiansc/go-cms/models"
 	"gorm.io/gorm"
 )
 
-func ArticleHistoryRoutes(mux *http.ServeMux, DB *gorm.DB) {
+func ArticleHistoryRoutes(mux *http.ServeMux, DB *gorm.DB, mw middlewares.AuthMiddleware) {
 	handlerFuncs := handlers.NewArticleHistoryHandler(DB)
-	mux.Handle("GET /article-histories/{uuid}", middlewares.Authenticate(http.HandlerFunc(handlerFuncs.Detail)))
+	mux.Handle("GET /article-histories/{uuid}", mw.Authenticate(mw.Authorize(models.PermissionArticleRead, http.HandlerFunc(handlerFuncs.Detail))))
 }
@@ -3,14 +3,18 @@ package routes
 import (
 	"net/http"
 
+	"github.com/herdiansc/go-cms/config"
 	"github.com/herdiansc/go-cms/handlers"
 	"github.com/herdiansc/go-cms/middlewares"
 	"gorm.io/gorm"
 )
 
-func AuthRoutes(mux *http.ServeMux, DB *gorm.DB) {
-	handlerFuncs := handlers.NewAuthHandler(DB)
+func AuthRoutes(mux *http.ServeMux, DB *gorm.DB, keys config.KeySet, mw middlewares.AuthMiddleware) {
+	handlerFuncs := handlers.NewAuthHandler(DB, keys)
 	mux.HandleFunc("POST /auth/register", handlerFuncs.Register)
 	mux.HandleFunc("POST /auth/login", handlerFuncs.Login)
-	mux.Handle("GET /auth/profile", middlewares.Authenticate(http.HandlerFunc(handlerFuncs.GetProfile)))
+	mux.HandleFunc("POST /auth/refresh", handlerFuncs.Refresh)
+	mux.Handle("POST /auth/logout", mw.Authenticate(http.HandlerFunc(handlerFuncs.Logout)))
+	mux.Handle("GET /auth/profile", mw.Authenticate(http.HandlerFunc(handlerFuncs.GetProfile)))
+	mux.HandleFunc("GET /.well-known/jwks.json", handlerFuncs.JWKS)
 }
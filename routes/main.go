@@ -5,22 +5,29 @@ import (
 	"net/http"
 	"os"
 
+	"github.com/herdiansc/go-cms/config"
+	"github.com/herdiansc/go-cms/middlewares"
+
 	httpSwagger "github.com/swaggo/http-swagger"
 	"gorm.io/gorm"
 )
 
-func LoadRoutes(DB *gorm.DB) http.Handler {
+func LoadRoutes(DB *gorm.DB, keys config.KeySet) http.Handler {
 	httpServer := http.NewServeMux()
 
-	AuthRoutes(httpServer, DB)
-	ArticleRoutes(httpServer, DB)
-	ArticleHistoryRoutes(httpServer, DB)
-	TagRoutes(httpServer, DB)
+	mw := middlewares.NewAuthMiddleware(DB, keys)
+
+	AuthRoutes(httpServer, DB, keys, mw)
+	ArticleRoutes(httpServer, DB, mw)
+	ArticleHistoryRoutes(httpServer, DB, mw)
+	TagRoutes(httpServer, DB, mw)
+	UserRoutes(httpServer, DB, mw)
+	PublicRoutes(httpServer, DB)
 
 	port := os.Getenv("SERVICE_PORT")
 	httpServer.HandleFunc("/swagger/", httpSwagger.Handler(
 		httpSwagger.URL(fmt.Sprintf("http://localhost:%s/swagger/doc.json", port)), //The url pointing to API definition
 	))
 
-	return httpServer
+	return middlewares.RequestID(httpServer)
 }
@@ -0,0 +1,19 @@
+package routes
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/herdiansc/go-cms/config"
+	"github.com/herdiansc/go-cms/handlers"
+	"github.com/herdiansc/go-cms/middlewares"
+	"gorm.io/gorm"
+)
+
+func PublicRoutes(mux *http.ServeMux, DB *gorm.DB) {
+	handlerFuncs := handlers.NewPublicHandler(DB)
+	maxAge := config.GetDuration("PUBLIC_CACHE_MAX_AGE", time.Minute)
+	mux.Handle("GET /public/articles", middlewares.PublicCache(maxAge, http.HandlerFunc(handlerFuncs.ListArticles)))
+	mux.Handle("GET /public/articles/{slug}", middlewares.PublicCache(maxAge, http.HandlerFunc(handlerFuncs.DetailArticle)))
+	mux.Handle("GET /public/tags", middlewares.PublicCache(maxAge, http.HandlerFunc(handlerFuncs.ListTags)))
+}
@@ -5,12 +5,20 @@ import (
 
 	"github.com/herdiansc/go-cms/handlers"
 	"github.com/herdiansc/go-cms/middlewares"
+	"github.com/herdiansc/go-cms/models"
 	"gorm.io/gorm"
 )
 
-func TagRoutes(mux *http.ServeMux, DB *gorm.DB) {
+func TagRoutes(mux *http.ServeMux, DB *gorm.DB, mw middlewares.AuthMiddleware) {
 	handlerFuncs := handlers.NewTagHandler(DB)
-	mux.Handle("GET /tags", middlewares.Authenticate(http.HandlerFunc(handlerFuncs.List)))
-	mux.Handle("GET /tags/{id}", middlewares.Authenticate(http.HandlerFunc(handlerFuncs.Detail)))
-	mux.Handle("POST /tags", middlewares.Authenticate(http.HandlerFunc(handlerFuncs.Create)))
+	mux.Handle("GET /tags", mw.Authenticate(mw.Authorize(models.PermissionArticleRead, http.HandlerFunc(handlerFuncs.List))))
+	mux.Handle("GET /tags/trending", mw.Authenticate(mw.Authorize(models.PermissionArticleRead, http.HandlerFunc(handlerFuncs.Trending))))
+	mux.Handle("GET /tags/tree", mw.Authenticate(mw.Authorize(models.PermissionArticleRead, http.HandlerFunc(handlerFuncs.Tree))))
+	mux.Handle("GET /tags/suggest", mw.Authenticate(mw.Authorize(models.PermissionArticleRead, http.HandlerFunc(handlerFuncs.Suggest))))
+	mux.Handle("GET /tags/{uuid}", mw.Authenticate(mw.Authorize(models.PermissionArticleRead, http.HandlerFunc(handlerFuncs.Detail))))
+	mux.Handle("POST /tags", mw.Authenticate(mw.Authorize(models.PermissionTagManage, http.HandlerFunc(handlerFuncs.Create))))
+	mux.Handle("PATCH /tags/{uuid}", mw.Authenticate(mw.Authorize(models.PermissionTagManage, http.HandlerFunc(handlerFuncs.Rename))))
+	mux.Handle("PUT /tags/{uuid}/parent", mw.Authenticate(mw.Authorize(models.PermissionTagManage, http.HandlerFunc(handlerFuncs.Move))))
+	mux.Handle("POST /tags/{uuid}/merge", mw.Authenticate(mw.Authorize(models.PermissionTagManage, http.HandlerFunc(handlerFuncs.Merge))))
+	mux.Handle("DELETE /tags/{uuid}", mw.Authenticate(mw.Authorize(models.PermissionTagManage, http.HandlerFunc(handlerFuncs.Delete))))
 }
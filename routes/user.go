@@ -0,0 +1,15 @@
+package routes
+
+import (
+	"net/http"
+
+	"github.com/herdiansc/go-cms/handlers"
+	"github.com/herdiansc/go-cms/middlewares"
+	"github.com/herdiansc/go-cms/models"
+	"gorm.io/gorm"
+)
+
+func UserRoutes(mux *http.ServeMux, DB *gorm.DB, mw middlewares.AuthMiddleware) {
+	handlerFuncs := handlers.NewUserHandler(DB)
+	mux.Handle("PATCH /users/{uuid}/role", mw.Authenticate(mw.Authorize(models.PermissionUserManage, http.HandlerFunc(handlerFuncs.ChangeRole))))
+}
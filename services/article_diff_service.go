@@ -0,0 +1,127 @@
+package services
+
+import (
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/herdiansc/go-cms/models"
+)
+
+// DiffArticleHistoryServices defines diff article history service struct
+type DiffArticleHistoryServices struct {
+	authData    any
+	articleRepo ArticleDetailer
+	policy      ArticlePolicy
+	historyRepo ArticleHistoryVersionFinder
+}
+
+// NewDiffArticleHistoryServices inits DiffArticleHistoryServices
+func NewDiffArticleHistoryServices(ad any, ar ArticleDetailer, pc PermissionChecker, hr ArticleHistoryVersionFinder) DiffArticleHistoryServices {
+	return DiffArticleHistoryServices{
+		authData:    ad,
+		articleRepo: ar,
+		policy:      NewArticlePolicy(pc),
+		historyRepo: hr,
+	}
+}
+
+// Diff compares two history versions of an article given by the from and to query params
+func (svc DiffArticleHistoryServices) Diff(articleUUID string, q url.Values) (int, models.Response) {
+	authData, ok := svc.authData.(models.VerifyData)
+	if !ok {
+		log.Printf("Failed to read authData\n")
+		return http.StatusBadRequest, models.Response{Message: "error", Data: nil}
+	}
+
+	from, errFrom := strconv.ParseInt(q.Get("from"), 10, 64)
+	to, errTo := strconv.ParseInt(q.Get("to"), 10, 64)
+	if errFrom != nil || errTo != nil {
+		log.Printf("Failed to read versions: from=%s to=%s\n", q.Get("from"), q.Get("to"))
+		return http.StatusBadRequest, models.Response{Message: "Bad Request", Data: "from and to must be version numbers"}
+	}
+
+	article, err := svc.articleRepo.FindByParam("uuid", articleUUID)
+	if err != nil {
+		log.Printf("Failed to get data: %+v\n", err.Error())
+		return http.StatusNotFound, models.Response{Message: "not found", Data: err.Error()}
+	}
+
+	if code, res := svc.policy.Authorize(authData, article, ArticleActionRead); code != http.StatusOK {
+		log.Printf("Failed to authorize: %+v\n", res.Data)
+		return code, res
+	}
+
+	snapshots := make([]models.Article, 2)
+	for i, version := range []int64{from, to} {
+		history, err := svc.historyRepo.FindVersion(article.ID, version)
+		if err != nil {
+			log.Printf("Failed to get history data: %+v\n", err.Error())
+			return http.StatusNotFound, models.Response{Message: "not found", Data: err.Error()}
+		}
+		snapshots[i], err = history.Snapshot()
+		if err != nil {
+			log.Printf("Failed to decode snapshot: %+v\n", err.Error())
+			return http.StatusInternalServerError, models.Response{Message: "Failed to read snapshot", Data: err.Error()}
+		}
+	}
+
+	data := diffArticles(snapshots[0], snapshots[1])
+	data.ArticleUUID = article.UUID
+	data.From = from
+	data.To = to
+
+	return http.StatusOK, models.Response{Message: "ok", Data: data}
+}
+
+// diffArticles compares two snapshots field by field, content gets a line and word-level diff
+func diffArticles(from, to models.Article) models.ArticleDiff {
+	contentEdits := editScript(splitLines(from.Content), splitLines(to.Content))
+	diff := models.ArticleDiff{
+		Fields:  []models.FieldDiff{},
+		Content: diffLines(contentEdits),
+	}
+	var unified strings.Builder
+
+	fields := []struct {
+		name     string
+		from, to any
+		text     func(any) string
+	}{
+		{"title", from.Title, to.Title, func(v any) string { return v.(string) }},
+		{"content", from.Content, to.Content, func(v any) string { return v.(string) }},
+		{"status", from.Status, to.Status, func(v any) string { return v.(string) }},
+		{"slug", from.Slug, to.Slug, func(v any) string { return v.(string) }},
+		{"tags", from.Tags, to.Tags, func(v any) string { return strings.Join(v.([]string), "\n") }},
+		{"publish_at", from.PublishAt, to.PublishAt, formatTime},
+		{"unpublish_at", from.UnpublishAt, to.UnpublishAt, formatTime},
+	}
+	for _, field := range fields {
+		fromText, toText := field.text(field.from), field.text(field.to)
+		if fromText == toText {
+			continue
+		}
+		if field.name == "content" {
+			diff.Fields = append(diff.Fields, models.FieldDiff{Field: field.name})
+			unified.WriteString(unifiedDiff(field.name, contentEdits))
+			continue
+		}
+		diff.Fields = append(diff.Fields, models.FieldDiff{Field: field.name, From: field.from, To: field.to})
+		unified.WriteString(unifiedDiff(field.name, editScript(splitLines(fromText), splitLines(toText))))
+	}
+	diff.Unified = unified.String()
+
+	return diff
+}
+
+// formatTime formats an optional time as RFC 3339, nil is empty
+func formatTime(v any) string {
+	t, _ := v.(*time.Time)
+	if t == nil {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
@@ -0,0 +1,265 @@
+package services
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/herdiansc/go-cms/models"
+)
+
+var (
+	mockDiffArticleHistoryVersionFinder = mockArticleHistoryVersionFinder{
+		d: models.ArticleHistory{Article: `{"Title":"old","Content":"a\nb\nc","Status":"DRAFT","Tags":["go"]}`},
+		e: nil,
+	}
+)
+
+func TestDiffArticleHistoryServices_Diff(t *testing.T) {
+	type fields struct {
+		authData    any
+		articleRepo mockArticleDetailer
+		policy      mockPermissionChecker
+		historyRepo mockArticleHistoryVersionFinder
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		q      url.Values
+		want   int
+	}{
+		{
+			name: "Positive",
+			fields: fields{
+				authData:    mockValidAuthData,
+				articleRepo: mockSuccessArticleDetailer,
+				policy:      mockGrantAllPermissionChecker,
+				historyRepo: mockDiffArticleHistoryVersionFinder,
+			},
+			q:    url.Values{"from": {"1"}, "to": {"2"}},
+			want: 200,
+		},
+		{
+			name: "Failed to read authData",
+			fields: fields{
+				authData:    "invalid",
+				articleRepo: mockSuccessArticleDetailer,
+				policy:      mockGrantAllPermissionChecker,
+				historyRepo: mockDiffArticleHistoryVersionFinder,
+			},
+			q:    url.Values{"from": {"1"}, "to": {"2"}},
+			want: 400,
+		},
+		{
+			name: "Missing versions",
+			fields: fields{
+				authData:    mockValidAuthData,
+				articleRepo: mockSuccessArticleDetailer,
+				policy:      mockGrantAllPermissionChecker,
+				historyRepo: mockDiffArticleHistoryVersionFinder,
+			},
+			q:    url.Values{"from": {"a"}},
+			want: 400,
+		},
+		{
+			name: "Failed to get article data",
+			fields: fields{
+				authData:    mockValidAuthData,
+				articleRepo: mockFailedArticleDetailer,
+				policy:      mockGrantAllPermissionChecker,
+				historyRepo: mockDiffArticleHistoryVersionFinder,
+			},
+			q:    url.Values{"from": {"1"}, "to": {"2"}},
+			want: 404,
+		},
+		{
+			name: "Not allowed to read article",
+			fields: fields{
+				authData:    mockValidAuthData,
+				articleRepo: mockSuccessArticleDetailer,
+				policy:      mockDenyAllPermissionChecker,
+				historyRepo: mockDiffArticleHistoryVersionFinder,
+			},
+			q:    url.Values{"from": {"1"}, "to": {"2"}},
+			want: 403,
+		},
+		{
+			name: "Failed to get history data",
+			fields: fields{
+				authData:    mockValidAuthData,
+				articleRepo: mockSuccessArticleDetailer,
+				policy:      mockGrantAllPermissionChecker,
+				historyRepo: mockFailedArticleHistoryVersionFinder,
+			},
+			q:    url.Values{"from": {"1"}, "to": {"2"}},
+			want: 404,
+		},
+		{
+			name: "Failed to decode snapshot",
+			fields: fields{
+				authData:    mockValidAuthData,
+				articleRepo: mockSuccessArticleDetailer,
+				policy:      mockGrantAllPermissionChecker,
+				historyRepo: mockInvalidArticleHistoryVersionFinder,
+			},
+			q:    url.Values{"from": {"1"}, "to": {"2"}},
+			want: 500,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := NewDiffArticleHistoryServices(tt.fields.authData, tt.fields.articleRepo, tt.fields.policy, tt.fields.historyRepo)
+			got, _ := svc.Diff("abc-123", tt.q)
+			if got != tt.want {
+				t.Errorf("DiffArticleHistoryServices.Diff() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDiffArticles(t *testing.T) {
+	from := models.Article{Title: "old", Content: "one\ntwo\nthree", Status: models.ArticleStatusDraft, Tags: []string{"go"}}
+	to := models.Article{Title: "new", Content: "one\ntwo 2\nthree\nfour", Status: models.ArticleStatusDraft, Tags: []string{"go"}}
+
+	diff := diffArticles(from, to)
+
+	var fields []string
+	for _, field := range diff.Fields {
+		fields = append(fields, field.Field)
+	}
+	if len(fields) != 2 || fields[0] != "title" || fields[1] != "content" {
+		t.Errorf("diffArticles() fields = %v, want [title content]", fields)
+	}
+
+	wantOps := []string{models.DiffEqual, models.DiffDelete, models.DiffInsert, models.DiffEqual, models.DiffInsert}
+	if len(diff.Content) != len(wantOps) {
+		t.Fatalf("diffArticles() content = %+v, want ops %v", diff.Content, wantOps)
+	}
+	for i, line := range diff.Content {
+		if line.Op != wantOps[i] {
+			t.Errorf("diffArticles() content[%d].Op = %v, want %v", i, line.Op, wantOps[i])
+		}
+	}
+	words := diff.Content[2].Words
+	if len(words) != 2 || words[1].Op != models.DiffInsert || words[1].Text != "2" {
+		t.Errorf("diffArticles() changed line words = %+v", words)
+	}
+
+	wantUnified := "--- a/title\n+++ b/title\n@@ -1 +1 @@\n-old\n+new\n" +
+		"--- a/content\n+++ b/content\n@@ -1,3 +1,4 @@\n one\n-two\n+two 2\n three\n+four\n"
+	if diff.Unified != wantUnified {
+		t.Errorf("diffArticles() unified = %q, want %q", diff.Unified, wantUnified)
+	}
+}
+
+func TestUnifiedDiff(t *testing.T) {
+	tests := []struct {
+		name string
+		from string
+		to   string
+		want string
+	}{
+		{
+			name: "Equal",
+			from: "a\nb",
+			to:   "a\nb",
+			want: "",
+		},
+		{
+			name: "From empty",
+			from: "",
+			to:   "a",
+			want: "--- a/f\n+++ b/f\n@@ -0,0 +1 @@\n+a\n",
+		},
+		{
+			name: "Separate hunks",
+			from: "1\n2\n3\n4\n5\n6\n7\n8\n9\n10",
+			to:   "0\n2\n3\n4\n5\n6\n7\n8\n9\nX",
+			want: "--- a/f\n+++ b/f\n@@ -1,4 +1,4 @@\n-1\n+0\n 2\n 3\n 4\n@@ -7,4 +7,4 @@\n 7\n 8\n 9\n-10\n+X\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := unifiedDiff("f", editScript(splitLines(tt.from), splitLines(tt.to))); got != tt.want {
+				t.Errorf("unifiedDiff() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEditScript(t *testing.T) {
+	ops := func(edits []edit) string {
+		var sb strings.Builder
+		for _, e := range edits {
+			switch e.op {
+			case models.DiffEqual:
+				sb.WriteString("=")
+			case models.DiffDelete:
+				sb.WriteString("-")
+			case models.DiffInsert:
+				sb.WriteString("+")
+			}
+		}
+		return sb.String()
+	}
+	many := func(prefix string, n int) []string {
+		lines := make([]string, n)
+		for i := range lines {
+			lines[i] = fmt.Sprintf("%s%d", prefix, i)
+		}
+		return lines
+	}
+
+	tests := []struct {
+		name string
+		a    []string
+		b    []string
+		want string
+	}{
+		{
+			name: "Equal",
+			a:    []string{"a", "b"},
+			b:    []string{"a", "b"},
+			want: "==",
+		},
+		{
+			name: "Both empty",
+			a:    []string{},
+			b:    []string{},
+			want: "",
+		},
+		{
+			name: "Shortest script",
+			a:    []string{"a", "b", "c", "a", "b", "b", "a"},
+			b:    []string{"c", "b", "a", "b", "a", "c"},
+			want: "-+=-==-=+",
+		},
+		{
+			name: "Replaced in whole above the limit",
+			a:    append(append([]string{"same"}, many("a", maxDiffLines/2+1)...), "end"),
+			b:    append(append([]string{"same"}, many("b", maxDiffLines/2)...), "end"),
+			want: "=" + strings.Repeat("-", maxDiffLines/2+1) + strings.Repeat("+", maxDiffLines/2) + "=",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			edits := editScript(tt.a, tt.b)
+			if got := ops(edits); got != tt.want {
+				t.Errorf("editScript() = %v, want %v", got, tt.want)
+			}
+			i, j := 0, 0
+			for _, e := range edits {
+				if e.aPos != i || e.bPos != j {
+					t.Fatalf("editScript() edit %+v at a=%d b=%d", e, i, j)
+				}
+				if e.op != models.DiffInsert {
+					i++
+				}
+				if e.op != models.DiffDelete {
+					j++
+				}
+			}
+		})
+	}
+}
@@ -1,61 +1,62 @@
 package services
 
 import (
+	"errors"
 	"log"
 	"net/http"
 	"net/url"
+	"strconv"
 
 	"github.com/herdiansc/go-cms/models"
 )
 
 // ArticleHistoryLister defines article history lister function
 type ArticleHistoryLister interface {
-	List(params map[string]interface{}) ([]models.ArticleHistory, error)
+	List(articleID int64, q url.Values) ([]models.ArticleHistory, models.PageMeta, error)
 }
 
 // ListArticleHistoryServices defines list article history service struct
 type ListArticleHistoryServices struct {
 	authData    any
 	articleRepo ArticleDetailer
+	policy      ArticlePolicy
 	repo        ArticleHistoryLister
 }
 
 // NewListArticleHistoryServices inits ListArticleHistoryServices
-func NewListArticleHistoryServices(ad any, ar ArticleDetailer, al ArticleHistoryLister) ListArticleHistoryServices {
+func NewListArticleHistoryServices(ad any, ar ArticleDetailer, pc PermissionChecker, al ArticleHistoryLister) ListArticleHistoryServices {
 	return ListArticleHistoryServices{
 		authData:    ad,
 		articleRepo: ar,
+		policy:      NewArticlePolicy(pc),
 		repo:        al,
 	}
 }
 
-// List performs action of listing article histories
-func (svc ListArticleHistoryServices) List(articleID int64, q url.Values) (int, models.Response) {
-	_, ok := svc.authData.(models.VerifyData)
+// List performs action of listing a page of article histories, an empty page is not an error
+func (svc ListArticleHistoryServices) List(articleUUID string, q url.Values) (int, models.Response) {
+	authData, ok := svc.authData.(models.VerifyData)
 	if !ok {
 		log.Printf("Failed to read authData\n")
 		return http.StatusBadRequest, models.Response{Message: "error", Data: nil}
 	}
 
-	article, err := svc.articleRepo.FindByParam("id", articleID)
+	article, err := svc.articleRepo.FindByParam("uuid", articleUUID)
 	if err != nil {
 		log.Printf("Failed to get data: %+v\n", err.Error())
 		return http.StatusNotFound, models.Response{Message: "not found", Data: err.Error()}
 	}
 
-	params := make(map[string]interface{})
-	for k, v := range q {
-		params[k] = v[0]
+	if code, res := svc.policy.Authorize(authData, article, ArticleActionRead); code != http.StatusOK {
+		log.Printf("Failed to authorize: %+v\n", res.Data)
+		return code, res
 	}
-	params["article_id"] = article.ID
 
-	data, _ := svc.repo.List(params)
-	if len(data) == 0 {
-		log.Printf("Failed to get data")
-		return http.StatusNotFound, models.Response{Message: "Not found", Data: nil}
+	data, meta, err := svc.repo.List(article.ID, q)
+	if err != nil {
+		return listErrorResponse(err)
 	}
-
-	return http.StatusOK, models.Response{Message: "ok", Data: data}
+	return http.StatusOK, models.Response{Message: "ok", Data: data, Meta: &meta}
 }
 
 // ArticleHistoryDetailer defines article history detailer function
@@ -65,31 +66,142 @@ type ArticleHistoryDetailer interface {
 
 // DetailArticleHistoryServices defines detail article history service struct
 type DetailArticleHistoryServices struct {
-	authData any
-	repo     ArticleHistoryDetailer
+	authData    any
+	articleRepo ArticleDetailer
+	policy      ArticlePolicy
+	repo        ArticleHistoryDetailer
 }
 
 // NewDetailArticleHistoryServices inits DetailArticleHistoryServices
-func NewDetailArticleHistoryServices(ad any, al ArticleHistoryDetailer) DetailArticleHistoryServices {
+func NewDetailArticleHistoryServices(ad any, ar ArticleDetailer, pc PermissionChecker, al ArticleHistoryDetailer) DetailArticleHistoryServices {
 	return DetailArticleHistoryServices{
-		authData: ad,
-		repo:     al,
+		authData:    ad,
+		articleRepo: ar,
+		policy:      NewArticlePolicy(pc),
+		repo:        al,
 	}
 }
 
-// GetDetailByUUID gets detail of an article history by id
-func (svc DetailArticleHistoryServices) GetDetailByUUID(id int64) (int, models.Response) {
-	_, ok := svc.authData.(models.VerifyData)
+// GetDetailByUUID gets detail of an article history by uuid
+func (svc DetailArticleHistoryServices) GetDetailByUUID(uuid string) (int, models.Response) {
+	authData, ok := svc.authData.(models.VerifyData)
 	if !ok {
 		log.Printf("Failed to read authData\n")
 		return http.StatusBadRequest, models.Response{Message: "error", Data: nil}
 	}
 
-	data, err := svc.repo.FindByParam("id", id)
+	data, err := svc.repo.FindByParam("uuid", uuid)
 	if err != nil {
 		log.Printf("Failed to get data: %+v\n", err.Error())
 		return http.StatusNotFound, models.Response{Message: "not found", Data: err.Error()}
 	}
 
+	article, err := svc.articleRepo.FindByParam("id", data.ArticleID)
+	if err != nil {
+		log.Printf("Failed to get data: %+v\n", err.Error())
+		return http.StatusNotFound, models.Response{Message: "not found", Data: err.Error()}
+	}
+
+	if code, res := svc.policy.Authorize(authData, article, ArticleActionRead); code != http.StatusOK {
+		log.Printf("Failed to authorize: %+v\n", res.Data)
+		return code, res
+	}
+
 	return http.StatusOK, models.Response{Message: "ok", Data: data}
 }
+
+// ArticleHistoryVersionFinder defines article history version finder function
+type ArticleHistoryVersionFinder interface {
+	FindVersion(articleID int64, version int64) (models.ArticleHistory, error)
+}
+
+// ArticleRestorer defines article restorer function
+type ArticleRestorer interface {
+	Restore(id int64, actor models.Actor, version int64, snapshot models.Article) (models.Article, error)
+}
+
+// RestoreArticleHistoryServices defines restore article history service struct
+type RestoreArticleHistoryServices struct {
+	authData    any
+	articleRepo ArticleDetailer
+	policy      ArticlePolicy
+	historyRepo ArticleHistoryVersionFinder
+	repo        ArticleRestorer
+	locks       ArticleLockFinder
+}
+
+// NewRestoreArticleHistoryServices inits RestoreArticleHistoryServices
+func NewRestoreArticleHistoryServices(ad any, ar ArticleDetailer, pc PermissionChecker, hr ArticleHistoryVersionFinder, rs ArticleRestorer, al ArticleLockFinder) RestoreArticleHistoryServices {
+	return RestoreArticleHistoryServices{
+		authData:    ad,
+		articleRepo: ar,
+		policy:      NewArticlePolicy(pc),
+		historyRepo: hr,
+		repo:        rs,
+		locks:       al,
+	}
+}
+
+// Restore rehydrates an article from the snapshot stored in one of its history versions.
+// Restoring a different status bypasses the workflow, so it also needs the change status permission.
+func (svc RestoreArticleHistoryServices) Restore(articleUUID string, rawVersion string) (int, models.Response) {
+	authData, ok := svc.authData.(models.VerifyData)
+	if !ok {
+		log.Printf("Failed to read authData\n")
+		return http.StatusBadRequest, models.Response{Message: "error", Data: nil}
+	}
+
+	version, err := strconv.ParseInt(rawVersion, 10, 64)
+	if err != nil || version < 1 {
+		log.Printf("Failed to read version: %s\n", rawVersion)
+		return http.StatusBadRequest, models.Response{Message: "Bad Request", Data: "version must be a positive version number"}
+	}
+
+	article, err := svc.articleRepo.FindByParam("uuid", articleUUID)
+	if err != nil {
+		log.Printf("Failed to get data: %+v\n", err.Error())
+		return http.StatusNotFound, models.Response{Message: "not found", Data: err.Error()}
+	}
+
+	if code, res := svc.policy.Authorize(authData, article, ArticleActionUpdate); code != http.StatusOK {
+		log.Printf("Failed to authorize: %+v\n", res.Data)
+		return code, res
+	}
+	if code, res := checkArticleLock(svc.locks, authData, article); code != http.StatusOK {
+		return code, res
+	}
+
+	history, err := svc.historyRepo.FindVersion(article.ID, version)
+	if err != nil {
+		log.Printf("Failed to get history data: %+v\n", err.Error())
+		return http.StatusNotFound, models.Response{Message: "not found", Data: err.Error()}
+	}
+
+	snapshot, err := history.Snapshot()
+	if err != nil {
+		log.Printf("Failed to decode snapshot: %+v\n", err.Error())
+		return http.StatusInternalServerError, models.Response{Message: "Failed to read snapshot", Data: err.Error()}
+	}
+	if snapshot.DeletedAt.Valid {
+		log.Printf("Snapshot %d of article %s is deleted\n", version, article.UUID)
+		return http.StatusConflict, models.Response{Message: "Conflict", Data: "cannot restore a snapshot of a deleted article"}
+	}
+
+	if snapshot.Status != article.Status {
+		if code, res := svc.policy.Authorize(authData, article, ArticleActionChangeStatus); code != http.StatusOK {
+			log.Printf("Failed to authorize: %+v\n", res.Data)
+			return code, res
+		}
+	}
+
+	restored, err := svc.repo.Restore(article.ID, authData.Actor(), version, snapshot)
+	if errors.Is(err, models.ErrArticleLocked) {
+		return articleLockedMeanwhile(svc.locks, article)
+	}
+	if err != nil {
+		log.Printf("Failed to save data: %+v\n", err.Error())
+		return http.StatusInternalServerError, models.Response{Message: "Failed to save data", Data: err.Error()}
+	}
+
+	return http.StatusOK, models.Response{Message: "ok", Data: restored}
+}
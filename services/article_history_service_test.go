@@ -13,8 +13,8 @@ type mockArticleHistoryLister struct {
 	e error
 }
 
-func (m mockArticleHistoryLister) List(params map[string]interface{}) ([]models.ArticleHistory, error) {
-	return m.d, m.e
+func (m mockArticleHistoryLister) List(articleID int64, q url.Values) ([]models.ArticleHistory, models.PageMeta, error) {
+	return m.d, models.PageMeta{Limit: 10}, m.e
 }
 
 var (
@@ -41,11 +41,12 @@ func TestListArticleHistoryServices_List(t *testing.T) {
 	type fields struct {
 		authData    any
 		articleRepo mockArticleDetailer
+		policy      mockPermissionChecker
 		repo        mockArticleHistoryLister
 	}
 	type args struct {
-		articleID int64
-		q         url.Values
+		articleUUID string
+		q           url.Values
 	}
 	tests := []struct {
 		name   string
@@ -58,11 +59,12 @@ func TestListArticleHistoryServices_List(t *testing.T) {
 			fields: fields{
 				authData:    mockValidAuthData,
 				articleRepo: mockSuccessArticleDetailer,
+				policy:      mockGrantAllPermissionChecker,
 				repo:        mockSuccessArticleHistoryLister,
 			},
 			args: args{
-				articleID: 1,
-				q:         map[string][]string{"a": {"b"}},
+				articleUUID: "abc-123",
+				q:           map[string][]string{"a": {"b"}},
 			},
 			want: 200,
 		},
@@ -71,11 +73,12 @@ func TestListArticleHistoryServices_List(t *testing.T) {
 			fields: fields{
 				authData:    "invalid",
 				articleRepo: mockSuccessArticleDetailer,
+				policy:      mockGrantAllPermissionChecker,
 				repo:        mockSuccessArticleHistoryLister,
 			},
 			args: args{
-				articleID: 1,
-				q:         map[string][]string{"a": {"b"}},
+				articleUUID: "abc-123",
+				q:           map[string][]string{"a": {"b"}},
 			},
 			want: 400,
 		},
@@ -84,26 +87,42 @@ func TestListArticleHistoryServices_List(t *testing.T) {
 			fields: fields{
 				authData:    mockValidAuthData,
 				articleRepo: mockFailedArticleDetailer,
+				policy:      mockGrantAllPermissionChecker,
 				repo:        mockSuccessArticleHistoryLister,
 			},
 			args: args{
-				articleID: 1,
-				q:         map[string][]string{"a": {"b"}},
+				articleUUID: "abc-123",
+				q:           map[string][]string{"a": {"b"}},
 			},
 			want: 404,
 		},
 		{
-			name: "Failed to get history data",
+			name: "Empty page",
 			fields: fields{
 				authData:    mockValidAuthData,
 				articleRepo: mockSuccessArticleDetailer,
+				policy:      mockGrantAllPermissionChecker,
 				repo:        mockEmptyArticleHistoryLister,
 			},
 			args: args{
-				articleID: 1,
-				q:         map[string][]string{"a": {"b"}},
+				articleUUID: "abc-123",
+				q:           map[string][]string{"a": {"b"}},
 			},
-			want: 404,
+			want: 200,
+		},
+		{
+			name: "Not allowed to read article",
+			fields: fields{
+				authData:    mockValidAuthData,
+				articleRepo: mockSuccessArticleDetailer,
+				policy:      mockDenyAllPermissionChecker,
+				repo:        mockSuccessArticleHistoryLister,
+			},
+			args: args{
+				articleUUID: "abc-123",
+				q:           map[string][]string{"a": {"b"}},
+			},
+			want: 403,
 		},
 	}
 	for _, tt := range tests {
@@ -111,9 +130,10 @@ func TestListArticleHistoryServices_List(t *testing.T) {
 			svc := NewListArticleHistoryServices(
 				tt.fields.authData,
 				tt.fields.articleRepo,
+				tt.fields.policy,
 				tt.fields.repo,
 			)
-			got, _ := svc.List(tt.args.articleID, tt.args.q)
+			got, _ := svc.List(tt.args.articleUUID, tt.args.q)
 			if got != tt.want {
 				t.Errorf("ListArticleHistoryServices.List() got = %v, want %v", got, tt.want)
 			}
@@ -143,11 +163,13 @@ var (
 
 func TestDetailArticleHistoryServices_GetDetailByUUID(t *testing.T) {
 	type fields struct {
-		authData any
-		repo     mockArticleHistoryDetailer
+		authData    any
+		articleRepo mockArticleDetailer
+		policy      mockPermissionChecker
+		repo        mockArticleHistoryDetailer
 	}
 	type args struct {
-		id int64
+		uuid string
 	}
 	tests := []struct {
 		name   string
@@ -158,44 +180,312 @@ func TestDetailArticleHistoryServices_GetDetailByUUID(t *testing.T) {
 		{
 			name: "Positive",
 			fields: fields{
-				authData: mockValidAuthData,
-				repo:     mockSuccessArticleHistoryDetailer,
+				authData:    mockValidAuthData,
+				articleRepo: mockSuccessArticleDetailer,
+				policy:      mockGrantAllPermissionChecker,
+				repo:        mockSuccessArticleHistoryDetailer,
 			},
 			args: args{
-				id: 1,
+				uuid: "abc-123",
 			},
 			want: 200,
 		},
 		{
 			name: "Failed to read authData",
 			fields: fields{
-				authData: "invalid",
-				repo:     mockSuccessArticleHistoryDetailer,
+				authData:    "invalid",
+				articleRepo: mockSuccessArticleDetailer,
+				policy:      mockGrantAllPermissionChecker,
+				repo:        mockSuccessArticleHistoryDetailer,
 			},
 			args: args{
-				id: 1,
+				uuid: "abc-123",
 			},
 			want: 400,
 		},
 		{
 			name: "Failed to get data",
 			fields: fields{
-				authData: mockValidAuthData,
-				repo:     mockFailedArticleHistoryDetailer,
+				authData:    mockValidAuthData,
+				articleRepo: mockSuccessArticleDetailer,
+				policy:      mockGrantAllPermissionChecker,
+				repo:        mockFailedArticleHistoryDetailer,
+			},
+			args: args{
+				uuid: "abc-123",
+			},
+			want: 404,
+		},
+		{
+			name: "Failed to get article data",
+			fields: fields{
+				authData:    mockValidAuthData,
+				articleRepo: mockFailedArticleDetailer,
+				policy:      mockGrantAllPermissionChecker,
+				repo:        mockSuccessArticleHistoryDetailer,
 			},
 			args: args{
-				id: 1,
+				uuid: "abc-123",
 			},
 			want: 404,
 		},
+		{
+			name: "Not allowed to read article",
+			fields: fields{
+				authData:    mockValidAuthData,
+				articleRepo: mockSuccessArticleDetailer,
+				policy:      mockDenyAllPermissionChecker,
+				repo:        mockSuccessArticleHistoryDetailer,
+			},
+			args: args{
+				uuid: "abc-123",
+			},
+			want: 403,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			svc := NewDetailArticleHistoryServices(tt.fields.authData, tt.fields.repo)
-			got, _ := svc.GetDetailByUUID(tt.args.id)
+			svc := NewDetailArticleHistoryServices(tt.fields.authData, tt.fields.articleRepo, tt.fields.policy, tt.fields.repo)
+			got, _ := svc.GetDetailByUUID(tt.args.uuid)
 			if got != tt.want {
 				t.Errorf("DetailArticleHistoryServices.GetDetailByUUID() got = %v, want %v", got, tt.want)
 			}
 		})
 	}
 }
+
+type mockArticleHistoryVersionFinder struct {
+	d models.ArticleHistory
+	e error
+}
+
+func (m mockArticleHistoryVersionFinder) FindVersion(articleID int64, version int64) (models.ArticleHistory, error) {
+	return m.d, m.e
+}
+
+type mockArticleRestorer struct {
+	d models.Article
+	e error
+}
+
+func (m mockArticleRestorer) Restore(id int64, actor models.Actor, version int64, snapshot models.Article) (models.Article, error) {
+	return m.d, m.e
+}
+
+var (
+	mockSuccessArticleHistoryVersionFinder = mockArticleHistoryVersionFinder{
+		d: models.ArticleHistory{Article: `{"Title":"old","Content":"old","Status":"","Tags":["go"]}`},
+		e: nil,
+	}
+	mockStatusArticleHistoryVersionFinder = mockArticleHistoryVersionFinder{
+		d: models.ArticleHistory{Article: `{"Title":"old","Content":"old","Status":"PUBLISHED"}`},
+		e: nil,
+	}
+	mockDeletedArticleHistoryVersionFinder = mockArticleHistoryVersionFinder{
+		d: models.ArticleHistory{Article: `{"Title":"old","Content":"old","deleted_at":"2026-01-01T08:00:00Z"}`},
+		e: nil,
+	}
+	mockInvalidArticleHistoryVersionFinder = mockArticleHistoryVersionFinder{
+		d: models.ArticleHistory{Article: `invalid`},
+		e: nil,
+	}
+	mockFailedArticleHistoryVersionFinder = mockArticleHistoryVersionFinder{
+		d: models.ArticleHistory{},
+		e: errors.New("error"),
+	}
+	mockSuccessArticleRestorer = mockArticleRestorer{
+		d: models.Article{},
+		e: nil,
+	}
+	mockFailedArticleRestorer = mockArticleRestorer{
+		d: models.Article{},
+		e: errors.New("error"),
+	}
+	mockLockedArticleRestorer = mockArticleRestorer{
+		d: models.Article{},
+		e: models.ErrArticleLocked,
+	}
+)
+
+func TestRestoreArticleHistoryServices_Restore(t *testing.T) {
+	type fields struct {
+		authData    any
+		articleRepo mockArticleDetailer
+		policy      mockPermissionChecker
+		historyRepo mockArticleHistoryVersionFinder
+		repo        mockArticleRestorer
+		locks       mockArticleLocker
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		want   int
+	}{
+		{
+			name: "Positive",
+			fields: fields{
+				authData:    mockValidAuthData,
+				articleRepo: mockSuccessArticleDetailer,
+				policy:      mockGrantAllPermissionChecker,
+				historyRepo: mockSuccessArticleHistoryVersionFinder,
+				repo:        mockSuccessArticleRestorer,
+			},
+			want: 200,
+		},
+		{
+			name: "Failed to read authData",
+			fields: fields{
+				authData:    "invalid",
+				articleRepo: mockSuccessArticleDetailer,
+				policy:      mockGrantAllPermissionChecker,
+				historyRepo: mockSuccessArticleHistoryVersionFinder,
+				repo:        mockSuccessArticleRestorer,
+			},
+			want: 400,
+		},
+		{
+			name: "Failed to get article data",
+			fields: fields{
+				authData:    mockValidAuthData,
+				articleRepo: mockFailedArticleDetailer,
+				policy:      mockGrantAllPermissionChecker,
+				historyRepo: mockSuccessArticleHistoryVersionFinder,
+				repo:        mockSuccessArticleRestorer,
+			},
+			want: 404,
+		},
+		{
+			name: "Writer restores article of another writer",
+			fields: fields{
+				authData:    mockValidAuthData,
+				articleRepo: mockSuccessArticleDetailer,
+				policy:      mockWriterPermissionChecker,
+				historyRepo: mockSuccessArticleHistoryVersionFinder,
+				repo:        mockSuccessArticleRestorer,
+			},
+			want: 403,
+		},
+		{
+			name: "Failed to get history data",
+			fields: fields{
+				authData:    mockValidAuthData,
+				articleRepo: mockSuccessArticleDetailer,
+				policy:      mockGrantAllPermissionChecker,
+				historyRepo: mockFailedArticleHistoryVersionFinder,
+				repo:        mockSuccessArticleRestorer,
+			},
+			want: 404,
+		},
+		{
+			name: "Failed to decode snapshot",
+			fields: fields{
+				authData:    mockValidAuthData,
+				articleRepo: mockSuccessArticleDetailer,
+				policy:      mockGrantAllPermissionChecker,
+				historyRepo: mockInvalidArticleHistoryVersionFinder,
+				repo:        mockSuccessArticleRestorer,
+			},
+			want: 500,
+		},
+		{
+			name: "Snapshot of a deleted article",
+			fields: fields{
+				authData:    mockValidAuthData,
+				articleRepo: mockSuccessArticleDetailer,
+				policy:      mockGrantAllPermissionChecker,
+				historyRepo: mockDeletedArticleHistoryVersionFinder,
+				repo:        mockSuccessArticleRestorer,
+			},
+			want: 409,
+		},
+		{
+			name: "Writer restores own article with the same status",
+			fields: fields{
+				authData:    mockValidAuthData,
+				articleRepo: mockOwnArticleDetailer,
+				policy:      mockWriterPermissionChecker,
+				historyRepo: mockSuccessArticleHistoryVersionFinder,
+				repo:        mockSuccessArticleRestorer,
+			},
+			want: 200,
+		},
+		{
+			name: "Writer restores own article with another status",
+			fields: fields{
+				authData:    mockValidAuthData,
+				articleRepo: mockOwnArticleDetailer,
+				policy:      mockWriterPermissionChecker,
+				historyRepo: mockStatusArticleHistoryVersionFinder,
+				repo:        mockSuccessArticleRestorer,
+			},
+			want: 403,
+		},
+		{
+			name: "Locked by another user",
+			fields: fields{
+				authData:    mockValidAuthData,
+				articleRepo: mockSuccessArticleDetailer,
+				policy:      mockGrantAllPermissionChecker,
+				historyRepo: mockSuccessArticleHistoryVersionFinder,
+				repo:        mockSuccessArticleRestorer,
+				locks:       mockOtherArticleLocker,
+			},
+			want: 423,
+		},
+		{
+			name: "Failed to save data",
+			fields: fields{
+				authData:    mockValidAuthData,
+				articleRepo: mockSuccessArticleDetailer,
+				policy:      mockGrantAllPermissionChecker,
+				historyRepo: mockSuccessArticleHistoryVersionFinder,
+				repo:        mockFailedArticleRestorer,
+			},
+			want: 500,
+		},
+		{
+			name: "Locked while saving",
+			fields: fields{
+				authData:    mockValidAuthData,
+				articleRepo: mockSuccessArticleDetailer,
+				policy:      mockGrantAllPermissionChecker,
+				historyRepo: mockSuccessArticleHistoryVersionFinder,
+				repo:        mockLockedArticleRestorer,
+			},
+			want: 423,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := NewRestoreArticleHistoryServices(
+				tt.fields.authData,
+				tt.fields.articleRepo,
+				tt.fields.policy,
+				tt.fields.historyRepo,
+				tt.fields.repo,
+				tt.fields.locks,
+			)
+			got, _ := svc.Restore("abc-123", "1")
+			if got != tt.want {
+				t.Errorf("RestoreArticleHistoryServices.Restore() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRestoreArticleHistoryServices_RestoreInvalidVersion(t *testing.T) {
+	svc := NewRestoreArticleHistoryServices(
+		mockValidAuthData,
+		mockSuccessArticleDetailer,
+		mockGrantAllPermissionChecker,
+		mockSuccessArticleHistoryVersionFinder,
+		mockSuccessArticleRestorer,
+		mockArticleLocker{},
+	)
+	for _, version := range []string{"", "abc", "0", "-1"} {
+		got, _ := svc.Restore("abc-123", version)
+		if got != 400 {
+			t.Errorf("RestoreArticleHistoryServices.Restore(%q) got = %v, want %v", version, got, 400)
+		}
+	}
+}
@@ -0,0 +1,173 @@
+package services
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/herdiansc/go-cms/models"
+)
+
+// ArticleLockFinder defines function finding the lock of an article which has not expired
+type ArticleLockFinder interface {
+	FindActive(articleID int64) (models.ArticleLock, error)
+}
+
+// ArticleLocker defines article lock functions
+type ArticleLocker interface {
+	ArticleLockFinder
+	Acquire(articleID int64, ownerID int64, ttl time.Duration) (models.ArticleLock, error)
+	Release(articleID int64, ownerID int64) error
+}
+
+// checkArticleLock returns 200 when nobody but authData holds a lock on article and a 423 response otherwise
+func checkArticleLock(locks ArticleLockFinder, authData models.VerifyData, article models.Article) (int, models.Response) {
+	lock, err := locks.FindActive(article.ID)
+	if errors.Is(err, models.ErrArticleNotLocked) {
+		return http.StatusOK, models.Response{Message: "ok", Data: nil}
+	}
+	if err != nil {
+		log.Printf("Failed to get lock: %+v\n", err.Error())
+		return http.StatusInternalServerError, models.Response{Message: "Failed to get lock", Data: err.Error()}
+	}
+	if lock.OwnerID != authData.ID {
+		return articleLocked(lock)
+	}
+	return http.StatusOK, models.Response{Message: "ok", Data: nil}
+}
+
+// articleLocked is the response to a change of an article locked by another user, it carries the lock
+func articleLocked(lock models.ArticleLock) (int, models.Response) {
+	log.Printf("Article %s is locked by %s until %s\n", lock.ArticleUUID, lock.OwnerUUID, lock.ExpiresAt)
+	return http.StatusLocked, models.Response{Message: "Locked", Data: lock}
+}
+
+// articleLockedMeanwhile is the response to a change the repository refused with models.ErrArticleLocked,
+// another user locked article after checkArticleLock. It carries the lock while it is held.
+func articleLockedMeanwhile(locks ArticleLockFinder, article models.Article) (int, models.Response) {
+	lock, err := locks.FindActive(article.ID)
+	if err != nil {
+		log.Printf("Failed to get lock: %+v\n", err.Error())
+		lock = models.ArticleLock{ArticleID: article.ID, ArticleUUID: article.UUID}
+	}
+	return articleLocked(lock)
+}
+
+// LockArticleServices defines lock article service struct
+type LockArticleServices struct {
+	authData    any
+	articleRepo ArticleDetailer
+	policy      ArticlePolicy
+	repo        ArticleLocker
+	ttl         time.Duration
+}
+
+// NewLockArticleServices inits LockArticleServices, locks last for ttl unless renewed
+func NewLockArticleServices(ad any, ar ArticleDetailer, pc PermissionChecker, al ArticleLocker, ttl time.Duration) LockArticleServices {
+	return LockArticleServices{
+		authData:    ad,
+		articleRepo: ar,
+		policy:      NewArticlePolicy(pc),
+		repo:        al,
+		ttl:         ttl,
+	}
+}
+
+// Lock locks an article by uuid for the user, or renews the lock the user already holds as a heartbeat.
+// Users allowed to update the article may lock it.
+func (svc LockArticleServices) Lock(uuid string) (int, models.Response) {
+	authData, ok := svc.authData.(models.VerifyData)
+	if !ok {
+		log.Printf("Failed to read authData\n")
+		return http.StatusBadRequest, models.Response{Message: "error", Data: nil}
+	}
+
+	article, err := svc.articleRepo.FindByParam("uuid", uuid)
+	if err != nil {
+		log.Printf("Failed to get data: %+v\n", err.Error())
+		return http.StatusNotFound, models.Response{Message: "not found", Data: err.Error()}
+	}
+
+	if code, res := svc.policy.Authorize(authData, article, ArticleActionUpdate); code != http.StatusOK {
+		log.Printf("Failed to authorize: %+v\n", res.Data)
+		return code, res
+	}
+
+	lock, err := svc.repo.Acquire(article.ID, authData.ID, svc.ttl)
+	if errors.Is(err, models.ErrArticleLocked) {
+		return articleLocked(lock)
+	}
+	if err != nil {
+		log.Printf("Failed to save data: %+v\n", err.Error())
+		return http.StatusInternalServerError, models.Response{Message: "Failed to save data", Data: err.Error()}
+	}
+
+	return http.StatusOK, models.Response{Message: "ok", Data: lock}
+}
+
+// UnlockArticleServices defines unlock article service struct
+type UnlockArticleServices struct {
+	authData    any
+	articleRepo ArticleDetailer
+	checker     PermissionChecker
+	repo        ArticleLocker
+}
+
+// NewUnlockArticleServices inits UnlockArticleServices
+func NewUnlockArticleServices(ad any, ar ArticleDetailer, pc PermissionChecker, al ArticleLocker) UnlockArticleServices {
+	return UnlockArticleServices{
+		authData:    ad,
+		articleRepo: ar,
+		checker:     pc,
+		repo:        al,
+	}
+}
+
+// Unlock releases the lock of an article by uuid. The lock of another user is refused with 423 unless
+// force is set, which requires the article:unlock permission.
+func (svc UnlockArticleServices) Unlock(uuid string, force bool) (int, models.Response) {
+	authData, ok := svc.authData.(models.VerifyData)
+	if !ok {
+		log.Printf("Failed to read authData\n")
+		return http.StatusBadRequest, models.Response{Message: "error", Data: nil}
+	}
+
+	article, err := svc.articleRepo.FindByParam("uuid", uuid)
+	if err != nil {
+		log.Printf("Failed to get data: %+v\n", err.Error())
+		return http.StatusNotFound, models.Response{Message: "not found", Data: err.Error()}
+	}
+
+	lock, err := svc.repo.FindActive(article.ID)
+	if errors.Is(err, models.ErrArticleNotLocked) {
+		log.Printf("Article %s is not locked\n", article.UUID)
+		return http.StatusNotFound, models.Response{Message: "not found", Data: err.Error()}
+	}
+	if err != nil {
+		log.Printf("Failed to get lock: %+v\n", err.Error())
+		return http.StatusInternalServerError, models.Response{Message: "Failed to get lock", Data: err.Error()}
+	}
+
+	if lock.OwnerID != authData.ID {
+		if !force {
+			return articleLocked(lock)
+		}
+		if !can(svc.checker, authData, models.PermissionArticleUnlock) {
+			log.Printf("Failed to authorize: %s cannot force unlock articles\n", authData.RoleName)
+			return http.StatusForbidden, models.Response{Message: "Forbidden", Data: "you are not allowed to unlock articles locked by other users"}
+		}
+		log.Printf("Article %s force unlocked by %s, it was locked by %s\n", article.UUID, authData.UUID, lock.OwnerUUID)
+	}
+
+	err = svc.repo.Release(article.ID, lock.OwnerID)
+	if errors.Is(err, models.ErrArticleNotLocked) {
+		return http.StatusNotFound, models.Response{Message: "not found", Data: err.Error()}
+	}
+	if err != nil {
+		log.Printf("Failed to delete data: %+v\n", err.Error())
+		return http.StatusInternalServerError, models.Response{Message: "Failed to delete data", Data: err.Error()}
+	}
+
+	return http.StatusOK, models.Response{Message: "ok"}
+}
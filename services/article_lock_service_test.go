@@ -0,0 +1,278 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/herdiansc/go-cms/models"
+)
+
+// mockArticleLocker holds the lock d, a nil d means the article is not locked
+type mockArticleLocker struct {
+	d  *models.ArticleLock
+	e  error
+	ae error
+	re error
+}
+
+func (m mockArticleLocker) FindActive(articleID int64) (models.ArticleLock, error) {
+	if m.e != nil {
+		return models.ArticleLock{}, m.e
+	}
+	if m.d == nil {
+		return models.ArticleLock{}, models.ErrArticleNotLocked
+	}
+	return *m.d, nil
+}
+
+func (m mockArticleLocker) Acquire(articleID int64, ownerID int64, ttl time.Duration) (models.ArticleLock, error) {
+	if m.d != nil && m.d.OwnerID != ownerID {
+		return *m.d, models.ErrArticleLocked
+	}
+	return models.ArticleLock{ArticleID: articleID, OwnerID: ownerID, ExpiresAt: time.Now().Add(ttl)}, m.ae
+}
+
+func (m mockArticleLocker) Release(articleID int64, ownerID int64) error {
+	return m.re
+}
+
+var (
+	mockOwnArticleLocker = mockArticleLocker{
+		d: &models.ArticleLock{OwnerID: mockValidAuthData.ID, OwnerUUID: mockValidAuthData.UUID},
+	}
+	mockOtherArticleLocker = mockArticleLocker{
+		d: &models.ArticleLock{OwnerID: 2, OwnerUUID: "abc-456"},
+	}
+	mockFailedArticleLocker = mockArticleLocker{
+		e: errors.New("error"),
+	}
+)
+
+func TestLockArticleServices_Lock(t *testing.T) {
+	type fields struct {
+		authData    any
+		articleRepo mockArticleDetailer
+		policy      mockPermissionChecker
+		repo        mockArticleLocker
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		want   int
+	}{
+		{
+			name: "Positive",
+			fields: fields{
+				authData:    mockValidAuthData,
+				articleRepo: mockSuccessArticleDetailer,
+				policy:      mockGrantAllPermissionChecker,
+				repo:        mockArticleLocker{},
+			},
+			want: 200,
+		},
+		{
+			name: "Renew own lock",
+			fields: fields{
+				authData:    mockValidAuthData,
+				articleRepo: mockOwnArticleDetailer,
+				policy:      mockWriterPermissionChecker,
+				repo:        mockOwnArticleLocker,
+			},
+			want: 200,
+		},
+		{
+			name: "Failed to read authData",
+			fields: fields{
+				authData:    "invalid",
+				articleRepo: mockSuccessArticleDetailer,
+				policy:      mockGrantAllPermissionChecker,
+				repo:        mockArticleLocker{},
+			},
+			want: 400,
+		},
+		{
+			name: "Failed to get data",
+			fields: fields{
+				authData:    mockValidAuthData,
+				articleRepo: mockFailedArticleDetailer,
+				policy:      mockGrantAllPermissionChecker,
+				repo:        mockArticleLocker{},
+			},
+			want: 404,
+		},
+		{
+			name: "Writer locks article of another writer",
+			fields: fields{
+				authData:    mockValidAuthData,
+				articleRepo: mockSuccessArticleDetailer,
+				policy:      mockWriterPermissionChecker,
+				repo:        mockArticleLocker{},
+			},
+			want: 403,
+		},
+		{
+			name: "Locked by another user",
+			fields: fields{
+				authData:    mockValidAuthData,
+				articleRepo: mockSuccessArticleDetailer,
+				policy:      mockGrantAllPermissionChecker,
+				repo:        mockOtherArticleLocker,
+			},
+			want: 423,
+		},
+		{
+			name: "Failed to save data",
+			fields: fields{
+				authData:    mockValidAuthData,
+				articleRepo: mockSuccessArticleDetailer,
+				policy:      mockGrantAllPermissionChecker,
+				repo:        mockArticleLocker{ae: errors.New("error")},
+			},
+			want: 500,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := NewLockArticleServices(tt.fields.authData, tt.fields.articleRepo, tt.fields.policy, tt.fields.repo, time.Minute)
+			got, _ := svc.Lock("abc-123")
+			if got != tt.want {
+				t.Errorf("LockArticleServices.Lock() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUnlockArticleServices_Unlock(t *testing.T) {
+	type fields struct {
+		authData    any
+		articleRepo mockArticleDetailer
+		policy      mockPermissionChecker
+		repo        mockArticleLocker
+	}
+	type args struct {
+		force bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		want   int
+	}{
+		{
+			name: "Positive",
+			fields: fields{
+				authData:    mockValidAuthData,
+				articleRepo: mockSuccessArticleDetailer,
+				policy:      mockWriterPermissionChecker,
+				repo:        mockOwnArticleLocker,
+			},
+			want: 200,
+		},
+		{
+			name: "Failed to read authData",
+			fields: fields{
+				authData:    "invalid",
+				articleRepo: mockSuccessArticleDetailer,
+				policy:      mockGrantAllPermissionChecker,
+				repo:        mockOwnArticleLocker,
+			},
+			want: 400,
+		},
+		{
+			name: "Failed to get data",
+			fields: fields{
+				authData:    mockValidAuthData,
+				articleRepo: mockFailedArticleDetailer,
+				policy:      mockGrantAllPermissionChecker,
+				repo:        mockOwnArticleLocker,
+			},
+			want: 404,
+		},
+		{
+			name: "Not locked",
+			fields: fields{
+				authData:    mockValidAuthData,
+				articleRepo: mockSuccessArticleDetailer,
+				policy:      mockGrantAllPermissionChecker,
+				repo:        mockArticleLocker{},
+			},
+			want: 404,
+		},
+		{
+			name: "Failed to get lock",
+			fields: fields{
+				authData:    mockValidAuthData,
+				articleRepo: mockSuccessArticleDetailer,
+				policy:      mockGrantAllPermissionChecker,
+				repo:        mockFailedArticleLocker,
+			},
+			want: 500,
+		},
+		{
+			name: "Locked by another user",
+			fields: fields{
+				authData:    mockValidAuthData,
+				articleRepo: mockSuccessArticleDetailer,
+				policy:      mockGrantAllPermissionChecker,
+				repo:        mockOtherArticleLocker,
+			},
+			want: 423,
+		},
+		{
+			name: "Force unlock",
+			fields: fields{
+				authData:    mockValidAuthData,
+				articleRepo: mockSuccessArticleDetailer,
+				policy:      mockGrantAllPermissionChecker,
+				repo:        mockOtherArticleLocker,
+			},
+			args: args{
+				force: true,
+			},
+			want: 200,
+		},
+		{
+			name: "Force unlock without permission",
+			fields: fields{
+				authData:    mockValidAuthData,
+				articleRepo: mockSuccessArticleDetailer,
+				policy:      mockWriterPermissionChecker,
+				repo:        mockOtherArticleLocker,
+			},
+			args: args{
+				force: true,
+			},
+			want: 403,
+		},
+		{
+			name: "Released in between",
+			fields: fields{
+				authData:    mockValidAuthData,
+				articleRepo: mockSuccessArticleDetailer,
+				policy:      mockGrantAllPermissionChecker,
+				repo:        mockArticleLocker{d: mockOwnArticleLocker.d, re: models.ErrArticleNotLocked},
+			},
+			want: 404,
+		},
+		{
+			name: "Failed to delete data",
+			fields: fields{
+				authData:    mockValidAuthData,
+				articleRepo: mockSuccessArticleDetailer,
+				policy:      mockGrantAllPermissionChecker,
+				repo:        mockArticleLocker{d: mockOwnArticleLocker.d, re: errors.New("error")},
+			},
+			want: 500,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := NewUnlockArticleServices(tt.fields.authData, tt.fields.articleRepo, tt.fields.policy, tt.fields.repo)
+			got, _ := svc.Unlock("abc-123", tt.args.force)
+			if got != tt.want {
+				t.Errorf("UnlockArticleServices.Unlock() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
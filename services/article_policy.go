@@ -0,0 +1,71 @@
+package services
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/herdiansc/go-cms/models"
+)
+
+// Article actions checked by ArticlePolicy
+const (
+	ArticleActionRead     = "read"
+	ArticleActionUpdate   = "update"
+	ArticleActionDelete   = "delete"
+	ArticleActionSchedule = "schedule"
+	// ArticleActionChangeStatus guards status changes made outside the workflow transitions
+	ArticleActionChangeStatus = "change the status of"
+)
+
+// articleActionPermissions maps an action to the permission allowing it on own and on any article.
+// Workflow transitions are guarded by their name.
+var articleActionPermissions = map[string][2]string{
+	ArticleActionRead:         {models.PermissionArticleRead, models.PermissionArticleRead},
+	ArticleActionUpdate:       {models.PermissionArticleUpdateOwn, models.PermissionArticleUpdateAny},
+	ArticleActionDelete:       {models.PermissionArticleDeleteOwn, models.PermissionArticleDeleteAny},
+	ArticleActionSchedule:     {models.PermissionArticlePublish, models.PermissionArticlePublish},
+	ArticleActionChangeStatus: {models.PermissionArticlePublish, models.PermissionArticlePublish},
+
+	models.ArticleTransitionSubmit:  {models.PermissionArticleUpdateOwn, models.PermissionArticleUpdateAny},
+	models.ArticleTransitionApprove: {models.PermissionArticleReview, models.PermissionArticleReview},
+	models.ArticleTransitionReject:  {models.PermissionArticleReview, models.PermissionArticleReview},
+	models.ArticleTransitionPublish: {models.PermissionArticlePublish, models.PermissionArticlePublish},
+	models.ArticleTransitionArchive: {models.PermissionArticlePublish, models.PermissionArticlePublish},
+}
+
+// ArticlePolicy decides whether a user may act on an article. Every per-article sub-resource
+// (histories, transitions, ...) is checked against its parent article through this policy.
+type ArticlePolicy struct {
+	checker PermissionChecker
+}
+
+// NewArticlePolicy inits ArticlePolicy
+func NewArticlePolicy(pc PermissionChecker) ArticlePolicy {
+	return ArticlePolicy{
+		checker: pc,
+	}
+}
+
+// Allows reports whether authData may perform action on article: the "any" permission covers every
+// article while the "own" permission only covers articles written by the user
+func (p ArticlePolicy) Allows(authData models.VerifyData, article models.Article, action string) bool {
+	permissions, ok := articleActionPermissions[action]
+	if !ok {
+		return false
+	}
+	if can(p.checker, authData, permissions[1]) {
+		return true
+	}
+	return article.WriterID == authData.ID && can(p.checker, authData, permissions[0])
+}
+
+// Authorize returns 200 when action is allowed and a 403 response otherwise
+func (p ArticlePolicy) Authorize(authData models.VerifyData, article models.Article, action string) (int, models.Response) {
+	if !p.Allows(authData, article, action) {
+		return http.StatusForbidden, models.Response{
+			Message: "Forbidden",
+			Data:    fmt.Sprintf("you are not allowed to %s this article", action),
+		}
+	}
+	return http.StatusOK, models.Response{Message: "ok", Data: nil}
+}
@@ -0,0 +1,101 @@
+package services
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/herdiansc/go-cms/models"
+)
+
+// Limits of related articles
+const (
+	defaultRelatedLimit = 10
+	maxRelatedLimit     = 50
+)
+
+// ArticleRelatedLister defines related article lister function
+type ArticleRelatedLister interface {
+	List(articleID int64, filter models.RelatedArticlesFilter) ([]models.Article, error)
+}
+
+// RelatedArticleServices defines related article service struct
+type RelatedArticleServices struct {
+	authData    any
+	articleRepo ArticleDetailer
+	policy      ArticlePolicy
+	repo        ArticleRelatedLister
+}
+
+// NewRelatedArticleServices inits RelatedArticleServices
+func NewRelatedArticleServices(ad any, ar ArticleDetailer, pc PermissionChecker, rl ArticleRelatedLister) RelatedArticleServices {
+	return RelatedArticleServices{
+		authData:    ad,
+		articleRepo: ar,
+		policy:      NewArticlePolicy(pc),
+		repo:        rl,
+	}
+}
+
+// List performs action of listing the articles most related to an article by their tags. Only PUBLISHED
+// articles are listed unless the status param names another status, exclude_same_writer=true leaves out
+// articles of the same writer.
+func (svc RelatedArticleServices) List(articleUUID string, q url.Values) (int, models.Response) {
+	authData, ok := svc.authData.(models.VerifyData)
+	if !ok {
+		log.Printf("Failed to read authData\n")
+		return http.StatusBadRequest, models.Response{Message: "error", Data: nil}
+	}
+
+	filter := models.RelatedArticlesFilter{Status: models.ArticleStatusPublished, Limit: defaultRelatedLimit}
+	if status := q.Get("status"); status != "" {
+		if !slices.Contains(models.ArticleStatuses, status) {
+			log.Printf("Unknown status: %s\n", status)
+			return http.StatusBadRequest, models.Response{Message: "Bad Request", Data: fmt.Sprintf("status must be one of %s", strings.Join(models.ArticleStatuses, ", "))}
+		}
+		filter.Status = status
+	}
+	excludeSameWriter := false
+	if raw := q.Get("exclude_same_writer"); raw != "" {
+		v, err := strconv.ParseBool(raw)
+		if err != nil {
+			log.Printf("Invalid exclude_same_writer: %s\n", raw)
+			return http.StatusBadRequest, models.Response{Message: "Bad Request", Data: "exclude_same_writer must be true or false"}
+		}
+		excludeSameWriter = v
+	}
+	if raw := q.Get("limit"); raw != "" {
+		v, err := strconv.Atoi(raw)
+		if err != nil || v < 1 || v > maxRelatedLimit {
+			log.Printf("Invalid related limit: %s\n", raw)
+			return http.StatusBadRequest, models.Response{Message: "Bad Request", Data: fmt.Sprintf("limit must be between 1 and %d", maxRelatedLimit)}
+		}
+		filter.Limit = v
+	}
+
+	article, err := svc.articleRepo.FindByParam("uuid", articleUUID)
+	if err != nil {
+		log.Printf("Failed to get data: %+v\n", err.Error())
+		return http.StatusNotFound, models.Response{Message: "not found", Data: err.Error()}
+	}
+
+	if code, res := svc.policy.Authorize(authData, article, ArticleActionRead); code != http.StatusOK {
+		log.Printf("Failed to authorize: %+v\n", res.Data)
+		return code, res
+	}
+	if excludeSameWriter {
+		filter.ExcludeWriterID = article.WriterID
+	}
+
+	data, err := svc.repo.List(article.ID, filter)
+	if err != nil {
+		log.Printf("Failed to get data: %+v\n", err.Error())
+		return http.StatusInternalServerError, models.Response{Message: "Failed to get data", Data: err.Error()}
+	}
+
+	return http.StatusOK, models.Response{Message: "ok", Data: data}
+}
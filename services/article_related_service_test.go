@@ -0,0 +1,175 @@
+package services
+
+import (
+	"errors"
+	"net/url"
+	"testing"
+
+	"github.com/herdiansc/go-cms/models"
+)
+
+type mockArticleRelatedLister struct {
+	d      []models.Article
+	e      error
+	filter *models.RelatedArticlesFilter
+}
+
+func (m mockArticleRelatedLister) List(articleID int64, filter models.RelatedArticlesFilter) ([]models.Article, error) {
+	if m.filter != nil {
+		*m.filter = filter
+	}
+	return m.d, m.e
+}
+
+var (
+	mockSuccessArticleRelatedLister = mockArticleRelatedLister{
+		d: []models.Article{{Title: "a", TagRelationshipScore: 0.5}},
+		e: nil,
+	}
+	mockFailedArticleRelatedLister = mockArticleRelatedLister{
+		d: nil,
+		e: errors.New("error"),
+	}
+)
+
+func TestRelatedArticleServices_List(t *testing.T) {
+	type fields struct {
+		authData    any
+		articleRepo mockArticleDetailer
+		policy      mockPermissionChecker
+		repo        mockArticleRelatedLister
+	}
+	tests := []struct {
+		name       string
+		fields     fields
+		q          url.Values
+		want       int
+		wantFilter models.RelatedArticlesFilter
+	}{
+		{
+			name: "Positive",
+			fields: fields{
+				authData:    mockValidAuthData,
+				articleRepo: mockOwnArticleDetailer,
+				policy:      mockGrantAllPermissionChecker,
+				repo:        mockSuccessArticleRelatedLister,
+			},
+			q:    url.Values{"status": {"ARCHIVED"}, "exclude_same_writer": {"true"}, "limit": {"5"}},
+			want: 200,
+			wantFilter: models.RelatedArticlesFilter{
+				Status:          models.ArticleStatusArchived,
+				ExcludeWriterID: mockValidAuthData.ID,
+				Limit:           5,
+			},
+		},
+		{
+			name: "Defaults",
+			fields: fields{
+				authData:    mockValidAuthData,
+				articleRepo: mockOwnArticleDetailer,
+				policy:      mockGrantAllPermissionChecker,
+				repo:        mockSuccessArticleRelatedLister,
+			},
+			q:          url.Values{},
+			want:       200,
+			wantFilter: models.RelatedArticlesFilter{Status: models.ArticleStatusPublished, Limit: 10},
+		},
+		{
+			name: "Failed to read authData",
+			fields: fields{
+				authData:    "invalid",
+				articleRepo: mockSuccessArticleDetailer,
+				policy:      mockGrantAllPermissionChecker,
+				repo:        mockSuccessArticleRelatedLister,
+			},
+			q:    url.Values{},
+			want: 400,
+		},
+		{
+			name: "Unknown status",
+			fields: fields{
+				authData:    mockValidAuthData,
+				articleRepo: mockSuccessArticleDetailer,
+				policy:      mockGrantAllPermissionChecker,
+				repo:        mockSuccessArticleRelatedLister,
+			},
+			q:    url.Values{"status": {"GONE"}},
+			want: 400,
+		},
+		{
+			name: "Invalid exclude_same_writer",
+			fields: fields{
+				authData:    mockValidAuthData,
+				articleRepo: mockSuccessArticleDetailer,
+				policy:      mockGrantAllPermissionChecker,
+				repo:        mockSuccessArticleRelatedLister,
+			},
+			q:    url.Values{"exclude_same_writer": {"maybe"}},
+			want: 400,
+		},
+		{
+			name: "Limit too big",
+			fields: fields{
+				authData:    mockValidAuthData,
+				articleRepo: mockSuccessArticleDetailer,
+				policy:      mockGrantAllPermissionChecker,
+				repo:        mockSuccessArticleRelatedLister,
+			},
+			q:    url.Values{"limit": {"51"}},
+			want: 400,
+		},
+		{
+			name: "Failed to get article data",
+			fields: fields{
+				authData:    mockValidAuthData,
+				articleRepo: mockFailedArticleDetailer,
+				policy:      mockGrantAllPermissionChecker,
+				repo:        mockSuccessArticleRelatedLister,
+			},
+			q:    url.Values{},
+			want: 404,
+		},
+		{
+			name: "Not allowed to read article",
+			fields: fields{
+				authData:    mockValidAuthData,
+				articleRepo: mockSuccessArticleDetailer,
+				policy:      mockDenyAllPermissionChecker,
+				repo:        mockSuccessArticleRelatedLister,
+			},
+			q:    url.Values{},
+			want: 403,
+		},
+		{
+			name: "Failed to get related data",
+			fields: fields{
+				authData:    mockValidAuthData,
+				articleRepo: mockSuccessArticleDetailer,
+				policy:      mockGrantAllPermissionChecker,
+				repo:        mockFailedArticleRelatedLister,
+			},
+			q:    url.Values{},
+			want: 500,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var filter models.RelatedArticlesFilter
+			tt.fields.repo.filter = &filter
+
+			svc := NewRelatedArticleServices(
+				tt.fields.authData,
+				tt.fields.articleRepo,
+				tt.fields.policy,
+				tt.fields.repo,
+			)
+			got, _ := svc.List("abc-123", tt.q)
+			if got != tt.want {
+				t.Errorf("RelatedArticleServices.List() got = %v, want %v", got, tt.want)
+			}
+			if got == 200 && filter != tt.wantFilter {
+				t.Errorf("RelatedArticleServices.List() filter = %+v, want %+v", filter, tt.wantFilter)
+			}
+		})
+	}
+}
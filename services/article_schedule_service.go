@@ -0,0 +1,86 @@
+package services
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/herdiansc/go-cms/models"
+)
+
+// ArticleScheduler defines article scheduler function
+type ArticleScheduler interface {
+	Schedule(id int64, actor models.Actor, schedule models.ScheduleArticleRequest) (models.Article, error)
+}
+
+// ScheduleArticleServices defines schedule article service struct
+type ScheduleArticleServices struct {
+	authData    any
+	decoder     JsonDecoder
+	articleRepo ArticleDetailer
+	policy      ArticlePolicy
+	repo        ArticleScheduler
+	locks       ArticleLockFinder
+}
+
+// NewScheduleArticleServices inits ScheduleArticleServices
+func NewScheduleArticleServices(ad any, jd JsonDecoder, ar ArticleDetailer, pc PermissionChecker, as ArticleScheduler, al ArticleLockFinder) ScheduleArticleServices {
+	return ScheduleArticleServices{
+		authData:    ad,
+		decoder:     jd,
+		articleRepo: ar,
+		policy:      NewArticlePolicy(pc),
+		repo:        as,
+		locks:       al,
+	}
+}
+
+// Schedule sets when an article is published and unpublished by the scheduler
+func (svc ScheduleArticleServices) Schedule(uuid string) (int, models.Response) {
+	authData, ok := svc.authData.(models.VerifyData)
+	if !ok {
+		log.Printf("Failed to read authData\n")
+		return http.StatusBadRequest, models.Response{Message: "error", Data: nil}
+	}
+
+	var data models.ScheduleArticleRequest
+	err := svc.decoder.Decode(&data)
+	if err != nil {
+		log.Printf("Failed to decode json data: %+v\n", err.Error())
+		return http.StatusBadRequest, models.Response{Message: "Bad Request", Data: err.Error()}
+	}
+	if data.PublishAt != nil && data.UnpublishAt != nil && !data.UnpublishAt.After(*data.PublishAt) {
+		log.Printf("Failed to validate data: unpublish_at is not after publish_at\n")
+		return http.StatusBadRequest, models.Response{Message: "Bad Request", Data: "unpublish_at must be after publish_at"}
+	}
+
+	article, err := svc.articleRepo.FindByParam("uuid", uuid)
+	if err != nil {
+		log.Printf("Failed to get data: %+v\n", err.Error())
+		return http.StatusNotFound, models.Response{Message: "not found", Data: err.Error()}
+	}
+
+	if code, res := svc.policy.Authorize(authData, article, ArticleActionSchedule); code != http.StatusOK {
+		log.Printf("Failed to authorize: %+v\n", res.Data)
+		return code, res
+	}
+	if code, res := checkArticleLock(svc.locks, authData, article); code != http.StatusOK {
+		return code, res
+	}
+
+	if article.Status == models.ArticleStatusArchived {
+		log.Printf("Failed to schedule archived article\n")
+		return http.StatusConflict, models.Response{Message: "Conflict", Data: "an archived article cannot be scheduled"}
+	}
+
+	scheduled, err := svc.repo.Schedule(article.ID, authData.Actor(), data)
+	if errors.Is(err, models.ErrArticleLocked) {
+		return articleLockedMeanwhile(svc.locks, article)
+	}
+	if err != nil {
+		log.Printf("Failed to save data: %+v\n", err.Error())
+		return http.StatusInternalServerError, models.Response{Message: "Failed to save data", Data: err.Error()}
+	}
+
+	return http.StatusOK, models.Response{Message: "ok", Data: scheduled}
+}
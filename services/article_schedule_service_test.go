@@ -0,0 +1,197 @@
+package services
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/herdiansc/go-cms/models"
+)
+
+type mockArticleScheduler struct {
+	d models.Article
+	e error
+}
+
+func (m mockArticleScheduler) Schedule(id int64, actor models.Actor, schedule models.ScheduleArticleRequest) (models.Article, error) {
+	return m.d, m.e
+}
+
+var (
+	mockSuccessArticleScheduler = mockArticleScheduler{
+		d: models.Article{},
+		e: nil,
+	}
+	mockFailedArticleScheduler = mockArticleScheduler{
+		d: models.Article{},
+		e: errors.New("error"),
+	}
+	mockLockedArticleScheduler = mockArticleScheduler{
+		d: models.Article{},
+		e: models.ErrArticleLocked,
+	}
+	mockArchivedArticleDetailer = mockArticleDetailer{
+		d: models.Article{Status: models.ArticleStatusArchived},
+		e: nil,
+	}
+	mockScheduleArticleDecoder = mockBodyDecoder{
+		body: `{"publish_at":"2026-01-01T08:00:00Z","unpublish_at":"2026-02-01T08:00:00Z"}`,
+	}
+	mockInvalidScheduleArticleDecoder = mockBodyDecoder{
+		body: `{"publish_at":"2026-02-01T08:00:00Z","unpublish_at":"2026-01-01T08:00:00Z"}`,
+	}
+)
+
+func TestScheduleArticleServices_Schedule(t *testing.T) {
+	type fields struct {
+		authData    any
+		decoder     JsonDecoder
+		articleRepo mockArticleDetailer
+		policy      mockPermissionChecker
+		repo        mockArticleScheduler
+		locks       mockArticleLocker
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		want   int
+	}{
+		{
+			name: "Positive",
+			fields: fields{
+				authData:    mockValidAuthData,
+				decoder:     mockScheduleArticleDecoder,
+				articleRepo: mockSuccessArticleDetailer,
+				policy:      mockGrantAllPermissionChecker,
+				repo:        mockSuccessArticleScheduler,
+			},
+			want: 200,
+		},
+		{
+			name: "Clear schedule",
+			fields: fields{
+				authData:    mockValidAuthData,
+				decoder:     mockBodyDecoder{body: `{}`},
+				articleRepo: mockSuccessArticleDetailer,
+				policy:      mockGrantAllPermissionChecker,
+				repo:        mockSuccessArticleScheduler,
+			},
+			want: 200,
+		},
+		{
+			name: "Failed to read authData",
+			fields: fields{
+				authData:    "invalid",
+				decoder:     mockScheduleArticleDecoder,
+				articleRepo: mockSuccessArticleDetailer,
+				policy:      mockGrantAllPermissionChecker,
+				repo:        mockSuccessArticleScheduler,
+			},
+			want: 400,
+		},
+		{
+			name: "Failed to decode json data",
+			fields: fields{
+				authData:    mockValidAuthData,
+				decoder:     mockFailedJsonDecoder,
+				articleRepo: mockSuccessArticleDetailer,
+				policy:      mockGrantAllPermissionChecker,
+				repo:        mockSuccessArticleScheduler,
+			},
+			want: 400,
+		},
+		{
+			name: "Unpublish before publish",
+			fields: fields{
+				authData:    mockValidAuthData,
+				decoder:     mockInvalidScheduleArticleDecoder,
+				articleRepo: mockSuccessArticleDetailer,
+				policy:      mockGrantAllPermissionChecker,
+				repo:        mockSuccessArticleScheduler,
+			},
+			want: 400,
+		},
+		{
+			name: "Failed to get data",
+			fields: fields{
+				authData:    mockValidAuthData,
+				decoder:     mockScheduleArticleDecoder,
+				articleRepo: mockFailedArticleDetailer,
+				policy:      mockGrantAllPermissionChecker,
+				repo:        mockSuccessArticleScheduler,
+			},
+			want: 404,
+		},
+		{
+			name: "Writer schedules own article",
+			fields: fields{
+				authData:    mockValidAuthData,
+				decoder:     mockScheduleArticleDecoder,
+				articleRepo: mockOwnArticleDetailer,
+				policy:      mockWriterPermissionChecker,
+				repo:        mockSuccessArticleScheduler,
+			},
+			want: 403,
+		},
+		{
+			name: "Archived article",
+			fields: fields{
+				authData:    mockValidAuthData,
+				decoder:     mockScheduleArticleDecoder,
+				articleRepo: mockArchivedArticleDetailer,
+				policy:      mockGrantAllPermissionChecker,
+				repo:        mockSuccessArticleScheduler,
+			},
+			want: 409,
+		},
+		{
+			name: "Locked by another user",
+			fields: fields{
+				authData:    mockValidAuthData,
+				decoder:     mockScheduleArticleDecoder,
+				articleRepo: mockSuccessArticleDetailer,
+				policy:      mockGrantAllPermissionChecker,
+				repo:        mockSuccessArticleScheduler,
+				locks:       mockOtherArticleLocker,
+			},
+			want: 423,
+		},
+		{
+			name: "Failed to save data",
+			fields: fields{
+				authData:    mockValidAuthData,
+				decoder:     mockScheduleArticleDecoder,
+				articleRepo: mockSuccessArticleDetailer,
+				policy:      mockGrantAllPermissionChecker,
+				repo:        mockFailedArticleScheduler,
+			},
+			want: 500,
+		},
+		{
+			name: "Locked while saving",
+			fields: fields{
+				authData:    mockValidAuthData,
+				decoder:     mockScheduleArticleDecoder,
+				articleRepo: mockSuccessArticleDetailer,
+				policy:      mockGrantAllPermissionChecker,
+				repo:        mockLockedArticleScheduler,
+			},
+			want: 423,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := NewScheduleArticleServices(
+				tt.fields.authData,
+				tt.fields.decoder,
+				tt.fields.articleRepo,
+				tt.fields.policy,
+				tt.fields.repo,
+				tt.fields.locks,
+			)
+			got, _ := svc.Schedule("abc-123")
+			if got != tt.want {
+				t.Errorf("ScheduleArticleServices.Schedule() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,44 +1,47 @@
 package services
 
 import (
+	"errors"
 	"log"
 	"net/http"
 	"net/url"
+	"strings"
 
 	"github.com/herdiansc/go-cms/models"
 )
 
 // ArticleProcessor defines article creator function
 type ArticleProcessor interface {
-	Create(writerID int64, data models.CreateArticleRequest) (models.Article, error)
+	Create(actor models.Actor, data models.CreateArticleRequest) (models.Article, error)
 }
 
-// ArticleHistoryCreator defines article history creator function
-type ArticleHistoryCreator interface {
-	Create(action string, data models.Article) error
+// TagSimilarityFinder defines similar tag finder function
+type TagSimilarityFinder interface {
+	Similar(titles []string) ([]models.TagWarning, error)
 }
 
 // CreateArticleServices defines article service struct
 type CreateArticleServices struct {
-	authData    any
-	decoder     JsonDecoder
-	validator   RequestValidator
-	repo        ArticleProcessor
-	historyRepo ArticleHistoryCreator
+	authData  any
+	decoder   JsonDecoder
+	validator RequestValidator
+	repo      ArticleProcessor
+	tags      TagSimilarityFinder
 }
 
 // NewCreateArticleServices inits CreateArticleServices
-func NewCreateArticleServices(ad any, jd JsonDecoder, rv RequestValidator, ac ArticleProcessor, hr ArticleHistoryCreator) CreateArticleServices {
+func NewCreateArticleServices(ad any, jd JsonDecoder, rv RequestValidator, ac ArticleProcessor, ts TagSimilarityFinder) CreateArticleServices {
 	return CreateArticleServices{
-		authData:    ad,
-		decoder:     jd,
-		validator:   rv,
-		repo:        ac,
-		historyRepo: hr,
+		authData:  ad,
+		decoder:   jd,
+		validator: rv,
+		repo:      ac,
+		tags:      ts,
 	}
 }
 
-// Create performs action of creating article
+// Create performs action of creating article. New tags looking like existing ones are still created,
+// the response warns about them.
 func (svc CreateArticleServices) Create() (int, models.Response) {
 	authData, ok := svc.authData.(models.VerifyData)
 	if !ok {
@@ -59,20 +62,23 @@ func (svc CreateArticleServices) Create() (int, models.Response) {
 		return http.StatusBadRequest, models.Response{Message: "Bad Request", Data: err.Error()}
 	}
 
-	article, err := svc.repo.Create(authData.ID, data)
+	warnings, err := svc.tags.Similar(data.Tags)
+	if err != nil {
+		log.Printf("Failed to find similar tags: %+v\n", err.Error())
+	}
+
+	article, err := svc.repo.Create(authData.Actor(), data)
 	if err != nil {
 		log.Printf("Failed to save data: %+v\n", err.Error())
 		return http.StatusInternalServerError, models.Response{Message: "Failed to save data", Data: err.Error()}
 	}
 
-	go svc.historyRepo.Create("create", article)
-
-	return http.StatusOK, models.Response{Message: "ok", Data: nil}
+	return http.StatusOK, models.Response{Message: "ok", Data: article, Warnings: warnings}
 }
 
 // ArticleLister defines article lister function
 type ArticleLister interface {
-	List(params map[string]interface{}) ([]models.Article, error)
+	List(q url.Values) ([]models.Article, models.PageMeta, error)
 }
 
 // ListArticleServices defines list article service struct
@@ -89,7 +95,7 @@ func NewListArticleServices(ad any, al ArticleLister) ListArticleServices {
 	}
 }
 
-// List performs action of listing articles
+// List performs action of listing a page of articles, an empty page is not an error
 func (svc ListArticleServices) List(q url.Values) (int, models.Response) {
 	_, ok := svc.authData.(models.VerifyData)
 	if !ok {
@@ -97,17 +103,51 @@ func (svc ListArticleServices) List(q url.Values) (int, models.Response) {
 		return http.StatusBadRequest, models.Response{Message: "error", Data: nil}
 	}
 
-	params := make(map[string]interface{})
-	for k, v := range q {
-		params[k] = v[0]
+	data, meta, err := svc.repo.List(q)
+	if err != nil {
+		return listErrorResponse(err)
 	}
-	data, _ := svc.repo.List(params)
-	if len(data) == 0 {
-		log.Printf("Failed to get data")
-		return http.StatusNotFound, models.Response{Message: "Not found", Data: nil}
+	return http.StatusOK, models.Response{Message: "ok", Data: data, Meta: &meta}
+}
+
+// ArticleSearcher defines article searcher function
+type ArticleSearcher interface {
+	Search(q url.Values) ([]models.ArticleSearchResult, models.PageMeta, error)
+}
+
+// SearchArticleServices defines search article service struct
+type SearchArticleServices struct {
+	authData any
+	repo     ArticleSearcher
+}
+
+// NewSearchArticleServices inits SearchArticleServices
+func NewSearchArticleServices(ad any, as ArticleSearcher) SearchArticleServices {
+	return SearchArticleServices{
+		authData: ad,
+		repo:     as,
 	}
+}
 
-	return http.StatusOK, models.Response{Message: "ok", Data: data}
+// Search performs full-text search of articles, the q param is the search query and the others
+// paginate and filter like List
+func (svc SearchArticleServices) Search(q url.Values) (int, models.Response) {
+	_, ok := svc.authData.(models.VerifyData)
+	if !ok {
+		log.Printf("Failed to read authData\n")
+		return http.StatusBadRequest, models.Response{Message: "error", Data: nil}
+	}
+
+	if strings.TrimSpace(q.Get("q")) == "" {
+		log.Printf("Empty search query\n")
+		return http.StatusBadRequest, models.Response{Message: "Bad Request", Data: "q is required"}
+	}
+
+	data, meta, err := svc.repo.Search(q)
+	if err != nil {
+		return listErrorResponse(err)
+	}
+	return http.StatusOK, models.Response{Message: "ok", Data: data, Meta: &meta}
 }
 
 // ArticleDetailer defines article detailer function
@@ -129,51 +169,142 @@ func NewDetailArticleServices(ad any, al ArticleDetailer) DetailArticleServices
 	}
 }
 
-// DetailArticleServices gets detail of an article by id
-func (svc DetailArticleServices) GetDetailByUUID(id int64) (int, models.Response) {
+// GetDetailByUUID gets detail of an article by uuid
+func (svc DetailArticleServices) GetDetailByUUID(uuid string) (int, models.Response) {
+	_, ok := svc.authData.(models.VerifyData)
+	if !ok {
+		log.Printf("Failed to read authData\n")
+		return http.StatusBadRequest, models.Response{Message: "error", Data: nil}
+	}
+
+	data, err := svc.repo.FindByParam("uuid", uuid)
+	if err != nil {
+		log.Printf("Failed to get data: %+v\n", err.Error())
+		return http.StatusNotFound, models.Response{Message: "not found", Data: err.Error()}
+	}
+
+	return http.StatusOK, models.Response{Message: "ok", Data: data}
+}
+
+// ArticleSlugFinder defines article finder by slug function
+type ArticleSlugFinder interface {
+	FindBySlug(slug string) (models.Article, error)
+}
+
+// DetailArticleBySlugServices defines detail article by slug service struct
+type DetailArticleBySlugServices struct {
+	authData any
+	repo     ArticleSlugFinder
+}
+
+// NewDetailArticleBySlugServices inits DetailArticleBySlugServices
+func NewDetailArticleBySlugServices(ad any, af ArticleSlugFinder) DetailArticleBySlugServices {
+	return DetailArticleBySlugServices{
+		authData: ad,
+		repo:     af,
+	}
+}
+
+// GetDetailBySlug gets detail of an article by slug. A former slug of the article is answered with
+// 301 Moved Permanently and its current slug.
+func (svc DetailArticleBySlugServices) GetDetailBySlug(slug string) (int, models.Response) {
 	_, ok := svc.authData.(models.VerifyData)
 	if !ok {
 		log.Printf("Failed to read authData\n")
 		return http.StatusBadRequest, models.Response{Message: "error", Data: nil}
 	}
 
-	data, err := svc.repo.FindByParam("id", id)
+	data, err := svc.repo.FindBySlug(slug)
 	if err != nil {
 		log.Printf("Failed to get data: %+v\n", err.Error())
 		return http.StatusNotFound, models.Response{Message: "not found", Data: err.Error()}
 	}
+	if data.Slug != slug {
+		return http.StatusMovedPermanently, models.Response{Message: "moved permanently", Data: models.MovedArticle{Slug: data.Slug}}
+	}
 
 	return http.StatusOK, models.Response{Message: "ok", Data: data}
 }
 
 // ArticleDeleter defines article remover function
 type ArticleDeleter interface {
-	DeleteByParam(param string, value any) error
+	Delete(id int64, actor models.Actor, version *int64) (models.Article, error)
+	Purge(id int64, actor models.Actor, version *int64) error
+}
+
+// ArticleTrashDetailer defines functions finding an article, the latter also finds it in the trash
+type ArticleTrashDetailer interface {
+	FindByParam(param string, value any) (models.Article, error)
+	FindByParamWithTrashed(param string, value any) (models.Article, error)
 }
 
 // DeleteArticleServices defines delete article service struct
 type DeleteArticleServices struct {
-	authData any
-	repo     ArticleDeleter
+	authData       any
+	articleRepo    ArticleTrashDetailer
+	checker        PermissionChecker
+	policy         ArticlePolicy
+	repo           ArticleDeleter
+	locks          ArticleLockFinder
+	requireIfMatch bool
 }
 
-// NewDeleteArticleServices inits DeleteArticleServices
-func NewDeleteArticleServices(ad any, ade ArticleDeleter) DeleteArticleServices {
+// NewDeleteArticleServices inits DeleteArticleServices, requireIfMatch refuses deletes without If-Match
+func NewDeleteArticleServices(ad any, ar ArticleTrashDetailer, pc PermissionChecker, ade ArticleDeleter, al ArticleLockFinder, requireIfMatch bool) DeleteArticleServices {
 	return DeleteArticleServices{
-		authData: ad,
-		repo:     ade,
+		authData:       ad,
+		articleRepo:    ar,
+		checker:        pc,
+		policy:         NewArticlePolicy(pc),
+		repo:           ade,
+		locks:          al,
+		requireIfMatch: requireIfMatch,
 	}
 }
 
-// Delete gets detail of an article by id
-func (svc DeleteArticleServices) Delete(id int64) (int, models.Response) {
-	_, ok := svc.authData.(models.VerifyData)
+// Delete moves an article to the trash by uuid. With purge, which requires the article:purge permission,
+// the article is deleted permanently instead, whether it is in the trash or not. ifMatch is the ETag of
+// the version the delete is based on. An article locked by another user is refused with 423.
+func (svc DeleteArticleServices) Delete(uuid string, purge bool, ifMatch string) (int, models.Response) {
+	authData, ok := svc.authData.(models.VerifyData)
 	if !ok {
 		log.Printf("Failed to read authData\n")
 		return http.StatusBadRequest, models.Response{Message: "error", Data: nil}
 	}
 
-	err := svc.repo.DeleteByParam("id", id)
+	version, code, res := articlePrecondition(ifMatch, svc.requireIfMatch)
+	if code != http.StatusOK {
+		return code, res
+	}
+
+	if purge {
+		return svc.purge(authData, uuid, version)
+	}
+
+	article, err := svc.articleRepo.FindByParam("uuid", uuid)
+	if err != nil {
+		log.Printf("Failed to get data: %+v\n", err.Error())
+		return http.StatusNotFound, models.Response{Message: "not found", Data: err.Error()}
+	}
+
+	if code, res := svc.policy.Authorize(authData, article, ArticleActionDelete); code != http.StatusOK {
+		log.Printf("Failed to authorize: %+v\n", res.Data)
+		return code, res
+	}
+	if code, res := checkArticleLock(svc.locks, authData, article); code != http.StatusOK {
+		return code, res
+	}
+	if code, res := checkArticleVersion(article, version); code != http.StatusOK {
+		return code, res
+	}
+
+	_, err = svc.repo.Delete(article.ID, authData.Actor(), version)
+	if errors.Is(err, models.ErrArticleLocked) {
+		return articleLockedMeanwhile(svc.locks, article)
+	}
+	if errors.Is(err, models.ErrArticleVersionMismatch) {
+		return articleVersionMismatch(article.UUID)
+	}
 	if err != nil {
 		log.Printf("Failed to delete data: %+v\n", err.Error())
 		return http.StatusNotFound, models.Response{Message: "Failed to delete article", Data: err.Error()}
@@ -182,39 +313,86 @@ func (svc DeleteArticleServices) Delete(id int64) (int, models.Response) {
 	return http.StatusOK, models.Response{Message: "ok"}
 }
 
+// purge permanently deletes an article by uuid
+func (svc DeleteArticleServices) purge(authData models.VerifyData, uuid string, version *int64) (int, models.Response) {
+	if !can(svc.checker, authData, models.PermissionArticlePurge) {
+		log.Printf("Failed to authorize: %s cannot purge articles\n", authData.RoleName)
+		return http.StatusForbidden, models.Response{Message: "Forbidden", Data: "you are not allowed to purge articles"}
+	}
+
+	article, err := svc.articleRepo.FindByParamWithTrashed("uuid", uuid)
+	if err != nil {
+		log.Printf("Failed to get data: %+v\n", err.Error())
+		return http.StatusNotFound, models.Response{Message: "not found", Data: err.Error()}
+	}
+
+	if code, res := checkArticleLock(svc.locks, authData, article); code != http.StatusOK {
+		return code, res
+	}
+	if code, res := checkArticleVersion(article, version); code != http.StatusOK {
+		return code, res
+	}
+
+	err = svc.repo.Purge(article.ID, authData.Actor(), version)
+	if errors.Is(err, models.ErrArticleLocked) {
+		return articleLockedMeanwhile(svc.locks, article)
+	}
+	if errors.Is(err, models.ErrArticleVersionMismatch) {
+		return articleVersionMismatch(article.UUID)
+	}
+	if err != nil {
+		log.Printf("Failed to purge data: %+v\n", err.Error())
+		return http.StatusNotFound, models.Response{Message: "Failed to purge article", Data: err.Error()}
+	}
+
+	return http.StatusOK, models.Response{Message: "ok"}
+}
+
 // ArticlePatcher defines article patcher function
 type ArticlePatcher interface {
-	PatchByParam(id int64, param string, value any) (models.Article, error)
+	Update(id int64, actor models.Actor, action string, patch models.PatchArticleRequest, version *int64) (models.Article, error)
 }
 
 // PatchArticleServices defines patch article service struct
 type PatchArticleServices struct {
-	authData    any
-	decoder     JsonDecoder
-	validator   RequestValidator
-	repo        ArticlePatcher
-	historyRepo ArticleHistoryCreator
+	authData       any
+	decoder        JsonDecoder
+	validator      RequestValidator
+	articleRepo    ArticleDetailer
+	policy         ArticlePolicy
+	repo           ArticlePatcher
+	locks          ArticleLockFinder
+	requireIfMatch bool
 }
 
-// NewPatchArticleServices inits PatchArticleServices
-func NewPatchArticleServices(ad any, jd JsonDecoder, rv RequestValidator, ac ArticlePatcher, hr ArticleHistoryCreator) PatchArticleServices {
+// NewPatchArticleServices inits PatchArticleServices, requireIfMatch refuses changes without If-Match
+func NewPatchArticleServices(ad any, jd JsonDecoder, rv RequestValidator, ar ArticleDetailer, pc PermissionChecker, ac ArticlePatcher, al ArticleLockFinder, requireIfMatch bool) PatchArticleServices {
 	return PatchArticleServices{
-		authData:    ad,
-		decoder:     jd,
-		validator:   rv,
-		repo:        ac,
-		historyRepo: hr,
+		authData:       ad,
+		decoder:        jd,
+		validator:      rv,
+		articleRepo:    ar,
+		policy:         NewArticlePolicy(pc),
+		repo:           ac,
+		locks:          al,
+		requireIfMatch: requireIfMatch,
 	}
 }
 
-// Patch performs action of patching an article
-func (svc PatchArticleServices) Patch(id int64) (int, models.Response) {
-	_, ok := svc.authData.(models.VerifyData)
+// Patch performs action of patching an article, only fields present in the request are changed.
+// ifMatch is the ETag of the version the patch is based on. An article locked by another user is refused with 423.
+func (svc PatchArticleServices) Patch(uuid string, ifMatch string) (int, models.Response) {
+	authData, ok := svc.authData.(models.VerifyData)
 	if !ok {
 		log.Printf("Failed to read authData\n")
 		return http.StatusBadRequest, models.Response{Message: "error", Data: nil}
 	}
 
+	version, code, res := articlePrecondition(ifMatch, svc.requireIfMatch)
+	if code != http.StatusOK {
+		return code, res
+	}
+
 	var data models.PatchArticleRequest
 	err := svc.decoder.Decode(&data)
 	if err != nil {
@@ -227,14 +405,124 @@ func (svc PatchArticleServices) Patch(id int64) (int, models.Response) {
 		log.Printf("Failed to validate data: %+v\n", err.Error())
 		return http.StatusBadRequest, models.Response{Message: "Bad Request", Data: err.Error()}
 	}
+	if data.IsEmpty() {
+		log.Printf("Empty patch\n")
+		return http.StatusBadRequest, models.Response{Message: "Bad Request", Data: "nothing to patch"}
+	}
+
+	current, err := svc.articleRepo.FindByParam("uuid", uuid)
+	if err != nil {
+		log.Printf("Failed to get data: %+v\n", err.Error())
+		return http.StatusNotFound, models.Response{Message: "not found", Data: err.Error()}
+	}
+
+	if code, res := svc.policy.Authorize(authData, current, ArticleActionUpdate); code != http.StatusOK {
+		log.Printf("Failed to authorize: %+v\n", res.Data)
+		return code, res
+	}
+	if code, res := checkArticleLock(svc.locks, authData, current); code != http.StatusOK {
+		return code, res
+	}
+	if code, res := checkArticleVersion(current, version); code != http.StatusOK {
+		return code, res
+	}
 
-	article, err := svc.repo.PatchByParam(id, "status", data.Status)
+	article, err := svc.repo.Update(current.ID, authData.Actor(), models.ArticleHistoryPatch, data, version)
+	if errors.Is(err, models.ErrArticleLocked) {
+		return articleLockedMeanwhile(svc.locks, current)
+	}
+	if errors.Is(err, models.ErrArticleVersionMismatch) {
+		return articleVersionMismatch(current.UUID)
+	}
 	if err != nil {
 		log.Printf("Failed to save data: %+v\n", err.Error())
 		return http.StatusInternalServerError, models.Response{Message: "Failed to save data", Data: err.Error()}
 	}
 
-	go svc.historyRepo.Create("patch", article)
+	return http.StatusOK, models.Response{Message: "ok", Data: article}
+}
+
+// PutArticleServices defines put article service struct
+type PutArticleServices struct {
+	authData       any
+	decoder        JsonDecoder
+	validator      RequestValidator
+	articleRepo    ArticleDetailer
+	policy         ArticlePolicy
+	repo           ArticlePatcher
+	locks          ArticleLockFinder
+	requireIfMatch bool
+}
+
+// NewPutArticleServices inits PutArticleServices, requireIfMatch refuses changes without If-Match
+func NewPutArticleServices(ad any, jd JsonDecoder, rv RequestValidator, ar ArticleDetailer, pc PermissionChecker, ac ArticlePatcher, al ArticleLockFinder, requireIfMatch bool) PutArticleServices {
+	return PutArticleServices{
+		authData:       ad,
+		decoder:        jd,
+		validator:      rv,
+		articleRepo:    ar,
+		policy:         NewArticlePolicy(pc),
+		repo:           ac,
+		locks:          al,
+		requireIfMatch: requireIfMatch,
+	}
+}
+
+// Put performs action of replacing title, content and tags of an article, status is kept.
+// ifMatch is the ETag of the version the change is based on. An article locked by another user is refused with 423.
+func (svc PutArticleServices) Put(uuid string, ifMatch string) (int, models.Response) {
+	authData, ok := svc.authData.(models.VerifyData)
+	if !ok {
+		log.Printf("Failed to read authData\n")
+		return http.StatusBadRequest, models.Response{Message: "error", Data: nil}
+	}
+
+	version, code, res := articlePrecondition(ifMatch, svc.requireIfMatch)
+	if code != http.StatusOK {
+		return code, res
+	}
+
+	var data models.PutArticleRequest
+	err := svc.decoder.Decode(&data)
+	if err != nil {
+		log.Printf("Failed to decode json data: %+v\n", err.Error())
+		return http.StatusBadRequest, models.Response{Message: "Bad Request", Data: err.Error()}
+	}
+
+	err = svc.validator.Struct(data)
+	if err != nil {
+		log.Printf("Failed to validate data: %+v\n", err.Error())
+		return http.StatusBadRequest, models.Response{Message: "Bad Request", Data: err.Error()}
+	}
+
+	current, err := svc.articleRepo.FindByParam("uuid", uuid)
+	if err != nil {
+		log.Printf("Failed to get data: %+v\n", err.Error())
+		return http.StatusNotFound, models.Response{Message: "not found", Data: err.Error()}
+	}
+
+	if code, res := svc.policy.Authorize(authData, current, ArticleActionUpdate); code != http.StatusOK {
+		log.Printf("Failed to authorize: %+v\n", res.Data)
+		return code, res
+	}
+	if code, res := checkArticleLock(svc.locks, authData, current); code != http.StatusOK {
+		return code, res
+	}
+	if code, res := checkArticleVersion(current, version); code != http.StatusOK {
+		return code, res
+	}
+
+	article, err := svc.repo.Update(current.ID, authData.Actor(), models.ArticleHistoryPut, data.Patch(), version)
+	if errors.Is(err, models.ErrArticleLocked) {
+		return articleLockedMeanwhile(svc.locks, current)
+	}
+	if errors.Is(err, models.ErrArticleVersionMismatch) {
+		return articleVersionMismatch(current.UUID)
+	}
+	if err != nil {
+		log.Printf("Failed to save data: %+v\n", err.Error())
+		return http.StatusInternalServerError, models.Response{Message: "Failed to save data", Data: err.Error()}
+	}
 
-	return http.StatusOK, models.Response{Message: "ok", Data: nil}
+	return http.StatusOK, models.Response{Message: "ok", Data: article}
 }
@@ -4,27 +4,26 @@ import (
 	"errors"
 	"net/url"
 	"testing"
+	"time"
 
 	"github.com/herdiansc/go-cms/models"
+	"github.com/herdiansc/go-cms/queryspec"
+	"gorm.io/gorm"
 )
 
 type mockArticleProcessor struct {
-	d models.Article
-	e error
+	d     models.Article
+	e     error
+	actor *models.Actor
 }
 
-func (m mockArticleProcessor) Create(writerID int64, data models.CreateArticleRequest) (models.Article, error) {
+func (m mockArticleProcessor) Create(actor models.Actor, data models.CreateArticleRequest) (models.Article, error) {
+	if m.actor != nil {
+		*m.actor = actor
+	}
 	return m.d, m.e
 }
 
-type mockArticleHistoryCreator struct {
-	e error
-}
-
-func (m mockArticleHistoryCreator) Create(action string, data models.Article) error {
-	return m.e
-}
-
 var (
 	mockSuccessArticleProcessor = mockArticleProcessor{
 		d: models.Article{},
@@ -34,87 +33,127 @@ var (
 		d: models.Article{},
 		e: errors.New("error"),
 	}
-	mockSuccessArticleHistoryCreator = mockArticleHistoryCreator{
-		e: nil,
+)
+
+type mockTagSimilarityFinder struct {
+	d []models.TagWarning
+	e error
+}
+
+func (m mockTagSimilarityFinder) Similar(titles []string) ([]models.TagWarning, error) {
+	return m.d, m.e
+}
+
+var (
+	mockNoTagSimilarityFinder      = mockTagSimilarityFinder{}
+	mockSimilarTagSimilarityFinder = mockTagSimilarityFinder{
+		d: []models.TagWarning{{Tag: "golnag", Similar: []string{"golang"}, Message: "did you mean"}},
+	}
+	mockFailedTagSimilarityFinder = mockTagSimilarityFinder{
+		e: errors.New("error"),
 	}
 )
 
 func TestCreateArticleServices_Create(t *testing.T) {
 	type fields struct {
-		authData    any
-		decoder     mockJsonDecoder
-		validator   mockRequestValidator
-		repo        mockArticleProcessor
-		historyRepo mockArticleHistoryCreator
+		authData  any
+		decoder   mockJsonDecoder
+		validator mockRequestValidator
+		repo      mockArticleProcessor
+		tags      mockTagSimilarityFinder
 	}
 	tests := []struct {
-		name   string
-		fields fields
-		want   int
+		name         string
+		fields       fields
+		want         int
+		wantWarnings int
 	}{
 		{
 			name: "Positive",
 			fields: fields{
-				authData:    mockValidAuthData,
-				decoder:     mockSuccessJsonDecoder,
-				validator:   mockSuccessRequestValidator,
-				repo:        mockSuccessArticleProcessor,
-				historyRepo: mockSuccessArticleHistoryCreator,
+				authData:  mockValidAuthData,
+				decoder:   mockSuccessJsonDecoder,
+				validator: mockSuccessRequestValidator,
+				repo:      mockSuccessArticleProcessor,
+				tags:      mockNoTagSimilarityFinder,
 			},
 			want: 200,
 		},
 		{
 			name: "Failed to read authData",
 			fields: fields{
-				authData:    "invalid",
-				decoder:     mockSuccessJsonDecoder,
-				validator:   mockSuccessRequestValidator,
-				repo:        mockSuccessArticleProcessor,
-				historyRepo: mockSuccessArticleHistoryCreator,
+				authData:  "invalid",
+				decoder:   mockSuccessJsonDecoder,
+				validator: mockSuccessRequestValidator,
+				repo:      mockSuccessArticleProcessor,
+				tags:      mockNoTagSimilarityFinder,
 			},
 			want: 400,
 		},
 		{
 			name: "Failed to decode json data",
 			fields: fields{
-				authData:    mockValidAuthData,
-				decoder:     mockFailedJsonDecoder,
-				validator:   mockSuccessRequestValidator,
-				repo:        mockSuccessArticleProcessor,
-				historyRepo: mockSuccessArticleHistoryCreator,
+				authData:  mockValidAuthData,
+				decoder:   mockFailedJsonDecoder,
+				validator: mockSuccessRequestValidator,
+				repo:      mockSuccessArticleProcessor,
+				tags:      mockNoTagSimilarityFinder,
 			},
 			want: 400,
 		},
 		{
 			name: "Failed to validate data",
 			fields: fields{
-				authData:    mockValidAuthData,
-				decoder:     mockSuccessJsonDecoder,
-				validator:   mockFailedRequestValidator,
-				repo:        mockSuccessArticleProcessor,
-				historyRepo: mockSuccessArticleHistoryCreator,
+				authData:  mockValidAuthData,
+				decoder:   mockSuccessJsonDecoder,
+				validator: mockFailedRequestValidator,
+				repo:      mockSuccessArticleProcessor,
+				tags:      mockNoTagSimilarityFinder,
 			},
 			want: 400,
 		},
 		{
 			name: "Failed to save data",
 			fields: fields{
-				authData:    mockValidAuthData,
-				decoder:     mockSuccessJsonDecoder,
-				validator:   mockSuccessRequestValidator,
-				repo:        mockFailedArticleProcessor,
-				historyRepo: mockSuccessArticleHistoryCreator,
+				authData:  mockValidAuthData,
+				decoder:   mockSuccessJsonDecoder,
+				validator: mockSuccessRequestValidator,
+				repo:      mockFailedArticleProcessor,
+				tags:      mockNoTagSimilarityFinder,
 			},
 			want: 500,
 		},
 		{
 			name: "Positive",
 			fields: fields{
-				authData:    mockValidAuthData,
-				decoder:     mockSuccessJsonDecoder,
-				validator:   mockSuccessRequestValidator,
-				repo:        mockSuccessArticleProcessor,
-				historyRepo: mockSuccessArticleHistoryCreator,
+				authData:  mockValidAuthData,
+				decoder:   mockSuccessJsonDecoder,
+				validator: mockSuccessRequestValidator,
+				repo:      mockSuccessArticleProcessor,
+				tags:      mockNoTagSimilarityFinder,
+			},
+			want: 200,
+		},
+		{
+			name: "Warns about similar tags",
+			fields: fields{
+				authData:  mockValidAuthData,
+				decoder:   mockSuccessJsonDecoder,
+				validator: mockSuccessRequestValidator,
+				repo:      mockSuccessArticleProcessor,
+				tags:      mockSimilarTagSimilarityFinder,
+			},
+			want:         200,
+			wantWarnings: 1,
+		},
+		{
+			name: "Failed to find similar tags",
+			fields: fields{
+				authData:  mockValidAuthData,
+				decoder:   mockSuccessJsonDecoder,
+				validator: mockSuccessRequestValidator,
+				repo:      mockSuccessArticleProcessor,
+				tags:      mockFailedTagSimilarityFinder,
 			},
 			want: 200,
 		},
@@ -126,23 +165,44 @@ func TestCreateArticleServices_Create(t *testing.T) {
 				tt.fields.decoder,
 				tt.fields.validator,
 				tt.fields.repo,
-				tt.fields.historyRepo,
+				tt.fields.tags,
 			)
-			got, _ := svc.Create()
+			got, res := svc.Create()
 			if got != tt.want {
 				t.Errorf("CreateArticleServices.Create() got = %v, want %v", got, tt.want)
 			}
+			if len(res.Warnings) != tt.wantWarnings {
+				t.Errorf("CreateArticleServices.Create() warnings = %v, want %v", len(res.Warnings), tt.wantWarnings)
+			}
+			if _, ok := res.Data.(models.Article); got == 200 && !ok {
+				t.Errorf("CreateArticleServices.Create() data = %T, want models.Article", res.Data)
+			}
 		})
 	}
 }
 
+func TestCreateArticleServices_Create_RecordsActor(t *testing.T) {
+	var actor models.Actor
+	authData := mockValidAuthData
+	authData.RequestID = "req-1"
+	repo := mockArticleProcessor{actor: &actor}
+
+	svc := NewCreateArticleServices(authData, mockSuccessJsonDecoder, mockSuccessRequestValidator, repo, mockNoTagSimilarityFinder)
+	svc.Create()
+
+	want := models.Actor{ID: mockValidAuthData.ID, RequestID: "req-1"}
+	if actor != want {
+		t.Errorf("CreateArticleServices.Create() actor = %+v, want %+v", actor, want)
+	}
+}
+
 type mockArticleLister struct {
 	d []models.Article
 	e error
 }
 
-func (m mockArticleLister) List(params map[string]interface{}) ([]models.Article, error) {
-	return m.d, m.e
+func (m mockArticleLister) List(q url.Values) ([]models.Article, models.PageMeta, error) {
+	return m.d, models.PageMeta{Limit: 10}, m.e
 }
 
 var (
@@ -163,6 +223,14 @@ var (
 		d: []models.Article{},
 		e: nil,
 	}
+	mockInvalidQueryArticleLister = mockArticleLister{
+		d: nil,
+		e: queryspec.Error{Param: "a", Reason: "unknown filter"},
+	}
+	mockFailedArticleLister = mockArticleLister{
+		d: nil,
+		e: errors.New("error"),
+	}
 )
 
 func TestListArticleServices_List(t *testing.T) {
@@ -202,7 +270,7 @@ func TestListArticleServices_List(t *testing.T) {
 			want: 400,
 		},
 		{
-			name: "Failed to get data",
+			name: "Empty page",
 			fields: fields{
 				authData: mockValidAuthData,
 				repo:     mockEmptyArticleLister,
@@ -210,7 +278,29 @@ func TestListArticleServices_List(t *testing.T) {
 			args: args{
 				q: map[string][]string{"a": {"b"}},
 			},
-			want: 404,
+			want: 200,
+		},
+		{
+			name: "Invalid query",
+			fields: fields{
+				authData: mockValidAuthData,
+				repo:     mockInvalidQueryArticleLister,
+			},
+			args: args{
+				q: map[string][]string{"a": {"b"}},
+			},
+			want: 400,
+		},
+		{
+			name: "Failed to list data",
+			fields: fields{
+				authData: mockValidAuthData,
+				repo:     mockFailedArticleLister,
+			},
+			args: args{
+				q: map[string][]string{"a": {"b"}},
+			},
+			want: 500,
 		},
 	}
 	for _, tt := range tests {
@@ -224,109 +314,135 @@ func TestListArticleServices_List(t *testing.T) {
 	}
 }
 
-type mockArticleDetailer struct {
-	d models.Article
+type mockArticleSearcher struct {
+	d []models.ArticleSearchResult
 	e error
 }
 
-func (m mockArticleDetailer) FindByParam(param string, value any) (models.Article, error) {
-	return m.d, m.e
+func (m mockArticleSearcher) Search(q url.Values) ([]models.ArticleSearchResult, models.PageMeta, error) {
+	return m.d, models.PageMeta{Limit: 10}, m.e
 }
 
 var (
-	mockSuccessArticleDetailer = mockArticleDetailer{
-		d: models.Article{},
+	mockSuccessArticleSearcher = mockArticleSearcher{
+		d: []models.ArticleSearchResult{
+			{Article: models.Article{Title: "a"}, Rank: 0.5, Headline: "<mark>a</mark>"},
+		},
 		e: nil,
 	}
-	mockFailedArticleDetailer = mockArticleDetailer{
-		d: models.Article{},
+	mockEmptyArticleSearcher = mockArticleSearcher{
+		d: []models.ArticleSearchResult{},
+		e: nil,
+	}
+	mockFailedArticleSearcher = mockArticleSearcher{
+		d: nil,
 		e: errors.New("error"),
 	}
 )
 
-func TestDetailArticleServices_GetDetailByUUID(t *testing.T) {
+func TestSearchArticleServices_Search(t *testing.T) {
 	type fields struct {
 		authData any
-		repo     mockArticleDetailer
-	}
-	type args struct {
-		id int64
+		repo     mockArticleSearcher
 	}
 	tests := []struct {
 		name   string
 		fields fields
-		args   args
+		q      url.Values
 		want   int
 	}{
 		{
 			name: "Positive",
 			fields: fields{
 				authData: mockValidAuthData,
-				repo:     mockSuccessArticleDetailer,
-			},
-			args: args{
-				id: 1,
+				repo:     mockSuccessArticleSearcher,
 			},
+			q:    url.Values{"q": {"golang"}, "limit": {"5"}},
 			want: 200,
 		},
 		{
 			name: "Failed to read authData",
 			fields: fields{
 				authData: "invalid",
-				repo:     mockSuccessArticleDetailer,
+				repo:     mockSuccessArticleSearcher,
 			},
-			args: args{
-				id: 1,
+			q:    url.Values{"q": {"golang"}},
+			want: 400,
+		},
+		{
+			name: "Empty query",
+			fields: fields{
+				authData: mockValidAuthData,
+				repo:     mockSuccessArticleSearcher,
 			},
+			q:    url.Values{"q": {" "}},
 			want: 400,
 		},
 		{
-			name: "Failed to get data",
+			name: "Failed to search data",
 			fields: fields{
 				authData: mockValidAuthData,
-				repo:     mockFailedArticleDetailer,
+				repo:     mockFailedArticleSearcher,
 			},
-			args: args{
-				id: 1,
+			q:    url.Values{"q": {"golang"}},
+			want: 500,
+		},
+		{
+			name: "Nothing found",
+			fields: fields{
+				authData: mockValidAuthData,
+				repo:     mockEmptyArticleSearcher,
 			},
-			want: 404,
+			q:    url.Values{"q": {"golang"}},
+			want: 200,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			svc := NewDetailArticleServices(tt.fields.authData, tt.fields.repo)
-			got, _ := svc.GetDetailByUUID(tt.args.id)
+			svc := NewSearchArticleServices(tt.fields.authData, tt.fields.repo)
+			got, _ := svc.Search(tt.q)
 			if got != tt.want {
-				t.Errorf("DetailArticleServices.GetDetailByUUID() got = %v, want %v", got, tt.want)
+				t.Errorf("SearchArticleServices.Search() got = %v, want %v", got, tt.want)
 			}
 		})
 	}
 }
 
-type mockArticleDeleter struct {
+type mockArticleDetailer struct {
+	d models.Article
 	e error
 }
 
-func (m mockArticleDeleter) DeleteByParam(param string, value any) error {
-	return m.e
+func (m mockArticleDetailer) FindByParam(param string, value any) (models.Article, error) {
+	return m.d, m.e
 }
 
 var (
-	mockSuccessArticleDeleter = mockArticleDeleter{
+	mockSuccessArticleDetailer = mockArticleDetailer{
+		d: models.Article{},
 		e: nil,
 	}
-	mockFailedArticleDeleter = mockArticleDeleter{
+	mockFailedArticleDetailer = mockArticleDetailer{
+		d: models.Article{},
 		e: errors.New("error"),
 	}
+	mockOwnArticleDetailer = mockArticleDetailer{
+		d: models.Article{WriterID: mockValidAuthData.ID},
+		e: nil,
+	}
+	mockVersionedArticleDetailer = mockArticleDetailer{
+		d: models.Article{Version: 3},
+		e: nil,
+	}
 )
 
-func TestDeleteArticleServices_Delete(t *testing.T) {
+func TestDetailArticleServices_GetDetailByUUID(t *testing.T) {
 	type fields struct {
 		authData any
-		repo     mockArticleDeleter
+		repo     mockArticleDetailer
 	}
 	type args struct {
-		id int64
+		uuid string
 	}
 	tests := []struct {
 		name   string
@@ -338,10 +454,10 @@ func TestDeleteArticleServices_Delete(t *testing.T) {
 			name: "Positive",
 			fields: fields{
 				authData: mockValidAuthData,
-				repo:     mockSuccessArticleDeleter,
+				repo:     mockSuccessArticleDetailer,
 			},
 			args: args{
-				id: 1,
+				uuid: "abc-123",
 			},
 			want: 200,
 		},
@@ -349,66 +465,115 @@ func TestDeleteArticleServices_Delete(t *testing.T) {
 			name: "Failed to read authData",
 			fields: fields{
 				authData: "invalid",
-				repo:     mockSuccessArticleDeleter,
+				repo:     mockSuccessArticleDetailer,
 			},
 			args: args{
-				id: 1,
+				uuid: "abc-123",
 			},
 			want: 400,
 		},
 		{
-			name: "Failed to delete data",
+			name: "Failed to get data",
 			fields: fields{
 				authData: mockValidAuthData,
-				repo:     mockFailedArticleDeleter,
+				repo:     mockFailedArticleDetailer,
 			},
 			args: args{
-				id: 1,
+				uuid: "abc-123",
 			},
 			want: 404,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			svc := NewDeleteArticleServices(tt.fields.authData, tt.fields.repo)
-			got, _ := svc.Delete(tt.args.id)
+			svc := NewDetailArticleServices(tt.fields.authData, tt.fields.repo)
+			got, _ := svc.GetDetailByUUID(tt.args.uuid)
 			if got != tt.want {
-				t.Errorf("DeleteArticleServices.Delete() got = %v, want %v", got, tt.want)
+				t.Errorf("DetailArticleServices.GetDetailByUUID() got = %v, want %v", got, tt.want)
 			}
 		})
 	}
 }
 
-type mockArticlePatcher struct {
+type mockArticleDeleter struct {
+	e error
+}
+
+func (m mockArticleDeleter) Delete(id int64, actor models.Actor, version *int64) (models.Article, error) {
+	return models.Article{}, m.e
+}
+
+func (m mockArticleDeleter) Purge(id int64, actor models.Actor, version *int64) error {
+	return m.e
+}
+
+var (
+	mockSuccessArticleDeleter = mockArticleDeleter{
+		e: nil,
+	}
+	mockFailedArticleDeleter = mockArticleDeleter{
+		e: errors.New("error"),
+	}
+	mockMismatchArticleDeleter = mockArticleDeleter{
+		e: models.ErrArticleVersionMismatch,
+	}
+	mockLockedArticleDeleter = mockArticleDeleter{
+		e: models.ErrArticleLocked,
+	}
+)
+
+type mockArticleTrashDetailer struct {
 	d models.Article
 	e error
 }
 
-func (m mockArticlePatcher) PatchByParam(id int64, param string, value any) (models.Article, error) {
+func (m mockArticleTrashDetailer) FindByParam(param string, value any) (models.Article, error) {
+	if m.d.DeletedAt.Valid {
+		return models.Article{}, errors.New("record not found")
+	}
+	return m.d, m.e
+}
+
+func (m mockArticleTrashDetailer) FindByParamWithTrashed(param string, value any) (models.Article, error) {
 	return m.d, m.e
 }
 
 var (
-	mockSuccessArticlePatcher = mockArticlePatcher{
+	mockSuccessArticleTrashDetailer = mockArticleTrashDetailer{
 		d: models.Article{},
 		e: nil,
 	}
-	mockFailedArticlePatcher = mockArticlePatcher{
+	mockFailedArticleTrashDetailer = mockArticleTrashDetailer{
 		d: models.Article{},
 		e: errors.New("error"),
 	}
+	mockOwnArticleTrashDetailer = mockArticleTrashDetailer{
+		d: models.Article{WriterID: mockValidAuthData.ID},
+		e: nil,
+	}
+	mockVersionedArticleTrashDetailer = mockArticleTrashDetailer{
+		d: models.Article{Version: 3},
+		e: nil,
+	}
+	mockTrashedArticleTrashDetailer = mockArticleTrashDetailer{
+		d: models.Article{WriterID: mockValidAuthData.ID, DeletedAt: gorm.DeletedAt{Time: time.Now(), Valid: true}},
+		e: nil,
+	}
 )
 
-func TestPatchArticleServices_Patch(t *testing.T) {
+func TestDeleteArticleServices_Delete(t *testing.T) {
 	type fields struct {
-		authData    any
-		decoder     mockJsonDecoder
-		validator   mockRequestValidator
-		repo        mockArticlePatcher
-		historyRepo mockArticleHistoryCreator
+		authData       any
+		articleRepo    mockArticleTrashDetailer
+		policy         mockPermissionChecker
+		repo           mockArticleDeleter
+		locks          mockArticleLocker
+		requireIfMatch bool
 	}
 	type args struct {
-		id int64
+		uuid    string
+		purge   bool
+		ifMatch string
 	}
 	tests := []struct {
 		name   string
@@ -420,13 +585,12 @@ func TestPatchArticleServices_Patch(t *testing.T) {
 			name: "Positive",
 			fields: fields{
 				authData:    mockValidAuthData,
-				decoder:     mockSuccessJsonDecoder,
-				validator:   mockSuccessRequestValidator,
-				repo:        mockSuccessArticlePatcher,
-				historyRepo: mockSuccessArticleHistoryCreator,
+				articleRepo: mockSuccessArticleTrashDetailer,
+				policy:      mockGrantAllPermissionChecker,
+				repo:        mockSuccessArticleDeleter,
 			},
 			args: args{
-				id: 1,
+				uuid: "abc-123",
 			},
 			want: 200,
 		},
@@ -434,71 +598,927 @@ func TestPatchArticleServices_Patch(t *testing.T) {
 			name: "Failed to read authData",
 			fields: fields{
 				authData:    "invalid",
-				decoder:     mockSuccessJsonDecoder,
-				validator:   mockSuccessRequestValidator,
-				repo:        mockSuccessArticlePatcher,
-				historyRepo: mockSuccessArticleHistoryCreator,
+				articleRepo: mockSuccessArticleTrashDetailer,
+				policy:      mockGrantAllPermissionChecker,
+				repo:        mockSuccessArticleDeleter,
 			},
 			args: args{
-				id: 1,
+				uuid: "abc-123",
 			},
 			want: 400,
 		},
 		{
-			name: "Failed to decode json data",
+			name: "Failed to delete data",
 			fields: fields{
 				authData:    mockValidAuthData,
-				decoder:     mockFailedJsonDecoder,
-				validator:   mockSuccessRequestValidator,
-				repo:        mockSuccessArticlePatcher,
-				historyRepo: mockSuccessArticleHistoryCreator,
+				articleRepo: mockSuccessArticleTrashDetailer,
+				policy:      mockGrantAllPermissionChecker,
+				repo:        mockFailedArticleDeleter,
 			},
 			args: args{
-				id: 1,
+				uuid: "abc-123",
 			},
-			want: 400,
+			want: 404,
 		},
 		{
-			name: "Failed to validate data",
+			name: "Failed to get data",
 			fields: fields{
 				authData:    mockValidAuthData,
-				decoder:     mockSuccessJsonDecoder,
-				validator:   mockFailedRequestValidator,
-				repo:        mockSuccessArticlePatcher,
-				historyRepo: mockSuccessArticleHistoryCreator,
+				articleRepo: mockFailedArticleTrashDetailer,
+				policy:      mockGrantAllPermissionChecker,
+				repo:        mockSuccessArticleDeleter,
 			},
 			args: args{
-				id: 1,
+				uuid: "abc-123",
 			},
-			want: 400,
+			want: 404,
 		},
 		{
-			name: "Failed to save data",
+			name: "Already in the trash",
 			fields: fields{
 				authData:    mockValidAuthData,
-				decoder:     mockSuccessJsonDecoder,
-				validator:   mockSuccessRequestValidator,
-				repo:        mockFailedArticlePatcher,
-				historyRepo: mockSuccessArticleHistoryCreator,
+				articleRepo: mockTrashedArticleTrashDetailer,
+				policy:      mockGrantAllPermissionChecker,
+				repo:        mockSuccessArticleDeleter,
 			},
 			args: args{
-				id: 1,
+				uuid: "abc-123",
 			},
-			want: 500,
+			want: 404,
 		},
-	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			svc := NewPatchArticleServices(
-				tt.fields.authData,
-				tt.fields.decoder,
-				tt.fields.validator,
-				tt.fields.repo,
-				tt.fields.historyRepo,
-			)
-			got, _ := svc.Patch(tt.args.id)
-			if got != tt.want {
-				t.Errorf("PatchArticleServices.Patch() got = %v, want %v", got, tt.want)
+		{
+			name: "Writer deletes own article",
+			fields: fields{
+				authData:    mockValidAuthData,
+				articleRepo: mockOwnArticleTrashDetailer,
+				policy:      mockWriterPermissionChecker,
+				repo:        mockSuccessArticleDeleter,
+			},
+			args: args{
+				uuid: "abc-123",
+			},
+			want: 200,
+		},
+		{
+			name: "Writer deletes article of another writer",
+			fields: fields{
+				authData:    mockValidAuthData,
+				articleRepo: mockSuccessArticleTrashDetailer,
+				policy:      mockWriterPermissionChecker,
+				repo:        mockSuccessArticleDeleter,
+			},
+			args: args{
+				uuid: "abc-123",
+			},
+			want: 403,
+		},
+		{
+			name: "Purge",
+			fields: fields{
+				authData:    mockValidAuthData,
+				articleRepo: mockSuccessArticleTrashDetailer,
+				policy:      mockGrantAllPermissionChecker,
+				repo:        mockSuccessArticleDeleter,
+			},
+			args: args{
+				uuid:  "abc-123",
+				purge: true,
+			},
+			want: 200,
+		},
+		{
+			name: "Purge trashed article",
+			fields: fields{
+				authData:    mockValidAuthData,
+				articleRepo: mockTrashedArticleTrashDetailer,
+				policy:      mockGrantAllPermissionChecker,
+				repo:        mockSuccessArticleDeleter,
+			},
+			args: args{
+				uuid:  "abc-123",
+				purge: true,
+			},
+			want: 200,
+		},
+		{
+			name: "Writer purges own article",
+			fields: fields{
+				authData:    mockValidAuthData,
+				articleRepo: mockOwnArticleTrashDetailer,
+				policy:      mockWriterPermissionChecker,
+				repo:        mockSuccessArticleDeleter,
+			},
+			args: args{
+				uuid:  "abc-123",
+				purge: true,
+			},
+			want: 403,
+		},
+		{
+			name: "Purge not found",
+			fields: fields{
+				authData:    mockValidAuthData,
+				articleRepo: mockFailedArticleTrashDetailer,
+				policy:      mockGrantAllPermissionChecker,
+				repo:        mockSuccessArticleDeleter,
+			},
+			args: args{
+				uuid:  "abc-123",
+				purge: true,
+			},
+			want: 404,
+		},
+		{
+			name: "Failed to purge data",
+			fields: fields{
+				authData:    mockValidAuthData,
+				articleRepo: mockSuccessArticleTrashDetailer,
+				policy:      mockGrantAllPermissionChecker,
+				repo:        mockFailedArticleDeleter,
+			},
+			args: args{
+				uuid:  "abc-123",
+				purge: true,
+			},
+			want: 404,
+		},
+		{
+			name: "Matching If-Match",
+			fields: fields{
+				authData:       mockValidAuthData,
+				articleRepo:    mockVersionedArticleTrashDetailer,
+				policy:         mockGrantAllPermissionChecker,
+				repo:           mockSuccessArticleDeleter,
+				requireIfMatch: true,
+			},
+			args: args{
+				uuid:    "abc-123",
+				ifMatch: `"3"`,
+			},
+			want: 200,
+		},
+		{
+			name: "Missing If-Match",
+			fields: fields{
+				authData:       mockValidAuthData,
+				articleRepo:    mockVersionedArticleTrashDetailer,
+				policy:         mockGrantAllPermissionChecker,
+				repo:           mockSuccessArticleDeleter,
+				requireIfMatch: true,
+			},
+			args: args{
+				uuid: "abc-123",
+			},
+			want: 428,
+		},
+		{
+			name: "Stale If-Match",
+			fields: fields{
+				authData:    mockValidAuthData,
+				articleRepo: mockVersionedArticleTrashDetailer,
+				policy:      mockGrantAllPermissionChecker,
+				repo:        mockSuccessArticleDeleter,
+			},
+			args: args{
+				uuid:    "abc-123",
+				ifMatch: `"2"`,
+			},
+			want: 412,
+		},
+		{
+			name: "Changed while deleting",
+			fields: fields{
+				authData:    mockValidAuthData,
+				articleRepo: mockVersionedArticleTrashDetailer,
+				policy:      mockGrantAllPermissionChecker,
+				repo:        mockMismatchArticleDeleter,
+			},
+			args: args{
+				uuid:    "abc-123",
+				ifMatch: `"3"`,
+			},
+			want: 412,
+		},
+		{
+			name: "Purge with stale If-Match",
+			fields: fields{
+				authData:    mockValidAuthData,
+				articleRepo: mockVersionedArticleTrashDetailer,
+				policy:      mockGrantAllPermissionChecker,
+				repo:        mockSuccessArticleDeleter,
+			},
+			args: args{
+				uuid:    "abc-123",
+				purge:   true,
+				ifMatch: `"2"`,
+			},
+			want: 412,
+		},
+		{
+			name: "Locked by another user",
+			fields: fields{
+				authData:    mockValidAuthData,
+				articleRepo: mockOwnArticleTrashDetailer,
+				policy:      mockGrantAllPermissionChecker,
+				repo:        mockSuccessArticleDeleter,
+				locks:       mockOtherArticleLocker,
+			},
+			args: args{
+				uuid: "abc-123",
+			},
+			want: 423,
+		},
+		{
+			name: "Locked by the user",
+			fields: fields{
+				authData:    mockValidAuthData,
+				articleRepo: mockOwnArticleTrashDetailer,
+				policy:      mockGrantAllPermissionChecker,
+				repo:        mockSuccessArticleDeleter,
+				locks:       mockOwnArticleLocker,
+			},
+			args: args{
+				uuid: "abc-123",
+			},
+			want: 200,
+		},
+		{
+			name: "Purge locked by another user",
+			fields: fields{
+				authData:    mockValidAuthData,
+				articleRepo: mockSuccessArticleTrashDetailer,
+				policy:      mockGrantAllPermissionChecker,
+				repo:        mockSuccessArticleDeleter,
+				locks:       mockOtherArticleLocker,
+			},
+			args: args{
+				uuid:  "abc-123",
+				purge: true,
+			},
+			want: 423,
+		},
+		{
+			name: "Failed to get lock",
+			fields: fields{
+				authData:    mockValidAuthData,
+				articleRepo: mockSuccessArticleTrashDetailer,
+				policy:      mockGrantAllPermissionChecker,
+				repo:        mockSuccessArticleDeleter,
+				locks:       mockFailedArticleLocker,
+			},
+			args: args{
+				uuid: "abc-123",
+			},
+			want: 500,
+		},
+		{
+			name: "Changed while purging",
+			fields: fields{
+				authData:    mockValidAuthData,
+				articleRepo: mockVersionedArticleTrashDetailer,
+				policy:      mockGrantAllPermissionChecker,
+				repo:        mockMismatchArticleDeleter,
+			},
+			args: args{
+				uuid:    "abc-123",
+				purge:   true,
+				ifMatch: `"3"`,
+			},
+			want: 412,
+		},
+		{
+			name: "Locked while deleting",
+			fields: fields{
+				authData:    mockValidAuthData,
+				articleRepo: mockSuccessArticleTrashDetailer,
+				policy:      mockGrantAllPermissionChecker,
+				repo:        mockLockedArticleDeleter,
+			},
+			args: args{
+				uuid: "abc-123",
+			},
+			want: 423,
+		},
+		{
+			name: "Locked while purging",
+			fields: fields{
+				authData:    mockValidAuthData,
+				articleRepo: mockSuccessArticleTrashDetailer,
+				policy:      mockGrantAllPermissionChecker,
+				repo:        mockLockedArticleDeleter,
+			},
+			args: args{
+				uuid:  "abc-123",
+				purge: true,
+			},
+			want: 423,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := NewDeleteArticleServices(tt.fields.authData, tt.fields.articleRepo, tt.fields.policy, tt.fields.repo, tt.fields.locks, tt.fields.requireIfMatch)
+			got, _ := svc.Delete(tt.args.uuid, tt.args.purge, tt.args.ifMatch)
+			if got != tt.want {
+				t.Errorf("DeleteArticleServices.Delete() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+type mockArticlePatcher struct {
+	d models.Article
+	e error
+}
+
+func (m mockArticlePatcher) Update(id int64, actor models.Actor, action string, patch models.PatchArticleRequest, version *int64) (models.Article, error) {
+	return m.d, m.e
+}
+
+var (
+	mockSuccessArticlePatcher = mockArticlePatcher{
+		d: models.Article{},
+		e: nil,
+	}
+	mockFailedArticlePatcher = mockArticlePatcher{
+		d: models.Article{},
+		e: errors.New("error"),
+	}
+	mockMismatchArticlePatcher = mockArticlePatcher{
+		d: models.Article{},
+		e: models.ErrArticleVersionMismatch,
+	}
+	mockLockedArticlePatcher = mockArticlePatcher{
+		d: models.Article{},
+		e: models.ErrArticleLocked,
+	}
+	mockPatchArticleDecoder = mockBodyDecoder{
+		body: `{"title":"new title","tags":["go"]}`,
+	}
+	mockEmptyPatchArticleDecoder = mockBodyDecoder{
+		body: `{}`,
+	}
+)
+
+func TestPatchArticleServices_Patch(t *testing.T) {
+	type fields struct {
+		authData       any
+		decoder        JsonDecoder
+		validator      mockRequestValidator
+		articleRepo    mockArticleDetailer
+		policy         mockPermissionChecker
+		repo           mockArticlePatcher
+		locks          mockArticleLocker
+		requireIfMatch bool
+	}
+	type args struct {
+		uuid    string
+		ifMatch string
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		want   int
+	}{
+		{
+			name: "Positive",
+			fields: fields{
+				authData:    mockValidAuthData,
+				decoder:     mockPatchArticleDecoder,
+				validator:   mockSuccessRequestValidator,
+				articleRepo: mockSuccessArticleDetailer,
+				policy:      mockGrantAllPermissionChecker,
+				repo:        mockSuccessArticlePatcher,
+			},
+			args: args{
+				uuid: "abc-123",
+			},
+			want: 200,
+		},
+		{
+			name: "Failed to read authData",
+			fields: fields{
+				authData:    "invalid",
+				decoder:     mockPatchArticleDecoder,
+				validator:   mockSuccessRequestValidator,
+				articleRepo: mockSuccessArticleDetailer,
+				policy:      mockGrantAllPermissionChecker,
+				repo:        mockSuccessArticlePatcher,
+			},
+			args: args{
+				uuid: "abc-123",
+			},
+			want: 400,
+		},
+		{
+			name: "Failed to decode json data",
+			fields: fields{
+				authData:    mockValidAuthData,
+				decoder:     mockFailedJsonDecoder,
+				validator:   mockSuccessRequestValidator,
+				articleRepo: mockSuccessArticleDetailer,
+				policy:      mockGrantAllPermissionChecker,
+				repo:        mockSuccessArticlePatcher,
+			},
+			args: args{
+				uuid: "abc-123",
+			},
+			want: 400,
+		},
+		{
+			name: "Failed to validate data",
+			fields: fields{
+				authData:    mockValidAuthData,
+				decoder:     mockPatchArticleDecoder,
+				validator:   mockFailedRequestValidator,
+				articleRepo: mockSuccessArticleDetailer,
+				policy:      mockGrantAllPermissionChecker,
+				repo:        mockSuccessArticlePatcher,
+			},
+			args: args{
+				uuid: "abc-123",
+			},
+			want: 400,
+		},
+		{
+			name: "Empty patch",
+			fields: fields{
+				authData:    mockValidAuthData,
+				decoder:     mockEmptyPatchArticleDecoder,
+				validator:   mockSuccessRequestValidator,
+				articleRepo: mockSuccessArticleDetailer,
+				policy:      mockGrantAllPermissionChecker,
+				repo:        mockSuccessArticlePatcher,
+			},
+			args: args{
+				uuid: "abc-123",
+			},
+			want: 400,
+		},
+		{
+			name: "Failed to save data",
+			fields: fields{
+				authData:    mockValidAuthData,
+				decoder:     mockPatchArticleDecoder,
+				validator:   mockSuccessRequestValidator,
+				articleRepo: mockSuccessArticleDetailer,
+				policy:      mockGrantAllPermissionChecker,
+				repo:        mockFailedArticlePatcher,
+			},
+			args: args{
+				uuid: "abc-123",
+			},
+			want: 500,
+		},
+		{
+			name: "Failed to get data",
+			fields: fields{
+				authData:    mockValidAuthData,
+				decoder:     mockPatchArticleDecoder,
+				validator:   mockSuccessRequestValidator,
+				articleRepo: mockFailedArticleDetailer,
+				policy:      mockGrantAllPermissionChecker,
+				repo:        mockSuccessArticlePatcher,
+			},
+			args: args{
+				uuid: "abc-123",
+			},
+			want: 404,
+		},
+		{
+			name: "Writer patches own article",
+			fields: fields{
+				authData:    mockValidAuthData,
+				decoder:     mockPatchArticleDecoder,
+				validator:   mockSuccessRequestValidator,
+				articleRepo: mockOwnArticleDetailer,
+				policy:      mockWriterPermissionChecker,
+				repo:        mockSuccessArticlePatcher,
+			},
+			args: args{
+				uuid: "abc-123",
+			},
+			want: 200,
+		},
+		{
+			name: "Writer patches article of another writer",
+			fields: fields{
+				authData:    mockValidAuthData,
+				decoder:     mockPatchArticleDecoder,
+				validator:   mockSuccessRequestValidator,
+				articleRepo: mockSuccessArticleDetailer,
+				policy:      mockWriterPermissionChecker,
+				repo:        mockSuccessArticlePatcher,
+			},
+			args: args{
+				uuid: "abc-123",
+			},
+			want: 403,
+		},
+		{
+			name: "Matching If-Match",
+			fields: fields{
+				authData:       mockValidAuthData,
+				decoder:        mockPatchArticleDecoder,
+				validator:      mockSuccessRequestValidator,
+				articleRepo:    mockVersionedArticleDetailer,
+				policy:         mockGrantAllPermissionChecker,
+				repo:           mockSuccessArticlePatcher,
+				requireIfMatch: true,
+			},
+			args: args{
+				uuid:    "abc-123",
+				ifMatch: `"3"`,
+			},
+			want: 200,
+		},
+		{
+			name: "Any version",
+			fields: fields{
+				authData:       mockValidAuthData,
+				decoder:        mockPatchArticleDecoder,
+				validator:      mockSuccessRequestValidator,
+				articleRepo:    mockVersionedArticleDetailer,
+				policy:         mockGrantAllPermissionChecker,
+				repo:           mockSuccessArticlePatcher,
+				requireIfMatch: true,
+			},
+			args: args{
+				uuid:    "abc-123",
+				ifMatch: "*",
+			},
+			want: 200,
+		},
+		{
+			name: "Missing If-Match",
+			fields: fields{
+				authData:       mockValidAuthData,
+				decoder:        mockPatchArticleDecoder,
+				validator:      mockSuccessRequestValidator,
+				articleRepo:    mockVersionedArticleDetailer,
+				policy:         mockGrantAllPermissionChecker,
+				repo:           mockSuccessArticlePatcher,
+				requireIfMatch: true,
+			},
+			args: args{
+				uuid: "abc-123",
+			},
+			want: 428,
+		},
+		{
+			name: "Weak If-Match",
+			fields: fields{
+				authData:    mockValidAuthData,
+				decoder:     mockPatchArticleDecoder,
+				validator:   mockSuccessRequestValidator,
+				articleRepo: mockVersionedArticleDetailer,
+				policy:      mockGrantAllPermissionChecker,
+				repo:        mockSuccessArticlePatcher,
+			},
+			args: args{
+				uuid:    "abc-123",
+				ifMatch: `W/"3"`,
+			},
+			want: 412,
+		},
+		{
+			name: "Stale If-Match",
+			fields: fields{
+				authData:    mockValidAuthData,
+				decoder:     mockPatchArticleDecoder,
+				validator:   mockSuccessRequestValidator,
+				articleRepo: mockVersionedArticleDetailer,
+				policy:      mockGrantAllPermissionChecker,
+				repo:        mockSuccessArticlePatcher,
+			},
+			args: args{
+				uuid:    "abc-123",
+				ifMatch: `"2"`,
+			},
+			want: 412,
+		},
+		{
+			name: "Locked by another user",
+			fields: fields{
+				authData:    mockValidAuthData,
+				decoder:     mockPatchArticleDecoder,
+				validator:   mockSuccessRequestValidator,
+				articleRepo: mockOwnArticleDetailer,
+				policy:      mockWriterPermissionChecker,
+				repo:        mockSuccessArticlePatcher,
+				locks:       mockOtherArticleLocker,
+			},
+			args: args{
+				uuid: "abc-123",
+			},
+			want: 423,
+		},
+		{
+			name: "Locked by the user",
+			fields: fields{
+				authData:    mockValidAuthData,
+				decoder:     mockPatchArticleDecoder,
+				validator:   mockSuccessRequestValidator,
+				articleRepo: mockOwnArticleDetailer,
+				policy:      mockWriterPermissionChecker,
+				repo:        mockSuccessArticlePatcher,
+				locks:       mockOwnArticleLocker,
+			},
+			args: args{
+				uuid: "abc-123",
+			},
+			want: 200,
+		},
+		{
+			name: "Changed while patching",
+			fields: fields{
+				authData:    mockValidAuthData,
+				decoder:     mockPatchArticleDecoder,
+				validator:   mockSuccessRequestValidator,
+				articleRepo: mockVersionedArticleDetailer,
+				policy:      mockGrantAllPermissionChecker,
+				repo:        mockMismatchArticlePatcher,
+			},
+			args: args{
+				uuid:    "abc-123",
+				ifMatch: `"3"`,
+			},
+			want: 412,
+		},
+		{
+			name: "Locked while patching",
+			fields: fields{
+				authData:    mockValidAuthData,
+				decoder:     mockPatchArticleDecoder,
+				validator:   mockSuccessRequestValidator,
+				articleRepo: mockSuccessArticleDetailer,
+				policy:      mockGrantAllPermissionChecker,
+				repo:        mockLockedArticlePatcher,
+			},
+			args: args{
+				uuid: "abc-123",
+			},
+			want: 423,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := NewPatchArticleServices(
+				tt.fields.authData,
+				tt.fields.decoder,
+				tt.fields.validator,
+				tt.fields.articleRepo,
+				tt.fields.policy,
+				tt.fields.repo,
+				tt.fields.locks,
+				tt.fields.requireIfMatch,
+			)
+			got, _ := svc.Patch(tt.args.uuid, tt.args.ifMatch)
+			if got != tt.want {
+				t.Errorf("PatchArticleServices.Patch() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPutArticleServices_Put(t *testing.T) {
+	type fields struct {
+		authData       any
+		decoder        JsonDecoder
+		validator      mockRequestValidator
+		articleRepo    mockArticleDetailer
+		policy         mockPermissionChecker
+		repo           mockArticlePatcher
+		locks          mockArticleLocker
+		requireIfMatch bool
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		ifMatch string
+		want    int
+	}{
+		{
+			name: "Positive",
+			fields: fields{
+				authData:    mockValidAuthData,
+				decoder:     mockSuccessJsonDecoder,
+				validator:   mockSuccessRequestValidator,
+				articleRepo: mockSuccessArticleDetailer,
+				policy:      mockGrantAllPermissionChecker,
+				repo:        mockSuccessArticlePatcher,
+			},
+			want: 200,
+		},
+		{
+			name: "Failed to read authData",
+			fields: fields{
+				authData:    "invalid",
+				decoder:     mockSuccessJsonDecoder,
+				validator:   mockSuccessRequestValidator,
+				articleRepo: mockSuccessArticleDetailer,
+				policy:      mockGrantAllPermissionChecker,
+				repo:        mockSuccessArticlePatcher,
+			},
+			want: 400,
+		},
+		{
+			name: "Failed to decode json data",
+			fields: fields{
+				authData:    mockValidAuthData,
+				decoder:     mockFailedJsonDecoder,
+				validator:   mockSuccessRequestValidator,
+				articleRepo: mockSuccessArticleDetailer,
+				policy:      mockGrantAllPermissionChecker,
+				repo:        mockSuccessArticlePatcher,
+			},
+			want: 400,
+		},
+		{
+			name: "Failed to validate data",
+			fields: fields{
+				authData:    mockValidAuthData,
+				decoder:     mockSuccessJsonDecoder,
+				validator:   mockFailedRequestValidator,
+				articleRepo: mockSuccessArticleDetailer,
+				policy:      mockGrantAllPermissionChecker,
+				repo:        mockSuccessArticlePatcher,
+			},
+			want: 400,
+		},
+		{
+			name: "Failed to get data",
+			fields: fields{
+				authData:    mockValidAuthData,
+				decoder:     mockSuccessJsonDecoder,
+				validator:   mockSuccessRequestValidator,
+				articleRepo: mockFailedArticleDetailer,
+				policy:      mockGrantAllPermissionChecker,
+				repo:        mockSuccessArticlePatcher,
+			},
+			want: 404,
+		},
+		{
+			name: "Writer replaces article of another writer",
+			fields: fields{
+				authData:    mockValidAuthData,
+				decoder:     mockSuccessJsonDecoder,
+				validator:   mockSuccessRequestValidator,
+				articleRepo: mockSuccessArticleDetailer,
+				policy:      mockWriterPermissionChecker,
+				repo:        mockSuccessArticlePatcher,
+			},
+			want: 403,
+		},
+		{
+			name: "Failed to save data",
+			fields: fields{
+				authData:    mockValidAuthData,
+				decoder:     mockSuccessJsonDecoder,
+				validator:   mockSuccessRequestValidator,
+				articleRepo: mockSuccessArticleDetailer,
+				policy:      mockGrantAllPermissionChecker,
+				repo:        mockFailedArticlePatcher,
+			},
+			want: 500,
+		},
+		{
+			name: "Missing If-Match",
+			fields: fields{
+				authData:       mockValidAuthData,
+				decoder:        mockSuccessJsonDecoder,
+				validator:      mockSuccessRequestValidator,
+				articleRepo:    mockVersionedArticleDetailer,
+				policy:         mockGrantAllPermissionChecker,
+				repo:           mockSuccessArticlePatcher,
+				requireIfMatch: true,
+			},
+			want: 428,
+		},
+		{
+			name: "Stale If-Match",
+			fields: fields{
+				authData:    mockValidAuthData,
+				decoder:     mockSuccessJsonDecoder,
+				validator:   mockSuccessRequestValidator,
+				articleRepo: mockVersionedArticleDetailer,
+				policy:      mockGrantAllPermissionChecker,
+				repo:        mockSuccessArticlePatcher,
+			},
+			ifMatch: `"2"`,
+			want:    412,
+		},
+		{
+			name: "Locked by another user",
+			fields: fields{
+				authData:    mockValidAuthData,
+				decoder:     mockSuccessJsonDecoder,
+				validator:   mockSuccessRequestValidator,
+				articleRepo: mockSuccessArticleDetailer,
+				policy:      mockGrantAllPermissionChecker,
+				repo:        mockSuccessArticlePatcher,
+				locks:       mockOtherArticleLocker,
+			},
+			want: 423,
+		},
+		{
+			name: "Changed while replacing",
+			fields: fields{
+				authData:    mockValidAuthData,
+				decoder:     mockSuccessJsonDecoder,
+				validator:   mockSuccessRequestValidator,
+				articleRepo: mockVersionedArticleDetailer,
+				policy:      mockGrantAllPermissionChecker,
+				repo:        mockMismatchArticlePatcher,
+			},
+			ifMatch: `"3"`,
+			want:    412,
+		},
+		{
+			name: "Locked while replacing",
+			fields: fields{
+				authData:    mockValidAuthData,
+				decoder:     mockSuccessJsonDecoder,
+				validator:   mockSuccessRequestValidator,
+				articleRepo: mockSuccessArticleDetailer,
+				policy:      mockGrantAllPermissionChecker,
+				repo:        mockLockedArticlePatcher,
+			},
+			want: 423,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := NewPutArticleServices(
+				tt.fields.authData,
+				tt.fields.decoder,
+				tt.fields.validator,
+				tt.fields.articleRepo,
+				tt.fields.policy,
+				tt.fields.repo,
+				tt.fields.locks,
+				tt.fields.requireIfMatch,
+			)
+			got, _ := svc.Put("abc-123", tt.ifMatch)
+			if got != tt.want {
+				t.Errorf("PutArticleServices.Put() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+type mockArticleSlugFinder struct {
+	d models.Article
+	e error
+}
+
+func (m mockArticleSlugFinder) FindBySlug(slug string) (models.Article, error) {
+	return m.d, m.e
+}
+
+func TestDetailArticleBySlugServices_GetDetailBySlug(t *testing.T) {
+	tests := []struct {
+		name      string
+		authData  any
+		repo      mockArticleSlugFinder
+		want      int
+		wantMoved string
+	}{
+		{
+			name:     "Positive",
+			authData: mockValidAuthData,
+			repo:     mockArticleSlugFinder{d: models.Article{Slug: "go"}},
+			want:     200,
+		},
+		{
+			name:      "Former slug",
+			authData:  mockValidAuthData,
+			repo:      mockArticleSlugFinder{d: models.Article{Slug: "go-2"}},
+			want:      301,
+			wantMoved: "go-2",
+		},
+		{
+			name:     "Failed to read authData",
+			authData: "invalid",
+			repo:     mockArticleSlugFinder{d: models.Article{Slug: "go"}},
+			want:     400,
+		},
+		{
+			name:     "Not found",
+			authData: mockValidAuthData,
+			repo:     mockArticleSlugFinder{e: errors.New("record not found")},
+			want:     404,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := NewDetailArticleBySlugServices(tt.authData, tt.repo)
+			got, res := svc.GetDetailBySlug("go")
+			if got != tt.want {
+				t.Errorf("DetailArticleBySlugServices.GetDetailBySlug() got = %v, want %v", got, tt.want)
+			}
+			if moved, ok := res.Data.(models.MovedArticle); ok != (tt.wantMoved != "") || moved.Slug != tt.wantMoved {
+				t.Errorf("DetailArticleBySlugServices.GetDetailBySlug() data = %+v, want moved to %q", res.Data, tt.wantMoved)
 			}
 		})
 	}
@@ -0,0 +1,149 @@
+package services
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/herdiansc/go-cms/models"
+)
+
+// ArticleTransitioner defines article transition function
+type ArticleTransitioner interface {
+	Transition(id int64, actor models.Actor, transition models.ArticleTransition, comment string) (models.Article, error)
+}
+
+// TransitionArticleServices defines transition article service struct
+type TransitionArticleServices struct {
+	authData    any
+	decoder     JsonDecoder
+	validator   RequestValidator
+	articleRepo ArticleDetailer
+	policy      ArticlePolicy
+	repo        ArticleTransitioner
+	locks       ArticleLockFinder
+}
+
+// NewTransitionArticleServices inits TransitionArticleServices
+func NewTransitionArticleServices(ad any, jd JsonDecoder, rv RequestValidator, ar ArticleDetailer, pc PermissionChecker, at ArticleTransitioner, al ArticleLockFinder) TransitionArticleServices {
+	return TransitionArticleServices{
+		authData:    ad,
+		decoder:     jd,
+		validator:   rv,
+		articleRepo: ar,
+		policy:      NewArticlePolicy(pc),
+		repo:        at,
+		locks:       al,
+	}
+}
+
+// Transition performs a workflow transition on an article
+func (svc TransitionArticleServices) Transition(uuid string) (int, models.Response) {
+	authData, ok := svc.authData.(models.VerifyData)
+	if !ok {
+		log.Printf("Failed to read authData\n")
+		return http.StatusBadRequest, models.Response{Message: "error", Data: nil}
+	}
+
+	var data models.TransitionArticleRequest
+	err := svc.decoder.Decode(&data)
+	if err != nil {
+		log.Printf("Failed to decode json data: %+v\n", err.Error())
+		return http.StatusBadRequest, models.Response{Message: "Bad Request", Data: err.Error()}
+	}
+
+	err = svc.validator.Struct(data)
+	if err != nil {
+		log.Printf("Failed to validate data: %+v\n", err.Error())
+		return http.StatusBadRequest, models.Response{Message: "Bad Request", Data: err.Error()}
+	}
+
+	transition, ok := models.FindArticleTransition(data.Action)
+	if !ok {
+		log.Printf("Unknown transition: %s\n", data.Action)
+		return http.StatusBadRequest, models.Response{Message: "Bad Request", Data: "unknown transition " + data.Action}
+	}
+	if transition.RequiresComment && data.Comment == "" {
+		log.Printf("Missing comment for transition: %s\n", data.Action)
+		return http.StatusBadRequest, models.Response{Message: "Bad Request", Data: "a comment is required to " + data.Action}
+	}
+
+	article, err := svc.articleRepo.FindByParam("uuid", uuid)
+	if err != nil {
+		log.Printf("Failed to get data: %+v\n", err.Error())
+		return http.StatusNotFound, models.Response{Message: "not found", Data: err.Error()}
+	}
+
+	if code, res := svc.policy.Authorize(authData, article, transition.Name); code != http.StatusOK {
+		log.Printf("Failed to authorize: %+v\n", res.Data)
+		return code, res
+	}
+	if code, res := checkArticleLock(svc.locks, authData, article); code != http.StatusOK {
+		return code, res
+	}
+
+	if !transition.AllowedFrom(article.Status) {
+		log.Printf("Transition %s not allowed from %s\n", transition.Name, article.Status)
+		return http.StatusConflict, models.Response{Message: "Conflict", Data: models.ErrArticleTransitionNotAllowed.Error()}
+	}
+
+	transitioned, err := svc.repo.Transition(article.ID, authData.Actor(), transition, data.Comment)
+	if errors.Is(err, models.ErrArticleLocked) {
+		return articleLockedMeanwhile(svc.locks, article)
+	}
+	if errors.Is(err, models.ErrArticleTransitionNotAllowed) {
+		log.Printf("Failed to transition data: %+v\n", err.Error())
+		return http.StatusConflict, models.Response{Message: "Conflict", Data: err.Error()}
+	}
+	if err != nil {
+		log.Printf("Failed to save data: %+v\n", err.Error())
+		return http.StatusInternalServerError, models.Response{Message: "Failed to save data", Data: err.Error()}
+	}
+
+	return http.StatusOK, models.Response{Message: "ok", Data: transitioned}
+}
+
+// ListArticleTransitionServices defines list article transition service struct
+type ListArticleTransitionServices struct {
+	authData    any
+	articleRepo ArticleDetailer
+	policy      ArticlePolicy
+}
+
+// NewListArticleTransitionServices inits ListArticleTransitionServices
+func NewListArticleTransitionServices(ad any, ar ArticleDetailer, pc PermissionChecker) ListArticleTransitionServices {
+	return ListArticleTransitionServices{
+		authData:    ad,
+		articleRepo: ar,
+		policy:      NewArticlePolicy(pc),
+	}
+}
+
+// List lists the transitions the user may perform on an article from its current status
+func (svc ListArticleTransitionServices) List(uuid string) (int, models.Response) {
+	authData, ok := svc.authData.(models.VerifyData)
+	if !ok {
+		log.Printf("Failed to read authData\n")
+		return http.StatusBadRequest, models.Response{Message: "error", Data: nil}
+	}
+
+	article, err := svc.articleRepo.FindByParam("uuid", uuid)
+	if err != nil {
+		log.Printf("Failed to get data: %+v\n", err.Error())
+		return http.StatusNotFound, models.Response{Message: "not found", Data: err.Error()}
+	}
+
+	if code, res := svc.policy.Authorize(authData, article, ArticleActionRead); code != http.StatusOK {
+		log.Printf("Failed to authorize: %+v\n", res.Data)
+		return code, res
+	}
+
+	data := []models.ArticleTransition{}
+	for _, transition := range models.ArticleTransitions {
+		if transition.AllowedFrom(article.Status) && svc.policy.Allows(authData, article, transition.Name) {
+			data = append(data, transition)
+		}
+	}
+
+	return http.StatusOK, models.Response{Message: "ok", Data: data}
+}
@@ -0,0 +1,369 @@
+package services
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/herdiansc/go-cms/models"
+)
+
+type mockArticleTransitioner struct {
+	d models.Article
+	e error
+}
+
+func (m mockArticleTransitioner) Transition(id int64, actor models.Actor, transition models.ArticleTransition, comment string) (models.Article, error) {
+	return m.d, m.e
+}
+
+var (
+	mockSuccessArticleTransitioner = mockArticleTransitioner{
+		d: models.Article{},
+		e: nil,
+	}
+	mockFailedArticleTransitioner = mockArticleTransitioner{
+		d: models.Article{},
+		e: errors.New("error"),
+	}
+	mockConflictArticleTransitioner = mockArticleTransitioner{
+		d: models.Article{},
+		e: models.ErrArticleTransitionNotAllowed,
+	}
+	mockLockedArticleTransitioner = mockArticleTransitioner{
+		d: models.Article{},
+		e: models.ErrArticleLocked,
+	}
+	mockDraftArticleDetailer = mockArticleDetailer{
+		d: models.Article{Status: models.ArticleStatusDraft},
+		e: nil,
+	}
+	mockOwnDraftArticleDetailer = mockArticleDetailer{
+		d: models.Article{Status: models.ArticleStatusDraft, WriterID: mockValidAuthData.ID},
+		e: nil,
+	}
+	mockInReviewArticleDetailer = mockArticleDetailer{
+		d: models.Article{Status: models.ArticleStatusInReview},
+		e: nil,
+	}
+	mockSubmitArticleDecoder = mockBodyDecoder{
+		body: `{"action":"submit"}`,
+	}
+	mockApproveArticleDecoder = mockBodyDecoder{
+		body: `{"action":"approve","comment":"looks good"}`,
+	}
+	mockRejectArticleDecoder = mockBodyDecoder{
+		body: `{"action":"reject"}`,
+	}
+	mockUnknownTransitionDecoder = mockBodyDecoder{
+		body: `{"action":"teleport"}`,
+	}
+)
+
+func TestTransitionArticleServices_Transition(t *testing.T) {
+	type fields struct {
+		authData    any
+		decoder     JsonDecoder
+		validator   mockRequestValidator
+		articleRepo mockArticleDetailer
+		policy      mockPermissionChecker
+		repo        mockArticleTransitioner
+		locks       mockArticleLocker
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		want   int
+	}{
+		{
+			name: "Positive",
+			fields: fields{
+				authData:    mockValidAuthData,
+				decoder:     mockApproveArticleDecoder,
+				validator:   mockSuccessRequestValidator,
+				articleRepo: mockInReviewArticleDetailer,
+				policy:      mockGrantAllPermissionChecker,
+				repo:        mockSuccessArticleTransitioner,
+			},
+			want: 200,
+		},
+		{
+			name: "Failed to read authData",
+			fields: fields{
+				authData:    "invalid",
+				decoder:     mockApproveArticleDecoder,
+				validator:   mockSuccessRequestValidator,
+				articleRepo: mockInReviewArticleDetailer,
+				policy:      mockGrantAllPermissionChecker,
+				repo:        mockSuccessArticleTransitioner,
+			},
+			want: 400,
+		},
+		{
+			name: "Failed to decode json data",
+			fields: fields{
+				authData:    mockValidAuthData,
+				decoder:     mockFailedJsonDecoder,
+				validator:   mockSuccessRequestValidator,
+				articleRepo: mockInReviewArticleDetailer,
+				policy:      mockGrantAllPermissionChecker,
+				repo:        mockSuccessArticleTransitioner,
+			},
+			want: 400,
+		},
+		{
+			name: "Failed to validate data",
+			fields: fields{
+				authData:    mockValidAuthData,
+				decoder:     mockApproveArticleDecoder,
+				validator:   mockFailedRequestValidator,
+				articleRepo: mockInReviewArticleDetailer,
+				policy:      mockGrantAllPermissionChecker,
+				repo:        mockSuccessArticleTransitioner,
+			},
+			want: 400,
+		},
+		{
+			name: "Unknown transition",
+			fields: fields{
+				authData:    mockValidAuthData,
+				decoder:     mockUnknownTransitionDecoder,
+				validator:   mockSuccessRequestValidator,
+				articleRepo: mockInReviewArticleDetailer,
+				policy:      mockGrantAllPermissionChecker,
+				repo:        mockSuccessArticleTransitioner,
+			},
+			want: 400,
+		},
+		{
+			name: "Reject without comment",
+			fields: fields{
+				authData:    mockValidAuthData,
+				decoder:     mockRejectArticleDecoder,
+				validator:   mockSuccessRequestValidator,
+				articleRepo: mockInReviewArticleDetailer,
+				policy:      mockGrantAllPermissionChecker,
+				repo:        mockSuccessArticleTransitioner,
+			},
+			want: 400,
+		},
+		{
+			name: "Failed to get data",
+			fields: fields{
+				authData:    mockValidAuthData,
+				decoder:     mockApproveArticleDecoder,
+				validator:   mockSuccessRequestValidator,
+				articleRepo: mockFailedArticleDetailer,
+				policy:      mockGrantAllPermissionChecker,
+				repo:        mockSuccessArticleTransitioner,
+			},
+			want: 404,
+		},
+		{
+			name: "Writer approves article",
+			fields: fields{
+				authData:    mockValidAuthData,
+				decoder:     mockApproveArticleDecoder,
+				validator:   mockSuccessRequestValidator,
+				articleRepo: mockInReviewArticleDetailer,
+				policy:      mockWriterPermissionChecker,
+				repo:        mockSuccessArticleTransitioner,
+			},
+			want: 403,
+		},
+		{
+			name: "Writer submits own article",
+			fields: fields{
+				authData:    mockValidAuthData,
+				decoder:     mockSubmitArticleDecoder,
+				validator:   mockSuccessRequestValidator,
+				articleRepo: mockOwnDraftArticleDetailer,
+				policy:      mockWriterPermissionChecker,
+				repo:        mockSuccessArticleTransitioner,
+			},
+			want: 200,
+		},
+		{
+			name: "Writer submits article of another writer",
+			fields: fields{
+				authData:    mockValidAuthData,
+				decoder:     mockSubmitArticleDecoder,
+				validator:   mockSuccessRequestValidator,
+				articleRepo: mockDraftArticleDetailer,
+				policy:      mockWriterPermissionChecker,
+				repo:        mockSuccessArticleTransitioner,
+			},
+			want: 403,
+		},
+		{
+			name: "Transition not allowed from current status",
+			fields: fields{
+				authData:    mockValidAuthData,
+				decoder:     mockApproveArticleDecoder,
+				validator:   mockSuccessRequestValidator,
+				articleRepo: mockDraftArticleDetailer,
+				policy:      mockGrantAllPermissionChecker,
+				repo:        mockSuccessArticleTransitioner,
+			},
+			want: 409,
+		},
+		{
+			name: "Status changed concurrently",
+			fields: fields{
+				authData:    mockValidAuthData,
+				decoder:     mockApproveArticleDecoder,
+				validator:   mockSuccessRequestValidator,
+				articleRepo: mockInReviewArticleDetailer,
+				policy:      mockGrantAllPermissionChecker,
+				repo:        mockConflictArticleTransitioner,
+			},
+			want: 409,
+		},
+		{
+			name: "Locked by another user",
+			fields: fields{
+				authData:    mockValidAuthData,
+				decoder:     mockApproveArticleDecoder,
+				validator:   mockSuccessRequestValidator,
+				articleRepo: mockInReviewArticleDetailer,
+				policy:      mockGrantAllPermissionChecker,
+				repo:        mockSuccessArticleTransitioner,
+				locks:       mockOtherArticleLocker,
+			},
+			want: 423,
+		},
+		{
+			name: "Failed to save data",
+			fields: fields{
+				authData:    mockValidAuthData,
+				decoder:     mockApproveArticleDecoder,
+				validator:   mockSuccessRequestValidator,
+				articleRepo: mockInReviewArticleDetailer,
+				policy:      mockGrantAllPermissionChecker,
+				repo:        mockFailedArticleTransitioner,
+			},
+			want: 500,
+		},
+		{
+			name: "Locked while saving",
+			fields: fields{
+				authData:    mockValidAuthData,
+				decoder:     mockApproveArticleDecoder,
+				validator:   mockSuccessRequestValidator,
+				articleRepo: mockInReviewArticleDetailer,
+				policy:      mockGrantAllPermissionChecker,
+				repo:        mockLockedArticleTransitioner,
+			},
+			want: 423,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := NewTransitionArticleServices(
+				tt.fields.authData,
+				tt.fields.decoder,
+				tt.fields.validator,
+				tt.fields.articleRepo,
+				tt.fields.policy,
+				tt.fields.repo,
+				tt.fields.locks,
+			)
+			got, _ := svc.Transition("abc-123")
+			if got != tt.want {
+				t.Errorf("TransitionArticleServices.Transition() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestListArticleTransitionServices_List(t *testing.T) {
+	type fields struct {
+		authData    any
+		articleRepo mockArticleDetailer
+		policy      mockPermissionChecker
+	}
+	tests := []struct {
+		name      string
+		fields    fields
+		want      int
+		wantNames []string
+	}{
+		{
+			name: "Positive",
+			fields: fields{
+				authData:    mockValidAuthData,
+				articleRepo: mockInReviewArticleDetailer,
+				policy:      mockGrantAllPermissionChecker,
+			},
+			want:      200,
+			wantNames: []string{models.ArticleTransitionApprove, models.ArticleTransitionReject},
+		},
+		{
+			name: "Writer of a draft",
+			fields: fields{
+				authData:    mockValidAuthData,
+				articleRepo: mockOwnDraftArticleDetailer,
+				policy:      mockWriterPermissionChecker,
+			},
+			want:      200,
+			wantNames: []string{models.ArticleTransitionSubmit},
+		},
+		{
+			name: "Writer of an article in review",
+			fields: fields{
+				authData:    mockValidAuthData,
+				articleRepo: mockInReviewArticleDetailer,
+				policy:      mockWriterPermissionChecker,
+			},
+			want:      200,
+			wantNames: []string{},
+		},
+		{
+			name: "Failed to read authData",
+			fields: fields{
+				authData:    "invalid",
+				articleRepo: mockInReviewArticleDetailer,
+				policy:      mockGrantAllPermissionChecker,
+			},
+			want: 400,
+		},
+		{
+			name: "Failed to get data",
+			fields: fields{
+				authData:    mockValidAuthData,
+				articleRepo: mockFailedArticleDetailer,
+				policy:      mockGrantAllPermissionChecker,
+			},
+			want: 404,
+		},
+		{
+			name: "Not allowed to read article",
+			fields: fields{
+				authData:    mockValidAuthData,
+				articleRepo: mockInReviewArticleDetailer,
+				policy:      mockDenyAllPermissionChecker,
+			},
+			want: 403,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := NewListArticleTransitionServices(tt.fields.authData, tt.fields.articleRepo, tt.fields.policy)
+			got, res := svc.List("abc-123")
+			if got != tt.want {
+				t.Errorf("ListArticleTransitionServices.List() got = %v, want %v", got, tt.want)
+			}
+			if tt.wantNames == nil {
+				return
+			}
+			transitions, _ := res.Data.([]models.ArticleTransition)
+			if len(transitions) != len(tt.wantNames) {
+				t.Fatalf("ListArticleTransitionServices.List() got %d transitions, want %d", len(transitions), len(tt.wantNames))
+			}
+			for i, transition := range transitions {
+				if transition.Name != tt.wantNames[i] {
+					t.Errorf("ListArticleTransitionServices.List() got = %v, want %v", transition.Name, tt.wantNames[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,121 @@
+package services
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"net/url"
+
+	"github.com/herdiansc/go-cms/models"
+)
+
+// ArticleTrashLister defines trashed article lister function
+type ArticleTrashLister interface {
+	ListTrash(q url.Values, writerID int64) ([]models.Article, models.PageMeta, error)
+}
+
+// ListArticleTrashServices defines list trashed article service struct
+type ListArticleTrashServices struct {
+	authData any
+	checker  PermissionChecker
+	repo     ArticleTrashLister
+}
+
+// NewListArticleTrashServices inits ListArticleTrashServices
+func NewListArticleTrashServices(ad any, pc PermissionChecker, al ArticleTrashLister) ListArticleTrashServices {
+	return ListArticleTrashServices{
+		authData: ad,
+		checker:  pc,
+		repo:     al,
+	}
+}
+
+// List performs action of listing a page of trashed articles. Users allowed to delete any article see
+// the whole trash, the others only their own articles. An empty page is not an error.
+func (svc ListArticleTrashServices) List(q url.Values) (int, models.Response) {
+	authData, ok := svc.authData.(models.VerifyData)
+	if !ok {
+		log.Printf("Failed to read authData\n")
+		return http.StatusBadRequest, models.Response{Message: "error", Data: nil}
+	}
+
+	writerID := authData.ID
+	if can(svc.checker, authData, models.PermissionArticleDeleteAny) {
+		writerID = 0
+	}
+
+	data, meta, err := svc.repo.ListTrash(q, writerID)
+	if err != nil {
+		return listErrorResponse(err)
+	}
+	return http.StatusOK, models.Response{Message: "ok", Data: data, Meta: &meta}
+}
+
+// ArticleUndeleter defines function restoring an article from the trash
+type ArticleUndeleter interface {
+	Undelete(id int64, actor models.Actor) (models.Article, error)
+}
+
+// ArticleWithTrashedFinder defines function finding an article whether it is in the trash or not
+type ArticleWithTrashedFinder interface {
+	FindByParamWithTrashed(param string, value any) (models.Article, error)
+}
+
+// UndeleteArticleServices defines restore article from the trash service struct
+type UndeleteArticleServices struct {
+	authData    any
+	articleRepo ArticleWithTrashedFinder
+	policy      ArticlePolicy
+	repo        ArticleUndeleter
+	locks       ArticleLockFinder
+}
+
+// NewUndeleteArticleServices inits UndeleteArticleServices
+func NewUndeleteArticleServices(ad any, af ArticleWithTrashedFinder, pc PermissionChecker, au ArticleUndeleter, al ArticleLockFinder) UndeleteArticleServices {
+	return UndeleteArticleServices{
+		authData:    ad,
+		articleRepo: af,
+		policy:      NewArticlePolicy(pc),
+		repo:        au,
+		locks:       al,
+	}
+}
+
+// Undelete restores an article from the trash by uuid, users allowed to delete it may restore it
+func (svc UndeleteArticleServices) Undelete(uuid string) (int, models.Response) {
+	authData, ok := svc.authData.(models.VerifyData)
+	if !ok {
+		log.Printf("Failed to read authData\n")
+		return http.StatusBadRequest, models.Response{Message: "error", Data: nil}
+	}
+
+	article, err := svc.articleRepo.FindByParamWithTrashed("uuid", uuid)
+	if err != nil {
+		log.Printf("Failed to get data: %+v\n", err.Error())
+		return http.StatusNotFound, models.Response{Message: "not found", Data: err.Error()}
+	}
+
+	if code, res := svc.policy.Authorize(authData, article, ArticleActionDelete); code != http.StatusOK {
+		log.Printf("Failed to authorize: %+v\n", res.Data)
+		return code, res
+	}
+	if code, res := checkArticleLock(svc.locks, authData, article); code != http.StatusOK {
+		return code, res
+	}
+
+	if !article.DeletedAt.Valid {
+		log.Printf("Article %s is not in the trash\n", article.UUID)
+		return http.StatusConflict, models.Response{Message: "Conflict", Data: "article is not in the trash"}
+	}
+
+	undeleted, err := svc.repo.Undelete(article.ID, authData.Actor())
+	if errors.Is(err, models.ErrArticleLocked) {
+		return articleLockedMeanwhile(svc.locks, article)
+	}
+	if err != nil {
+		log.Printf("Failed to save data: %+v\n", err.Error())
+		return http.StatusInternalServerError, models.Response{Message: "Failed to save data", Data: err.Error()}
+	}
+
+	return http.StatusOK, models.Response{Message: "ok", Data: undeleted}
+}
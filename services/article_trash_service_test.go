@@ -0,0 +1,223 @@
+package services
+
+import (
+	"errors"
+	"net/url"
+	"testing"
+
+	"github.com/herdiansc/go-cms/models"
+	"github.com/herdiansc/go-cms/queryspec"
+)
+
+type mockArticleTrashLister struct {
+	d        []models.Article
+	e        error
+	writerID *int64
+}
+
+func (m mockArticleTrashLister) ListTrash(q url.Values, writerID int64) ([]models.Article, models.PageMeta, error) {
+	if m.writerID != nil {
+		*m.writerID = writerID
+	}
+	return m.d, models.PageMeta{Limit: 10}, m.e
+}
+
+func TestListArticleTrashServices_List(t *testing.T) {
+	type fields struct {
+		authData any
+		policy   mockPermissionChecker
+		repo     mockArticleTrashLister
+	}
+	tests := []struct {
+		name         string
+		fields       fields
+		want         int
+		wantWriterID int64
+	}{
+		{
+			name: "Positive: whole trash",
+			fields: fields{
+				authData: mockValidAuthData,
+				policy:   mockGrantAllPermissionChecker,
+				repo:     mockArticleTrashLister{d: []models.Article{{}}},
+			},
+			want:         200,
+			wantWriterID: 0,
+		},
+		{
+			name: "Positive: own trash of a writer",
+			fields: fields{
+				authData: mockValidAuthData,
+				policy:   mockWriterPermissionChecker,
+				repo:     mockArticleTrashLister{d: []models.Article{{}}},
+			},
+			want:         200,
+			wantWriterID: mockValidAuthData.ID,
+		},
+		{
+			name: "Failed to read authData",
+			fields: fields{
+				authData: "invalid",
+				policy:   mockGrantAllPermissionChecker,
+				repo:     mockArticleTrashLister{},
+			},
+			want: 400,
+		},
+		{
+			name: "Invalid query",
+			fields: fields{
+				authData: mockValidAuthData,
+				policy:   mockGrantAllPermissionChecker,
+				repo:     mockArticleTrashLister{e: queryspec.Error{Param: "deleted_at", Reason: "invalid time"}},
+			},
+			want: 400,
+		},
+		{
+			name: "Failed to get data",
+			fields: fields{
+				authData: mockValidAuthData,
+				policy:   mockGrantAllPermissionChecker,
+				repo:     mockArticleTrashLister{e: errors.New("error")},
+			},
+			want: 500,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var writerID int64 = -1
+			tt.fields.repo.writerID = &writerID
+			svc := NewListArticleTrashServices(tt.fields.authData, tt.fields.policy, tt.fields.repo)
+			got, _ := svc.List(url.Values{})
+			if got != tt.want {
+				t.Errorf("ListArticleTrashServices.List() got = %v, want %v", got, tt.want)
+			}
+			if got == 200 && writerID != tt.wantWriterID {
+				t.Errorf("ListArticleTrashServices.List() writerID = %v, want %v", writerID, tt.wantWriterID)
+			}
+		})
+	}
+}
+
+type mockArticleUndeleter struct {
+	e error
+}
+
+func (m mockArticleUndeleter) Undelete(id int64, actor models.Actor) (models.Article, error) {
+	return models.Article{}, m.e
+}
+
+func TestUndeleteArticleServices_Undelete(t *testing.T) {
+	type fields struct {
+		authData    any
+		articleRepo mockArticleTrashDetailer
+		policy      mockPermissionChecker
+		repo        mockArticleUndeleter
+		locks       mockArticleLocker
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		want   int
+	}{
+		{
+			name: "Positive",
+			fields: fields{
+				authData:    mockValidAuthData,
+				articleRepo: mockTrashedArticleTrashDetailer,
+				policy:      mockGrantAllPermissionChecker,
+				repo:        mockArticleUndeleter{},
+			},
+			want: 200,
+		},
+		{
+			name: "Writer restores own article",
+			fields: fields{
+				authData:    mockValidAuthData,
+				articleRepo: mockTrashedArticleTrashDetailer,
+				policy:      mockWriterPermissionChecker,
+				repo:        mockArticleUndeleter{},
+			},
+			want: 200,
+		},
+		{
+			name: "Failed to read authData",
+			fields: fields{
+				authData:    "invalid",
+				articleRepo: mockTrashedArticleTrashDetailer,
+				policy:      mockGrantAllPermissionChecker,
+				repo:        mockArticleUndeleter{},
+			},
+			want: 400,
+		},
+		{
+			name: "Failed to get data",
+			fields: fields{
+				authData:    mockValidAuthData,
+				articleRepo: mockFailedArticleTrashDetailer,
+				policy:      mockGrantAllPermissionChecker,
+				repo:        mockArticleUndeleter{},
+			},
+			want: 404,
+		},
+		{
+			name: "Not allowed",
+			fields: fields{
+				authData:    mockValidAuthData,
+				articleRepo: mockTrashedArticleTrashDetailer,
+				policy:      mockDenyAllPermissionChecker,
+				repo:        mockArticleUndeleter{},
+			},
+			want: 403,
+		},
+		{
+			name: "Not in the trash",
+			fields: fields{
+				authData:    mockValidAuthData,
+				articleRepo: mockSuccessArticleTrashDetailer,
+				policy:      mockGrantAllPermissionChecker,
+				repo:        mockArticleUndeleter{},
+			},
+			want: 409,
+		},
+		{
+			name: "Locked by another user",
+			fields: fields{
+				authData:    mockValidAuthData,
+				articleRepo: mockTrashedArticleTrashDetailer,
+				policy:      mockGrantAllPermissionChecker,
+				repo:        mockArticleUndeleter{},
+				locks:       mockOtherArticleLocker,
+			},
+			want: 423,
+		},
+		{
+			name: "Failed to save data",
+			fields: fields{
+				authData:    mockValidAuthData,
+				articleRepo: mockTrashedArticleTrashDetailer,
+				policy:      mockGrantAllPermissionChecker,
+				repo:        mockArticleUndeleter{e: errors.New("error")},
+			},
+			want: 500,
+		},
+		{
+			name: "Locked while saving",
+			fields: fields{
+				authData:    mockValidAuthData,
+				articleRepo: mockTrashedArticleTrashDetailer,
+				policy:      mockGrantAllPermissionChecker,
+				repo:        mockArticleUndeleter{e: models.ErrArticleLocked},
+			},
+			want: 423,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := NewUndeleteArticleServices(tt.fields.authData, tt.fields.articleRepo, tt.fields.policy, tt.fields.repo, tt.fields.locks)
+			got, _ := svc.Undelete("abc-123")
+			if got != tt.want {
+				t.Errorf("UndeleteArticleServices.Undelete() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package services
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/herdiansc/go-cms/models"
+)
+
+// PermissionChecker defines permission checker function
+type PermissionChecker interface {
+	HasPermission(roleName, permission string) (bool, error)
+}
+
+// AuthorizationServices defines authorization service struct
+type AuthorizationServices struct {
+	authData any
+	repo     PermissionChecker
+}
+
+// NewAuthorizationServices inits AuthorizationServices
+func NewAuthorizationServices(ad any, pc PermissionChecker) AuthorizationServices {
+	return AuthorizationServices{
+		authData: ad,
+		repo:     pc,
+	}
+}
+
+// Authorize checks that the currently logged in user is granted the permission
+func (svc AuthorizationServices) Authorize(permission string) (int, models.Response) {
+	authData, ok := svc.authData.(models.VerifyData)
+	if !ok {
+		log.Printf("Failed to read authData\n")
+		return http.StatusBadRequest, models.Response{Message: "error", Data: nil}
+	}
+
+	if !can(svc.repo, authData, permission) {
+		return http.StatusForbidden, models.Response{Message: "Forbidden", Data: "missing permission " + permission}
+	}
+
+	return http.StatusOK, models.Response{Message: "ok", Data: nil}
+}
+
+// can reports whether the role of authData is granted the permission, failing closed on errors
+func can(pc PermissionChecker, authData models.VerifyData, permission string) bool {
+	granted, err := pc.HasPermission(authData.RoleName, permission)
+	if err != nil {
+		log.Printf("Failed to check permission: %+v\n", err.Error())
+		return false
+	}
+	return granted
+}
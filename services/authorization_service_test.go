@@ -0,0 +1,105 @@
+package services
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/herdiansc/go-cms/models"
+)
+
+type mockPermissionChecker struct {
+	granted map[string]bool
+	e       error
+}
+
+func (m mockPermissionChecker) HasPermission(roleName, permission string) (bool, error) {
+	return m.granted[permission], m.e
+}
+
+var (
+	mockGrantAllPermissionChecker = mockPermissionChecker{
+		granted: map[string]bool{
+			models.PermissionArticleRead:      true,
+			models.PermissionArticleCreate:    true,
+			models.PermissionArticleUpdateOwn: true,
+			models.PermissionArticleUpdateAny: true,
+			models.PermissionArticleDeleteOwn: true,
+			models.PermissionArticleDeleteAny: true,
+			models.PermissionArticlePurge:     true,
+			models.PermissionArticleUnlock:    true,
+			models.PermissionArticleReview:    true,
+			models.PermissionArticlePublish:   true,
+			models.PermissionTagManage:        true,
+			models.PermissionUserManage:       true,
+		},
+	}
+	mockWriterPermissionChecker = mockPermissionChecker{
+		granted: map[string]bool{
+			models.PermissionArticleRead:      true,
+			models.PermissionArticleCreate:    true,
+			models.PermissionArticleUpdateOwn: true,
+			models.PermissionArticleDeleteOwn: true,
+		},
+	}
+	mockDenyAllPermissionChecker = mockPermissionChecker{
+		granted: map[string]bool{},
+	}
+	mockFailedPermissionChecker = mockPermissionChecker{
+		granted: map[string]bool{},
+		e:       errors.New("error"),
+	}
+)
+
+func TestAuthorizationServices_Authorize(t *testing.T) {
+	type fields struct {
+		authData any
+		repo     mockPermissionChecker
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		want   int
+	}{
+		{
+			name: "Positive",
+			fields: fields{
+				authData: mockValidAuthData,
+				repo:     mockGrantAllPermissionChecker,
+			},
+			want: 200,
+		},
+		{
+			name: "Failed to read authData",
+			fields: fields{
+				authData: "invalid",
+				repo:     mockGrantAllPermissionChecker,
+			},
+			want: 400,
+		},
+		{
+			name: "Permission not granted",
+			fields: fields{
+				authData: mockValidAuthData,
+				repo:     mockDenyAllPermissionChecker,
+			},
+			want: 403,
+		},
+		{
+			name: "Failed to check permission",
+			fields: fields{
+				authData: mockValidAuthData,
+				repo:     mockFailedPermissionChecker,
+			},
+			want: 403,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := NewAuthorizationServices(tt.fields.authData, tt.fields.repo)
+			got, _ := svc.Authorize(models.PermissionArticleCreate)
+			if got != tt.want {
+				t.Errorf("AuthorizationServices.Authorize() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,275 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/herdiansc/go-cms/models"
+)
+
+// diffContextLines is the number of unchanged lines shown around changes in a unified diff
+const diffContextLines = 3
+
+// edit is a step of an edit script, aPos and bPos count the lines of a and b consumed before it
+type edit struct {
+	op   string
+	text string
+	aPos int
+	bPos int
+}
+
+// splitLines splits text into lines, an empty text has no lines
+func splitLines(text string) []string {
+	if text == "" {
+		return []string{}
+	}
+	return strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+}
+
+// maxDiffLines caps the number of differing lines, left once the common prefix and suffix are trimmed,
+// that are compared to find the shortest edit script. Longer inputs are diffed as replaced in whole
+// so diffing two large versions cannot take unbounded time.
+const maxDiffLines = 10000
+
+// editScript computes the shortest edit script turning a into b with the linear space variant of
+// Myers' algorithm
+func editScript(a, b []string) []edit {
+	d := differ{a: a, b: b, edits: make([]edit, 0, len(a)+len(b))}
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+	if len(a)+len(b)-2*(prefix+suffix) > maxDiffLines {
+		d.equal(0, prefix, 0)
+		d.replace(prefix, len(a)-suffix, prefix, len(b)-suffix)
+		d.equal(len(a)-suffix, len(a), len(b)-suffix)
+		return d.edits
+	}
+	d.compare(0, len(a), 0, len(b))
+	return d.edits
+}
+
+// differ builds the edit script turning a into b
+type differ struct {
+	a, b  []string
+	edits []edit
+}
+
+// equal appends the lines a[aLo:aHi] as equal to the lines of b from bLo
+func (d *differ) equal(aLo, aHi, bLo int) {
+	for i := aLo; i < aHi; i++ {
+		d.edits = append(d.edits, edit{op: models.DiffEqual, text: d.a[i], aPos: i, bPos: bLo + i - aLo})
+	}
+}
+
+// replace appends the lines a[aLo:aHi] as deleted and then the lines b[bLo:bHi] as inserted
+func (d *differ) replace(aLo, aHi, bLo, bHi int) {
+	for i := aLo; i < aHi; i++ {
+		d.edits = append(d.edits, edit{op: models.DiffDelete, text: d.a[i], aPos: i, bPos: bLo})
+	}
+	for j := bLo; j < bHi; j++ {
+		d.edits = append(d.edits, edit{op: models.DiffInsert, text: d.b[j], aPos: aHi, bPos: j})
+	}
+}
+
+// compare appends the shortest edit script turning a[aLo:aHi] into b[bLo:bHi]. It splits the
+// ranges at the middle snake of an optimal path and recurses on both halves.
+func (d *differ) compare(aLo, aHi, bLo, bHi int) {
+	start := aLo
+	for aLo < aHi && bLo < bHi && d.a[aLo] == d.b[bLo] {
+		aLo++
+		bLo++
+	}
+	d.equal(start, aLo, bLo-(aLo-start))
+
+	end := aHi
+	for aLo < aHi && bLo < bHi && d.a[aHi-1] == d.b[bHi-1] {
+		aHi--
+		bHi--
+	}
+
+	if aLo == aHi || bLo == bHi {
+		d.replace(aLo, aHi, bLo, bHi)
+	} else {
+		x, y := d.middleSnake(aLo, aHi, bLo, bHi)
+		d.compare(aLo, x, bLo, y)
+		d.compare(x, aHi, y, bHi)
+	}
+
+	d.equal(aHi, end, bHi)
+}
+
+// middleSnake searches an optimal path through a[aLo:aHi] and b[bLo:bHi] from both ends at once
+// and returns where the forward and backward searches meet. The ranges must both be non-empty and
+// differ in their first and last lines, so the point splits them into strictly smaller problems.
+func (d *differ) middleSnake(aLo, aHi, bLo, bHi int) (int, int) {
+	n, m := aHi-aLo, bHi-bLo
+	delta := n - m
+	odd := delta%2 != 0
+	limit := (n + m + 1) / 2
+	offset := limit + 1
+	// forward[offset+k] is the furthest x reached on diagonal k = x - y from the start, backward[offset+c]
+	// the furthest x reached on diagonal c from the end, with x and y counted backwards
+	forward := make([]int, 2*limit+3)
+	backward := make([]int, 2*limit+3)
+
+	for step := 0; step <= limit; step++ {
+		for k := -step; k <= step; k += 2 {
+			var x int
+			if k == -step || (k != step && forward[offset+k-1] < forward[offset+k+1]) {
+				x = forward[offset+k+1]
+			} else {
+				x = forward[offset+k-1] + 1
+			}
+			y := x - k
+			startX, startY := x, y
+			for x < n && y < m && d.a[aLo+x] == d.b[bLo+y] {
+				x++
+				y++
+			}
+			forward[offset+k] = x
+			if c := delta - k; odd && c >= -(step-1) && c <= step-1 && x+backward[offset+c] >= n {
+				return aLo + startX, bLo + startY
+			}
+		}
+		for c := -step; c <= step; c += 2 {
+			var x int
+			if c == -step || (c != step && backward[offset+c-1] < backward[offset+c+1]) {
+				x = backward[offset+c+1]
+			} else {
+				x = backward[offset+c-1] + 1
+			}
+			y := x - c
+			for x < n && y < m && d.a[aHi-1-x] == d.b[bHi-1-y] {
+				x++
+				y++
+			}
+			backward[offset+c] = x
+			if k := delta - c; !odd && k >= -step && k <= step && x+forward[offset+k] >= n {
+				return aHi - x, bHi - y
+			}
+		}
+	}
+	panic("diff: no middle snake")
+}
+
+// diffLines builds a line-level diff from the edit script of two texts. A run of deleted lines directly
+// followed by inserted lines is treated as lines changed in place and also gets a word-level diff.
+func diffLines(edits []edit) []models.DiffLine {
+	lines := make([]models.DiffLine, len(edits))
+	for i, e := range edits {
+		lines[i] = models.DiffLine{Op: e.op, Text: e.text}
+	}
+
+	for i := 0; i < len(lines); {
+		if lines[i].Op != models.DiffDelete {
+			i++
+			continue
+		}
+		deleted := i
+		for i < len(lines) && lines[i].Op == models.DiffDelete {
+			i++
+		}
+		inserted := i
+		for i < len(lines) && lines[i].Op == models.DiffInsert {
+			i++
+		}
+		for k := 0; k < inserted-deleted && inserted+k < i; k++ {
+			words := diffWords(lines[deleted+k].Text, lines[inserted+k].Text)
+			lines[deleted+k].Words = filterSegments(words, models.DiffInsert)
+			lines[inserted+k].Words = filterSegments(words, models.DiffDelete)
+		}
+	}
+	return lines
+}
+
+// diffWords computes a word-level diff of two lines, merging consecutive words with the same operation
+func diffWords(from, to string) []models.DiffSegment {
+	var segments []models.DiffSegment
+	for _, e := range editScript(strings.Fields(from), strings.Fields(to)) {
+		if n := len(segments); n > 0 && segments[n-1].Op == e.op {
+			segments[n-1].Text += " " + e.text
+			continue
+		}
+		segments = append(segments, models.DiffSegment{Op: e.op, Text: e.text})
+	}
+	return segments
+}
+
+// filterSegments drops the segments with operation op
+func filterSegments(segments []models.DiffSegment, op string) []models.DiffSegment {
+	filtered := make([]models.DiffSegment, 0, len(segments))
+	for _, segment := range segments {
+		if segment.Op != op {
+			filtered = append(filtered, segment)
+		}
+	}
+	return filtered
+}
+
+// unifiedDiff renders the edit script of two texts in unified format, name is used as the file name.
+// It returns an empty string when the texts are equal.
+func unifiedDiff(name string, edits []edit) string {
+	var changes []int
+	for i, e := range edits {
+		if e.op != models.DiffEqual {
+			changes = append(changes, i)
+		}
+	}
+	if len(changes) == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "--- a/%s\n+++ b/%s\n", name, name)
+	for start := 0; start < len(changes); {
+		end := start
+		for end+1 < len(changes) && changes[end+1]-changes[end] <= 2*diffContextLines+1 {
+			end++
+		}
+		first := max(changes[start]-diffContextLines, 0)
+		last := min(changes[end]+diffContextLines, len(edits)-1)
+		writeHunk(&sb, edits[first:last+1])
+		start = end + 1
+	}
+	return sb.String()
+}
+
+// writeHunk writes a hunk header and its lines
+func writeHunk(sb *strings.Builder, hunk []edit) {
+	aCount, bCount := 0, 0
+	for _, e := range hunk {
+		if e.op != models.DiffInsert {
+			aCount++
+		}
+		if e.op != models.DiffDelete {
+			bCount++
+		}
+	}
+	fmt.Fprintf(sb, "@@ -%s +%s @@\n", hunkRange(hunk[0].aPos, aCount), hunkRange(hunk[0].bPos, bCount))
+	for _, e := range hunk {
+		prefix := " "
+		switch e.op {
+		case models.DiffDelete:
+			prefix = "-"
+		case models.DiffInsert:
+			prefix = "+"
+		}
+		sb.WriteString(prefix + e.text + "\n")
+	}
+}
+
+// hunkRange formats the start and length of a hunk side, an empty side starts at the line before it
+func hunkRange(pos, count int) string {
+	if count == 0 {
+		return fmt.Sprintf("%d,0", pos)
+	}
+	if count == 1 {
+		return fmt.Sprintf("%d", pos+1)
+	}
+	return fmt.Sprintf("%d,%d", pos+1, count)
+}
@@ -1,16 +1,20 @@
 package services
 
 import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
 	"log"
 	"net/http"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/herdiansc/go-cms/config"
 	"github.com/herdiansc/go-cms/models"
 )
 
-var secretKey = []byte("jwt-secret-key")
-
 // AuthFinder defines auth finder function
 type AuthFinder interface {
 	FindByUsername(msisdn string) (models.Auth, error)
@@ -21,6 +25,11 @@ type PasswordComparer interface {
 	VerifyPassword(password, hash string) bool
 }
 
+// RefreshTokenCreator defines refresh token creator function
+type RefreshTokenCreator interface {
+	CreateRefreshToken(data models.RefreshToken) error
+}
+
 // Signer defines signer func
 type Signer func(method jwt.SigningMethod, claim jwt.Claims, opts ...jwt.TokenOption) *jwt.Token
 
@@ -30,17 +39,21 @@ type LoginServices struct {
 	validator    RequestValidator
 	hashComparer PasswordComparer
 	signer       Signer
+	keys         config.KeySet
 	repo         AuthFinder
+	tokenRepo    RefreshTokenCreator
 }
 
 // NewLoginServices inits LoginServices
-func NewLoginServices(jd JsonDecoder, rv RequestValidator, ph PasswordComparer, s Signer, ac AuthFinder) LoginServices {
+func NewLoginServices(jd JsonDecoder, rv RequestValidator, ph PasswordComparer, s Signer, ks config.KeySet, ac AuthFinder, tr RefreshTokenCreator) LoginServices {
 	return LoginServices{
 		decoder:      jd,
 		validator:    rv,
 		hashComparer: ph,
 		signer:       s,
+		keys:         ks,
 		repo:         ac,
+		tokenRepo:    tr,
 	}
 }
 
@@ -69,15 +82,72 @@ func (svc LoginServices) Login() (int, models.Response) {
 		return http.StatusUnauthorized, models.Response{Message: "Login failed", Data: nil}
 	}
 
-	tokenString, err := svc.signer(jwt.SigningMethodHS256, jwt.MapClaims{
-		"id":       auth.ID,
-		"username": auth.Username,
-		"exp":      time.Now().Add(time.Hour * 24 * 30).Unix(),
-	}).SignedString(secretKey)
+	tokenString, err := signAccessToken(svc.signer, svc.keys, auth)
+	if err != nil {
+		log.Printf("Failed to create jwt: %+v\n", err.Error())
+		return http.StatusInternalServerError, models.Response{Message: "Login failed", Data: err.Error()}
+	}
+
+	refreshToken, refreshTokenHash, err := newRefreshToken()
+	if err != nil {
+		log.Printf("Failed to create refresh token: %+v\n", err.Error())
+		return http.StatusInternalServerError, models.Response{Message: "Login failed", Data: err.Error()}
+	}
+	err = svc.tokenRepo.CreateRefreshToken(models.RefreshToken{
+		AuthID:    auth.ID,
+		FamilyID:  uuid.NewString(),
+		TokenHash: refreshTokenHash,
+		ExpiresAt: time.Now().Add(refreshTokenTTL()),
+	})
 	if err != nil {
-		log.Printf("Failed to create jwt: %+v\n", isSimilar)
+		log.Printf("Failed to save refresh token: %+v\n", err.Error())
 		return http.StatusInternalServerError, models.Response{Message: "Login failed", Data: err.Error()}
 	}
 
-	return http.StatusOK, models.Response{Message: "ok", Data: map[string]string{"token": tokenString}}
+	return http.StatusOK, models.Response{Message: "ok", Data: models.TokenResponse{
+		Token:        tokenString,
+		RefreshToken: refreshToken,
+		ExpiresIn:    int64(accessTokenTTL().Seconds()),
+	}}
+}
+
+// accessTokenTTL returns lifetime of an access token
+func accessTokenTTL() time.Duration {
+	return config.GetPositiveDuration("ACCESS_TOKEN_TTL", 15*time.Minute)
+}
+
+// refreshTokenTTL returns lifetime of a refresh token
+func refreshTokenTTL() time.Duration {
+	return config.GetPositiveDuration("REFRESH_TOKEN_TTL", 30*24*time.Hour)
+}
+
+// signAccessToken signs a short-lived access token carrying a unique jti with the active key
+func signAccessToken(signer Signer, keys config.KeySet, auth models.Auth) (string, error) {
+	now := time.Now()
+	token := signer(keys.Active.Method, jwt.MapClaims{
+		"uuid":     auth.UUID,
+		"username": auth.Username,
+		"role":     auth.RoleName,
+		"jti":      uuid.NewString(),
+		"iat":      now.Unix(),
+		"exp":      now.Add(accessTokenTTL()).Unix(),
+	})
+	token.Header["kid"] = keys.Active.ID
+	return token.SignedString(keys.Active.SignKey)
+}
+
+// newRefreshToken generates an opaque refresh token and the hash stored for it
+func newRefreshToken() (string, string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", err
+	}
+	token := base64.RawURLEncoding.EncodeToString(b)
+	return token, hashRefreshToken(token), nil
+}
+
+// hashRefreshToken hashes a refresh token so the plain value is never stored
+func hashRefreshToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
 }
@@ -25,6 +25,14 @@ func (m mockAuthFinder) FindByUsername(username string) (models.Auth, error) {
 	return m.a, m.e
 }
 
+type mockRefreshTokenCreator struct {
+	e error
+}
+
+func (m mockRefreshTokenCreator) CreateRefreshToken(data models.RefreshToken) error {
+	return m.e
+}
+
 type mockSigner func(method jwt.SigningMethod, claim jwt.Claims, opts ...jwt.TokenOption) *jwt.Token
 
 var (
@@ -42,6 +50,12 @@ var (
 		a: models.Auth{},
 		e: errors.New("error"),
 	}
+	mockSuccessRefreshTokenCreator = mockRefreshTokenCreator{
+		e: nil,
+	}
+	mockFailedRefreshTokenCreator = mockRefreshTokenCreator{
+		e: errors.New("error"),
+	}
 )
 
 func TestLoginService_Login(t *testing.T) {
@@ -51,6 +65,7 @@ func TestLoginService_Login(t *testing.T) {
 		validator mockRequestValidator
 		comparer  mockPasswordComparer
 		repo      mockAuthFinder
+		tokenRepo mockRefreshTokenCreator
 		signer    Signer
 		want      int
 	}{
@@ -60,6 +75,7 @@ func TestLoginService_Login(t *testing.T) {
 			validator: mockSuccessRequestValidator,
 			comparer:  mockSuccessPasswordComparer,
 			repo:      mockSuccessAuthFinder,
+			tokenRepo: mockSuccessRefreshTokenCreator,
 			signer:    jwt.NewWithClaims,
 			want:      200,
 		},
@@ -69,6 +85,7 @@ func TestLoginService_Login(t *testing.T) {
 			validator: mockSuccessRequestValidator,
 			comparer:  mockSuccessPasswordComparer,
 			repo:      mockSuccessAuthFinder,
+			tokenRepo: mockSuccessRefreshTokenCreator,
 			signer:    jwt.NewWithClaims,
 			want:      400,
 		},
@@ -78,6 +95,7 @@ func TestLoginService_Login(t *testing.T) {
 			validator: mockFailedRequestValidator,
 			comparer:  mockSuccessPasswordComparer,
 			repo:      mockSuccessAuthFinder,
+			tokenRepo: mockSuccessRefreshTokenCreator,
 			signer:    jwt.NewWithClaims,
 			want:      400,
 		},
@@ -87,6 +105,7 @@ func TestLoginService_Login(t *testing.T) {
 			validator: mockSuccessRequestValidator,
 			comparer:  mockSuccessPasswordComparer,
 			repo:      mockFailedAuthFinder,
+			tokenRepo: mockSuccessRefreshTokenCreator,
 			signer:    jwt.NewWithClaims,
 			want:      404,
 		},
@@ -96,14 +115,25 @@ func TestLoginService_Login(t *testing.T) {
 			validator: mockSuccessRequestValidator,
 			comparer:  mockFailedPasswordComparer,
 			repo:      mockSuccessAuthFinder,
+			tokenRepo: mockSuccessRefreshTokenCreator,
 			signer:    jwt.NewWithClaims,
 			want:      401,
 		},
+		{
+			name:      "Negative: Failed to save refresh token",
+			dec:       mockSuccessJsonDecoder,
+			validator: mockSuccessRequestValidator,
+			comparer:  mockSuccessPasswordComparer,
+			repo:      mockSuccessAuthFinder,
+			tokenRepo: mockFailedRefreshTokenCreator,
+			signer:    jwt.NewWithClaims,
+			want:      500,
+		},
 	}
 
 	for _, tt := range cases {
 		t.Run(tt.name, func(t *testing.T) {
-			svc := NewLoginServices(tt.dec, tt.validator, tt.comparer, tt.signer, tt.repo)
+			svc := NewLoginServices(tt.dec, tt.validator, tt.comparer, tt.signer, mockKeySet, tt.repo, tt.tokenRepo)
 			code, _ := svc.Login()
 			if code != tt.want {
 				t.Errorf("Expected resp to be %q but it was %q", tt.want, code)
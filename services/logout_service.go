@@ -0,0 +1,65 @@
+package services
+
+import (
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/herdiansc/go-cms/models"
+)
+
+// TokenRevoker defines token revoker function
+type TokenRevoker interface {
+	RevokeAccessToken(jti string, expiresAt time.Time) error
+	RevokeRefreshFamily(authID int64, hash string) error
+}
+
+// LogoutServices defines logout service struct
+type LogoutServices struct {
+	authData any
+	decoder  JsonDecoder
+	repo     TokenRevoker
+}
+
+// NewLogoutServices inits LogoutServices
+func NewLogoutServices(ad any, jd JsonDecoder, tr TokenRevoker) LogoutServices {
+	return LogoutServices{
+		authData: ad,
+		decoder:  jd,
+		repo:     tr,
+	}
+}
+
+// Logout revokes the current access token and, when given, the family of the refresh token
+func (svc LogoutServices) Logout() (int, models.Response) {
+	authData, ok := svc.authData.(models.VerifyData)
+	if !ok {
+		log.Printf("Failed to read authData\n")
+		return http.StatusBadRequest, models.Response{Message: "error", Data: nil}
+	}
+
+	var data models.LogoutRequest
+	err := svc.decoder.Decode(&data)
+	if err != nil && !errors.Is(err, io.EOF) {
+		log.Printf("Failed to decode json data: %+v\n", err.Error())
+		return http.StatusBadRequest, models.Response{Message: "Bad Request", Data: err.Error()}
+	}
+
+	err = svc.repo.RevokeAccessToken(authData.JTI, time.Unix(authData.ExpiresAt, 0))
+	if err != nil {
+		log.Printf("Failed to revoke token: %+v\n", err.Error())
+		return http.StatusInternalServerError, models.Response{Message: "Logout failed", Data: err.Error()}
+	}
+
+	if data.RefreshToken != "" {
+		err = svc.repo.RevokeRefreshFamily(authData.ID, hashRefreshToken(data.RefreshToken))
+		if err != nil {
+			log.Printf("Failed to revoke refresh token: %+v\n", err.Error())
+			return http.StatusInternalServerError, models.Response{Message: "Logout failed", Data: err.Error()}
+		}
+	}
+
+	return http.StatusOK, models.Response{Message: "ok", Data: nil}
+}
@@ -0,0 +1,124 @@
+package services
+
+import (
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/herdiansc/go-cms/models"
+)
+
+type mockTokenRevoker struct {
+	accessErr  error
+	refreshErr error
+}
+
+func (m mockTokenRevoker) RevokeAccessToken(jti string, expiresAt time.Time) error {
+	return m.accessErr
+}
+
+func (m mockTokenRevoker) RevokeRefreshFamily(authID int64, hash string) error {
+	return m.refreshErr
+}
+
+type mockRefreshTokenDecoder struct {
+	err error
+}
+
+func (m mockRefreshTokenDecoder) Decode(v any) error {
+	if m.err != nil {
+		return m.err
+	}
+	if data, ok := v.(*models.LogoutRequest); ok {
+		data.RefreshToken = "refresh-token"
+	}
+	return nil
+}
+
+var (
+	mockSuccessTokenRevoker = mockTokenRevoker{}
+	mockFailedAccessRevoker = mockTokenRevoker{
+		accessErr: errors.New("error"),
+	}
+	mockFailedRefreshRevoker = mockTokenRevoker{
+		refreshErr: errors.New("error"),
+	}
+)
+
+func TestLogoutServices_Logout(t *testing.T) {
+	type fields struct {
+		authData any
+		decoder  JsonDecoder
+		repo     mockTokenRevoker
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		want   int
+	}{
+		{
+			name: "Positive",
+			fields: fields{
+				authData: mockValidAuthData,
+				decoder:  mockRefreshTokenDecoder{},
+				repo:     mockSuccessTokenRevoker,
+			},
+			want: 200,
+		},
+		{
+			name: "Positive: Empty body",
+			fields: fields{
+				authData: mockValidAuthData,
+				decoder:  mockRefreshTokenDecoder{err: io.EOF},
+				repo:     mockSuccessTokenRevoker,
+			},
+			want: 200,
+		},
+		{
+			name: "Failed to read authData",
+			fields: fields{
+				authData: "invalid",
+				decoder:  mockRefreshTokenDecoder{},
+				repo:     mockSuccessTokenRevoker,
+			},
+			want: 400,
+		},
+		{
+			name: "Failed to decode json data",
+			fields: fields{
+				authData: mockValidAuthData,
+				decoder:  mockFailedJsonDecoder,
+				repo:     mockSuccessTokenRevoker,
+			},
+			want: 400,
+		},
+		{
+			name: "Failed to revoke access token",
+			fields: fields{
+				authData: mockValidAuthData,
+				decoder:  mockRefreshTokenDecoder{},
+				repo:     mockFailedAccessRevoker,
+			},
+			want: 500,
+		},
+		{
+			name: "Failed to revoke refresh token",
+			fields: fields{
+				authData: mockValidAuthData,
+				decoder:  mockRefreshTokenDecoder{},
+				repo:     mockFailedRefreshRevoker,
+			},
+			want: 500,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := NewLogoutServices(tt.fields.authData, tt.fields.decoder, tt.fields.repo)
+			got, _ := svc.Logout()
+			if got != tt.want {
+				t.Errorf("LogoutServices.Logout() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package services
+
+import (
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/herdiansc/go-cms/models"
+)
+
+// articlePrecondition reads the version an If-Match header expects an article to be at, nil for any
+// version when the header is * or left out. A missing header is refused with 428 when required and
+// a header which is not the ETag of an article with 412.
+func articlePrecondition(ifMatch string, required bool) (*int64, int, models.Response) {
+	ifMatch = strings.TrimSpace(ifMatch)
+	if ifMatch == "" {
+		if required {
+			log.Printf("Missing If-Match\n")
+			return nil, http.StatusPreconditionRequired, models.Response{Message: "Precondition Required", Data: "the If-Match header is required"}
+		}
+		return nil, http.StatusOK, models.Response{Message: "ok", Data: nil}
+	}
+	if ifMatch == "*" {
+		return nil, http.StatusOK, models.Response{Message: "ok", Data: nil}
+	}
+
+	version, ok := models.ParseArticleETag(ifMatch)
+	if !ok {
+		log.Printf("Invalid If-Match: %s\n", ifMatch)
+		return nil, http.StatusPreconditionFailed, models.Response{Message: "Precondition Failed", Data: "If-Match is not the ETag of an article"}
+	}
+	return &version, http.StatusOK, models.Response{Message: "ok", Data: nil}
+}
+
+// checkArticleVersion returns 200 when article is at version, nil for any version, and a 412 response otherwise
+func checkArticleVersion(article models.Article, version *int64) (int, models.Response) {
+	if version != nil && article.Version != *version {
+		return articleVersionMismatch(article.UUID)
+	}
+	return http.StatusOK, models.Response{Message: "ok", Data: nil}
+}
+
+// articleVersionMismatch is the response to a change based on a stale version of an article
+func articleVersionMismatch(uuid string) (int, models.Response) {
+	log.Printf("Article %s changed since the expected version\n", uuid)
+	return http.StatusPreconditionFailed, models.Response{Message: "Precondition Failed", Data: models.ErrArticleVersionMismatch.Error()}
+}
@@ -0,0 +1,98 @@
+package services
+
+import (
+	"log"
+	"net/http"
+	"net/url"
+
+	"github.com/herdiansc/go-cms/models"
+)
+
+// PublishedArticleLister defines published article lister function
+type PublishedArticleLister interface {
+	ListPublished(q url.Values) ([]models.Article, models.PageMeta, error)
+}
+
+// ListPublicArticleServices defines public article list service struct, it needs no authentication
+type ListPublicArticleServices struct {
+	repo PublishedArticleLister
+}
+
+// NewListPublicArticleServices inits ListPublicArticleServices
+func NewListPublicArticleServices(al PublishedArticleLister) ListPublicArticleServices {
+	return ListPublicArticleServices{
+		repo: al,
+	}
+}
+
+// List performs action of listing a page of published articles without their internal fields
+func (svc ListPublicArticleServices) List(q url.Values) (int, models.Response) {
+	data, meta, err := svc.repo.ListPublished(q)
+	if err != nil {
+		return listErrorResponse(err)
+	}
+
+	articles := make([]models.PublicArticle, len(data))
+	for i, article := range data {
+		articles[i] = article.Public()
+	}
+	return http.StatusOK, models.Response{Message: "ok", Data: articles, Meta: &meta}
+}
+
+// PublishedArticleFinder defines published article finder function
+type PublishedArticleFinder interface {
+	FindPublishedBySlug(slug string) (models.Article, error)
+}
+
+// DetailPublicArticleServices defines public article detail service struct, it needs no authentication
+type DetailPublicArticleServices struct {
+	repo PublishedArticleFinder
+}
+
+// NewDetailPublicArticleServices inits DetailPublicArticleServices
+func NewDetailPublicArticleServices(af PublishedArticleFinder) DetailPublicArticleServices {
+	return DetailPublicArticleServices{
+		repo: af,
+	}
+}
+
+// GetDetailBySlug gets detail of a published article by slug without its internal fields. A former slug
+// of the article is answered with 301 Moved Permanently and its current slug.
+func (svc DetailPublicArticleServices) GetDetailBySlug(slug string) (int, models.Response) {
+	data, err := svc.repo.FindPublishedBySlug(slug)
+	if err != nil {
+		log.Printf("Failed to get data: %+v\n", err.Error())
+		return http.StatusNotFound, models.Response{Message: "not found", Data: nil}
+	}
+	if data.Slug != slug {
+		return http.StatusMovedPermanently, models.Response{Message: "moved permanently", Data: models.MovedArticle{Slug: data.Slug}}
+	}
+
+	return http.StatusOK, models.Response{Message: "ok", Data: data.Public()}
+}
+
+// PublishedTagLister defines published tag lister function
+type PublishedTagLister interface {
+	ListPublished(q url.Values) ([]models.PublicTag, models.PageMeta, error)
+}
+
+// ListPublicTagServices defines public tag list service struct, it needs no authentication
+type ListPublicTagServices struct {
+	repo PublishedTagLister
+}
+
+// NewListPublicTagServices inits ListPublicTagServices
+func NewListPublicTagServices(tl PublishedTagLister) ListPublicTagServices {
+	return ListPublicTagServices{
+		repo: tl,
+	}
+}
+
+// List performs action of listing a page of the tags of published articles
+func (svc ListPublicTagServices) List(q url.Values) (int, models.Response) {
+	data, meta, err := svc.repo.ListPublished(q)
+	if err != nil {
+		return listErrorResponse(err)
+	}
+	return http.StatusOK, models.Response{Message: "ok", Data: data, Meta: &meta}
+}
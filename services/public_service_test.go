@@ -0,0 +1,170 @@
+package services
+
+import (
+	"errors"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/herdiansc/go-cms/models"
+	"github.com/herdiansc/go-cms/queryspec"
+)
+
+type mockPublishedArticleLister struct {
+	d []models.Article
+	e error
+}
+
+func (m mockPublishedArticleLister) ListPublished(q url.Values) ([]models.Article, models.PageMeta, error) {
+	return m.d, models.PageMeta{Limit: 10}, m.e
+}
+
+var mockPublishedArticle = models.Article{
+	Base:     models.Base{ID: 7, PublicBase: models.PublicBase{CreatedAt: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)}},
+	Title:    "Go",
+	Content:  "content",
+	Status:   models.ArticleStatusPublished,
+	WriterID: 3,
+	Slug:     "go",
+	Tags:     []string{"golang"},
+}
+
+func TestListPublicArticleServices_List(t *testing.T) {
+	tests := []struct {
+		name     string
+		repo     mockPublishedArticleLister
+		want     int
+		wantData []models.PublicArticle
+	}{
+		{
+			name: "Positive",
+			repo: mockPublishedArticleLister{d: []models.Article{mockPublishedArticle}},
+			want: 200,
+			wantData: []models.PublicArticle{
+				{Title: "Go", Slug: "go", Content: "content", Tags: []string{"golang"}, CreatedAt: mockPublishedArticle.CreatedAt},
+			},
+		},
+		{
+			name:     "Empty page",
+			repo:     mockPublishedArticleLister{d: []models.Article{}},
+			want:     200,
+			wantData: []models.PublicArticle{},
+		},
+		{
+			name: "Invalid query",
+			repo: mockPublishedArticleLister{e: queryspec.Error{Param: "writer_id", Reason: "unknown filter"}},
+			want: 400,
+		},
+		{
+			name: "Failed to get data",
+			repo: mockPublishedArticleLister{e: errors.New("error")},
+			want: 500,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := NewListPublicArticleServices(tt.repo)
+			got, res := svc.List(url.Values{})
+			if got != tt.want {
+				t.Errorf("ListPublicArticleServices.List() got = %v, want %v", got, tt.want)
+			}
+			if tt.wantData != nil && !reflect.DeepEqual(res.Data, tt.wantData) {
+				t.Errorf("ListPublicArticleServices.List() data = %+v, want %+v", res.Data, tt.wantData)
+			}
+		})
+	}
+}
+
+type mockPublishedArticleFinder struct {
+	d models.Article
+	e error
+}
+
+func (m mockPublishedArticleFinder) FindPublishedBySlug(slug string) (models.Article, error) {
+	return m.d, m.e
+}
+
+func TestDetailPublicArticleServices_GetDetailBySlug(t *testing.T) {
+	tests := []struct {
+		name string
+		repo mockPublishedArticleFinder
+		slug string
+		want int
+	}{
+		{
+			name: "Positive",
+			repo: mockPublishedArticleFinder{d: mockPublishedArticle},
+			want: 200,
+		},
+		{
+			name: "Former slug",
+			repo: mockPublishedArticleFinder{d: mockPublishedArticle},
+			slug: "golang",
+			want: 301,
+		},
+		{
+			name: "Not found",
+			repo: mockPublishedArticleFinder{e: errors.New("record not found")},
+			want: 404,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			slug := tt.slug
+			if slug == "" {
+				slug = "go"
+			}
+			svc := NewDetailPublicArticleServices(tt.repo)
+			got, res := svc.GetDetailBySlug(slug)
+			if got != tt.want {
+				t.Errorf("DetailPublicArticleServices.GetDetailBySlug() got = %v, want %v", got, tt.want)
+			}
+			if _, ok := res.Data.(models.Article); ok {
+				t.Errorf("DetailPublicArticleServices.GetDetailBySlug() exposes the internal article")
+			}
+		})
+	}
+}
+
+type mockPublishedTagLister struct {
+	d []models.PublicTag
+	e error
+}
+
+func (m mockPublishedTagLister) ListPublished(q url.Values) ([]models.PublicTag, models.PageMeta, error) {
+	return m.d, models.PageMeta{Limit: 10}, m.e
+}
+
+func TestListPublicTagServices_List(t *testing.T) {
+	tests := []struct {
+		name string
+		repo mockPublishedTagLister
+		want int
+	}{
+		{
+			name: "Positive",
+			repo: mockPublishedTagLister{d: []models.PublicTag{{Title: "golang", UsageCount: 2}}},
+			want: 200,
+		},
+		{
+			name: "Invalid query",
+			repo: mockPublishedTagLister{e: queryspec.Error{Param: "id", Reason: "unknown filter"}},
+			want: 400,
+		},
+		{
+			name: "Failed to get data",
+			repo: mockPublishedTagLister{e: errors.New("error")},
+			want: 500,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := NewListPublicTagServices(tt.repo)
+			got, _ := svc.List(url.Values{})
+			if got != tt.want {
+				t.Errorf("ListPublicTagServices.List() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,21 @@
+package services
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/herdiansc/go-cms/models"
+	"github.com/herdiansc/go-cms/queryspec"
+)
+
+// listErrorResponse maps an error of a list query to a response, invalid query params are a bad request
+func listErrorResponse(err error) (int, models.Response) {
+	var queryErr queryspec.Error
+	if errors.As(err, &queryErr) {
+		log.Printf("Invalid query: %+v\n", queryErr.Error())
+		return http.StatusBadRequest, models.Response{Message: "Bad Request", Data: queryErr.Error()}
+	}
+	log.Printf("Failed to get data: %+v\n", err.Error())
+	return http.StatusInternalServerError, models.Response{Message: "Failed to get data", Data: err.Error()}
+}
@@ -0,0 +1,89 @@
+package services
+
+import (
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/herdiansc/go-cms/config"
+	"github.com/herdiansc/go-cms/models"
+)
+
+// RefreshTokenRotator defines refresh token rotator function
+type RefreshTokenRotator interface {
+	RotateRefreshToken(hash string, next models.RefreshToken) (models.RefreshToken, error)
+}
+
+// AuthIDFinder defines auth finder by id function
+type AuthIDFinder interface {
+	FindByID(id int64) (models.Auth, error)
+}
+
+// RefreshTokenServices defines refresh token service struct
+type RefreshTokenServices struct {
+	decoder   JsonDecoder
+	validator RequestValidator
+	signer    Signer
+	keys      config.KeySet
+	tokenRepo RefreshTokenRotator
+	repo      AuthIDFinder
+}
+
+// NewRefreshTokenServices inits RefreshTokenServices
+func NewRefreshTokenServices(jd JsonDecoder, rv RequestValidator, s Signer, ks config.KeySet, tr RefreshTokenRotator, af AuthIDFinder) RefreshTokenServices {
+	return RefreshTokenServices{
+		decoder:   jd,
+		validator: rv,
+		signer:    s,
+		keys:      ks,
+		tokenRepo: tr,
+		repo:      af,
+	}
+}
+
+// Refresh exchanges a refresh token for a new access token and a rotated refresh token
+func (svc RefreshTokenServices) Refresh() (int, models.Response) {
+	var data models.RefreshTokenRequest
+	err := svc.decoder.Decode(&data)
+	if err != nil {
+		return http.StatusBadRequest, models.Response{Message: "Bad Request", Data: err.Error()}
+	}
+	err = svc.validator.Struct(data)
+	if err != nil {
+		log.Printf("Failed to validate data: %+v\n", err.Error())
+		return http.StatusBadRequest, models.Response{Message: "Bad Request", Data: err.Error()}
+	}
+
+	refreshToken, refreshTokenHash, err := newRefreshToken()
+	if err != nil {
+		log.Printf("Failed to create refresh token: %+v\n", err.Error())
+		return http.StatusInternalServerError, models.Response{Message: "Refresh failed", Data: err.Error()}
+	}
+
+	current, err := svc.tokenRepo.RotateRefreshToken(hashRefreshToken(data.RefreshToken), models.RefreshToken{
+		TokenHash: refreshTokenHash,
+		ExpiresAt: time.Now().Add(refreshTokenTTL()),
+	})
+	if err != nil {
+		log.Printf("Failed to rotate refresh token: %+v\n", err.Error())
+		return http.StatusUnauthorized, models.Response{Message: "Invalid refresh token", Data: nil}
+	}
+
+	auth, err := svc.repo.FindByID(current.AuthID)
+	if err != nil {
+		log.Printf("Failed to get data: %+v\n", err.Error())
+		return http.StatusUnauthorized, models.Response{Message: "Invalid refresh token", Data: nil}
+	}
+
+	tokenString, err := signAccessToken(svc.signer, svc.keys, auth)
+	if err != nil {
+		log.Printf("Failed to create jwt: %+v\n", err.Error())
+		return http.StatusInternalServerError, models.Response{Message: "Refresh failed", Data: err.Error()}
+	}
+
+	return http.StatusOK, models.Response{Message: "ok", Data: models.TokenResponse{
+		Token:        tokenString,
+		RefreshToken: refreshToken,
+		ExpiresIn:    int64(accessTokenTTL().Seconds()),
+	}}
+}
@@ -0,0 +1,108 @@
+package services
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/herdiansc/go-cms/models"
+)
+
+type mockRefreshTokenRotator struct {
+	d models.RefreshToken
+	e error
+}
+
+func (m mockRefreshTokenRotator) RotateRefreshToken(hash string, next models.RefreshToken) (models.RefreshToken, error) {
+	return m.d, m.e
+}
+
+type mockAuthIDFinder struct {
+	a models.Auth
+	e error
+}
+
+func (m mockAuthIDFinder) FindByID(id int64) (models.Auth, error) {
+	return m.a, m.e
+}
+
+var (
+	mockSuccessRefreshTokenRotator = mockRefreshTokenRotator{
+		d: models.RefreshToken{AuthID: 1},
+		e: nil,
+	}
+	mockFailedRefreshTokenRotator = mockRefreshTokenRotator{
+		d: models.RefreshToken{},
+		e: errors.New("error"),
+	}
+	mockSuccessAuthIDFinder = mockAuthIDFinder{
+		a: models.Auth{},
+		e: nil,
+	}
+	mockFailedAuthIDFinder = mockAuthIDFinder{
+		a: models.Auth{},
+		e: errors.New("error"),
+	}
+)
+
+func TestRefreshTokenServices_Refresh(t *testing.T) {
+	cases := []struct {
+		name      string
+		dec       mockJsonDecoder
+		validator mockRequestValidator
+		tokenRepo mockRefreshTokenRotator
+		repo      mockAuthIDFinder
+		want      int
+	}{
+		{
+			name:      "Positive",
+			dec:       mockSuccessJsonDecoder,
+			validator: mockSuccessRequestValidator,
+			tokenRepo: mockSuccessRefreshTokenRotator,
+			repo:      mockSuccessAuthIDFinder,
+			want:      200,
+		},
+		{
+			name:      "Negative: Failed decode body",
+			dec:       mockFailedJsonDecoder,
+			validator: mockSuccessRequestValidator,
+			tokenRepo: mockSuccessRefreshTokenRotator,
+			repo:      mockSuccessAuthIDFinder,
+			want:      400,
+		},
+		{
+			name:      "Negative: Failed to validate request",
+			dec:       mockSuccessJsonDecoder,
+			validator: mockFailedRequestValidator,
+			tokenRepo: mockSuccessRefreshTokenRotator,
+			repo:      mockSuccessAuthIDFinder,
+			want:      400,
+		},
+		{
+			name:      "Negative: Failed to rotate refresh token",
+			dec:       mockSuccessJsonDecoder,
+			validator: mockSuccessRequestValidator,
+			tokenRepo: mockFailedRefreshTokenRotator,
+			repo:      mockSuccessAuthIDFinder,
+			want:      401,
+		},
+		{
+			name:      "Negative: Failed find auth",
+			dec:       mockSuccessJsonDecoder,
+			validator: mockSuccessRequestValidator,
+			tokenRepo: mockSuccessRefreshTokenRotator,
+			repo:      mockFailedAuthIDFinder,
+			want:      401,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := NewRefreshTokenServices(tt.dec, tt.validator, jwt.NewWithClaims, mockKeySet, tt.tokenRepo, tt.repo)
+			code, _ := svc.Refresh()
+			if code != tt.want {
+				t.Errorf("Expected resp to be %q but it was %q", tt.want, code)
+			}
+		})
+	}
+}
@@ -3,6 +3,7 @@ package services
 import (
 	"log"
 	"net/http"
+	"slices"
 
 	"github.com/herdiansc/go-cms/models"
 )
@@ -58,6 +59,10 @@ func (svc RegistrationServices) Register() (int, models.Response) {
 		return http.StatusBadRequest, models.Response{Message: "Bad Request", Data: err.Error()}
 	}
 	authData := data.Auth()
+	if !slices.Contains(models.SelfRegistrableRoles, authData.RoleName) {
+		log.Printf("Role %s cannot be self-assigned\n", authData.RoleName)
+		return http.StatusForbidden, models.Response{Message: "Forbidden", Data: "role " + authData.RoleName + " cannot be self-assigned"}
+	}
 	authData.Password, err = svc.hasher.HashPassword(data.Password)
 	if err != nil {
 		log.Printf("Failed to hash password: %+v\n", err.Error())
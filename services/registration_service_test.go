@@ -18,6 +18,14 @@ func (m mockJsonDecoder) Decode(v any) error {
 	return m.err
 }
 
+type mockBodyDecoder struct {
+	body string
+}
+
+func (m mockBodyDecoder) Decode(v any) error {
+	return json.Unmarshal([]byte(m.body), v)
+}
+
 type mockRequestValidator struct {
 	err error
 }
@@ -91,7 +99,7 @@ var (
 func TestRegistrationService_Register(t *testing.T) {
 	cases := []struct {
 		name      string
-		dec       mockJsonDecoder
+		dec       JsonDecoder
 		validator mockRequestValidator
 		hasher    mockPasswordHasher
 		repo      mockAuthCreator
@@ -137,6 +145,22 @@ func TestRegistrationService_Register(t *testing.T) {
 			repo:      mockFailedAuthCreator,
 			want:      500,
 		},
+		{
+			name:      "Positive: Self-assign viewer role",
+			dec:       mockBodyDecoder{body: `{"username":"herdiansc","password":"adalah","role":"VIEWER"}`},
+			validator: mockSuccessRequestValidator,
+			hasher:    mockSuccessPasswordHasher,
+			repo:      mockSuccessAuthCreator,
+			want:      201,
+		},
+		{
+			name:      "Negative: Self-assign privileged role",
+			dec:       mockBodyDecoder{body: `{"username":"herdiansc","password":"adalah","role":"ADMIN"}`},
+			validator: mockSuccessRequestValidator,
+			hasher:    mockSuccessPasswordHasher,
+			repo:      mockSuccessAuthCreator,
+			want:      403,
+		},
 	}
 
 	for _, tt := range cases {
@@ -1,9 +1,13 @@
 package services
 
 import (
+	"errors"
+	"fmt"
 	"log"
 	"net/http"
 	"net/url"
+	"strconv"
+	"strings"
 
 	"github.com/herdiansc/go-cms/models"
 )
@@ -18,15 +22,17 @@ type CreateTagServices struct {
 	authData  any
 	decoder   JsonDecoder
 	validator RequestValidator
+	tagRepo   TagDetailer
 	repo      TagCreator
 }
 
 // NewCreateTagServices inits CreateTagServices
-func NewCreateTagServices(ad any, jd JsonDecoder, rv RequestValidator, ac TagCreator) CreateTagServices {
+func NewCreateTagServices(ad any, jd JsonDecoder, rv RequestValidator, td TagDetailer, ac TagCreator) CreateTagServices {
 	return CreateTagServices{
 		authData:  ad,
 		decoder:   jd,
 		validator: rv,
+		tagRepo:   td,
 		repo:      ac,
 	}
 }
@@ -52,7 +58,21 @@ func (svc CreateTagServices) Create() (int, models.Response) {
 		return http.StatusBadRequest, models.Response{Message: "Bad Request", Data: err.Error()}
 	}
 
-	tag, err := svc.repo.Create(data.Tag())
+	tag := data.Tag()
+	if data.ParentUUID != nil {
+		parent, err := svc.tagRepo.FindByParam("uuid", *data.ParentUUID)
+		if err != nil {
+			log.Printf("Failed to get parent data: %+v\n", err.Error())
+			return http.StatusNotFound, models.Response{Message: "parent not found", Data: err.Error()}
+		}
+		tag.ParentID = &parent.Tag.ID
+	}
+
+	tag, err = svc.repo.Create(tag)
+	if errors.Is(err, models.ErrTagParentNotFound) {
+		log.Printf("Failed to save data: %+v\n", err.Error())
+		return http.StatusNotFound, models.Response{Message: "parent not found", Data: err.Error()}
+	}
 	if err != nil {
 		log.Printf("Failed to save data: %+v\n", err.Error())
 		return http.StatusInternalServerError, models.Response{Message: "Failed to save data", Data: err.Error()}
@@ -63,7 +83,7 @@ func (svc CreateTagServices) Create() (int, models.Response) {
 
 // TagLister defines tag lister function
 type TagLister interface {
-	List(params map[string]interface{}) ([]models.TagListItem, error)
+	List(q url.Values) ([]models.TagListItem, models.PageMeta, error)
 }
 
 // ListTagServices defines list tag service struct
@@ -80,7 +100,7 @@ func NewListTagServices(ad any, al TagLister) ListTagServices {
 	}
 }
 
-// List performs action of listing tags
+// List performs action of listing a page of tags, an empty page is not an error
 func (svc ListTagServices) List(q url.Values) (int, models.Response) {
 	_, ok := svc.authData.(models.VerifyData)
 	if !ok {
@@ -88,19 +108,139 @@ func (svc ListTagServices) List(q url.Values) (int, models.Response) {
 		return http.StatusBadRequest, models.Response{Message: "error", Data: nil}
 	}
 
-	params := make(map[string]interface{})
-	for k, v := range q {
-		params[k] = v[0]
+	data, meta, err := svc.repo.List(q)
+	if err != nil {
+		return listErrorResponse(err)
 	}
-	data, _ := svc.repo.List(params)
-	if len(data) == 0 {
-		log.Printf("Failed to get data")
-		return http.StatusNotFound, models.Response{Message: "Not found", Data: nil}
+	return http.StatusOK, models.Response{Message: "ok", Data: data, Meta: &meta}
+}
+
+// Limits of tag suggestions
+const (
+	defaultSuggestLimit = 10
+	maxSuggestLimit     = 50
+)
+
+// TagSuggester defines tag suggester function
+type TagSuggester interface {
+	Suggest(q string, limit int) ([]models.TagSuggestion, error)
+}
+
+// SuggestTagServices defines suggest tag service struct
+type SuggestTagServices struct {
+	authData any
+	repo     TagSuggester
+}
+
+// NewSuggestTagServices inits SuggestTagServices
+func NewSuggestTagServices(ad any, ts TagSuggester) SuggestTagServices {
+	return SuggestTagServices{
+		authData: ad,
+		repo:     ts,
+	}
+}
+
+// Suggest performs action of suggesting tags for the q param, limited by the limit param
+func (svc SuggestTagServices) Suggest(q url.Values) (int, models.Response) {
+	_, ok := svc.authData.(models.VerifyData)
+	if !ok {
+		log.Printf("Failed to read authData\n")
+		return http.StatusBadRequest, models.Response{Message: "error", Data: nil}
+	}
+
+	term := models.NormalizeTagTitle(q.Get("q"))
+	if term == "" {
+		log.Printf("Empty suggest query\n")
+		return http.StatusBadRequest, models.Response{Message: "Bad Request", Data: "q is required"}
+	}
+
+	limit := defaultSuggestLimit
+	if raw := q.Get("limit"); raw != "" {
+		v, err := strconv.Atoi(raw)
+		if err != nil || v < 1 || v > maxSuggestLimit {
+			log.Printf("Invalid suggest limit: %s\n", raw)
+			return http.StatusBadRequest, models.Response{Message: "Bad Request", Data: fmt.Sprintf("limit must be between 1 and %d", maxSuggestLimit)}
+		}
+		limit = v
+	}
+
+	data, err := svc.repo.Suggest(term, limit)
+	if err != nil {
+		log.Printf("Failed to get data: %+v\n", err.Error())
+		return http.StatusInternalServerError, models.Response{Message: "Failed to get data", Data: err.Error()}
 	}
 
 	return http.StatusOK, models.Response{Message: "ok", Data: data}
 }
 
+// TagTreeLister defines tag tree lister function
+type TagTreeLister interface {
+	All() ([]models.TagListItem, error)
+}
+
+// TreeTagServices defines tag tree service struct
+type TreeTagServices struct {
+	authData any
+	repo     TagTreeLister
+}
+
+// NewTreeTagServices inits TreeTagServices
+func NewTreeTagServices(ad any, tl TagTreeLister) TreeTagServices {
+	return TreeTagServices{
+		authData: ad,
+		repo:     tl,
+	}
+}
+
+// Tree performs action of listing all tags as a tree of categories, root categories first
+func (svc TreeTagServices) Tree() (int, models.Response) {
+	_, ok := svc.authData.(models.VerifyData)
+	if !ok {
+		log.Printf("Failed to read authData\n")
+		return http.StatusBadRequest, models.Response{Message: "error", Data: nil}
+	}
+
+	data, err := svc.repo.All()
+	if err != nil {
+		log.Printf("Failed to get data: %+v\n", err.Error())
+		return http.StatusInternalServerError, models.Response{Message: "Failed to get data", Data: err.Error()}
+	}
+
+	return http.StatusOK, models.Response{Message: "ok", Data: tagTree(data)}
+}
+
+// tagTree nests tags under their parents keeping their order, tags whose parent is not listed are roots
+func tagTree(tags []models.TagListItem) []models.TagNode {
+	listed := make(map[int64]bool, len(tags))
+	for _, tag := range tags {
+		listed[tag.ID] = true
+	}
+	children := make(map[int64][]models.TagListItem)
+	var roots []models.TagListItem
+	for _, tag := range tags {
+		if tag.ParentID != nil && listed[*tag.ParentID] {
+			children[*tag.ParentID] = append(children[*tag.ParentID], tag)
+		} else {
+			roots = append(roots, tag)
+		}
+	}
+
+	var nodes func(tags []models.TagListItem) []models.TagNode
+	nodes = func(tags []models.TagListItem) []models.TagNode {
+		result := make([]models.TagNode, len(tags))
+		for i, tag := range tags {
+			result[i] = models.TagNode{
+				UUID:       tag.UUID,
+				Title:      tag.Title,
+				UsageCount: tag.UsageCount,
+				Children:   nodes(children[tag.ID]),
+			}
+		}
+		return result
+	}
+	return nodes(roots)
+}
+
 // TagDetailer defines tag detailer function
 type TagDetailer interface {
 	FindByParam(param string, value any) (models.TagDetail, error)
@@ -121,13 +261,13 @@ func NewDetailTagServices(ad any, al TagDetailer) DetailTagServices {
 }
 
 // GetDetailByUUID gets detail of a tag by uuid
-func (svc DetailTagServices) GetDetailByUUID(id int64) (int, models.Response) {
+func (svc DetailTagServices) GetDetailByUUID(uuid string) (int, models.Response) {
 	_, ok := svc.authData.(models.VerifyData)
 	if !ok {
 		log.Printf("Failed to read authData\n")
 		return http.StatusBadRequest, models.Response{Message: "error", Data: nil}
 	}
-	data, err := svc.repo.FindByParam("id", id)
+	data, err := svc.repo.FindByParam("uuid", uuid)
 	if err != nil {
 		log.Printf("Failed to get data: %+v\n", err.Error())
 		return http.StatusNotFound, models.Response{Message: "not found", Data: err.Error()}
@@ -135,3 +275,272 @@ func (svc DetailTagServices) GetDetailByUUID(id int64) (int, models.Response) {
 
 	return http.StatusOK, models.Response{Message: "ok", Data: data}
 }
+
+// TagRenamer defines tag renamer function
+type TagRenamer interface {
+	Rename(id int64, title string) (models.Tag, error)
+}
+
+// RenameTagServices defines rename tag service struct
+type RenameTagServices struct {
+	authData  any
+	decoder   JsonDecoder
+	validator RequestValidator
+	tagRepo   TagDetailer
+	repo      TagRenamer
+}
+
+// NewRenameTagServices inits RenameTagServices
+func NewRenameTagServices(ad any, jd JsonDecoder, rv RequestValidator, td TagDetailer, tr TagRenamer) RenameTagServices {
+	return RenameTagServices{
+		authData:  ad,
+		decoder:   jd,
+		validator: rv,
+		tagRepo:   td,
+		repo:      tr,
+	}
+}
+
+// Rename performs action of renaming a tag, the title is lower-cased like on create
+func (svc RenameTagServices) Rename(uuid string) (int, models.Response) {
+	_, ok := svc.authData.(models.VerifyData)
+	if !ok {
+		log.Printf("Failed to read authData\n")
+		return http.StatusBadRequest, models.Response{Message: "error", Data: nil}
+	}
+
+	var data models.RenameTagRequest
+	err := svc.decoder.Decode(&data)
+	if err != nil {
+		log.Printf("Failed to decode json data: %+v\n", err.Error())
+		return http.StatusBadRequest, models.Response{Message: "Bad Request", Data: err.Error()}
+	}
+
+	err = svc.validator.Struct(data)
+	if err != nil {
+		log.Printf("Failed to validate data: %+v\n", err.Error())
+		return http.StatusBadRequest, models.Response{Message: "Bad Request", Data: err.Error()}
+	}
+	title := models.NormalizeTagTitle(data.Title)
+	if title == "" {
+		log.Printf("Empty tag title\n")
+		return http.StatusBadRequest, models.Response{Message: "Bad Request", Data: "title cannot be empty"}
+	}
+
+	current, err := svc.tagRepo.FindByParam("uuid", uuid)
+	if err != nil {
+		log.Printf("Failed to get data: %+v\n", err.Error())
+		return http.StatusNotFound, models.Response{Message: "not found", Data: err.Error()}
+	}
+
+	tag, err := svc.repo.Rename(current.Tag.ID, title)
+	if errors.Is(err, models.ErrTagTitleTaken) {
+		log.Printf("Failed to rename tag: %+v\n", err.Error())
+		return http.StatusConflict, models.Response{Message: "Conflict", Data: err.Error()}
+	}
+	if err != nil {
+		log.Printf("Failed to save data: %+v\n", err.Error())
+		return http.StatusInternalServerError, models.Response{Message: "Failed to save data", Data: err.Error()}
+	}
+
+	return http.StatusOK, models.Response{Message: "ok", Data: tag}
+}
+
+// TagMerger defines tag merger function
+type TagMerger interface {
+	Merge(id int64, targetID int64, actor models.Actor) (models.Tag, error)
+}
+
+// MergeTagServices defines merge tag service struct
+type MergeTagServices struct {
+	authData  any
+	decoder   JsonDecoder
+	validator RequestValidator
+	tagRepo   TagDetailer
+	repo      TagMerger
+}
+
+// NewMergeTagServices inits MergeTagServices
+func NewMergeTagServices(ad any, jd JsonDecoder, rv RequestValidator, td TagDetailer, tm TagMerger) MergeTagServices {
+	return MergeTagServices{
+		authData:  ad,
+		decoder:   jd,
+		validator: rv,
+		tagRepo:   td,
+		repo:      tm,
+	}
+}
+
+// Merge performs action of merging a tag into the target tag of the request, the tag is deleted.
+// Each article of the tag gets a new history version.
+func (svc MergeTagServices) Merge(uuid string) (int, models.Response) {
+	authData, ok := svc.authData.(models.VerifyData)
+	if !ok {
+		log.Printf("Failed to read authData\n")
+		return http.StatusBadRequest, models.Response{Message: "error", Data: nil}
+	}
+
+	var data models.MergeTagRequest
+	err := svc.decoder.Decode(&data)
+	if err != nil {
+		log.Printf("Failed to decode json data: %+v\n", err.Error())
+		return http.StatusBadRequest, models.Response{Message: "Bad Request", Data: err.Error()}
+	}
+
+	err = svc.validator.Struct(data)
+	if err != nil {
+		log.Printf("Failed to validate data: %+v\n", err.Error())
+		return http.StatusBadRequest, models.Response{Message: "Bad Request", Data: err.Error()}
+	}
+	if strings.EqualFold(data.TargetUUID, uuid) {
+		log.Printf("Merging tag %s into itself\n", uuid)
+		return http.StatusBadRequest, models.Response{Message: "Bad Request", Data: "cannot merge a tag into itself"}
+	}
+
+	current, err := svc.tagRepo.FindByParam("uuid", uuid)
+	if err != nil {
+		log.Printf("Failed to get data: %+v\n", err.Error())
+		return http.StatusNotFound, models.Response{Message: "not found", Data: err.Error()}
+	}
+	target, err := svc.tagRepo.FindByParam("uuid", data.TargetUUID)
+	if err != nil {
+		log.Printf("Failed to get target data: %+v\n", err.Error())
+		return http.StatusNotFound, models.Response{Message: "target not found", Data: err.Error()}
+	}
+
+	tag, err := svc.repo.Merge(current.Tag.ID, target.Tag.ID, authData.Actor())
+	if errors.Is(err, models.ErrTagMergeConflict) {
+		log.Printf("Failed to merge data: %+v\n", err.Error())
+		return http.StatusConflict, models.Response{Message: "Conflict", Data: err.Error()}
+	}
+	if err != nil {
+		log.Printf("Failed to merge data: %+v\n", err.Error())
+		return http.StatusInternalServerError, models.Response{Message: "Failed to merge tag", Data: err.Error()}
+	}
+
+	return http.StatusOK, models.Response{Message: "ok", Data: tag}
+}
+
+// TagMover defines tag mover function
+type TagMover interface {
+	Move(id int64, parentID *int64) (models.Tag, error)
+}
+
+// MoveTagServices defines move tag service struct
+type MoveTagServices struct {
+	authData any
+	decoder  JsonDecoder
+	tagRepo  TagDetailer
+	repo     TagMover
+}
+
+// NewMoveTagServices inits MoveTagServices
+func NewMoveTagServices(ad any, jd JsonDecoder, td TagDetailer, tm TagMover) MoveTagServices {
+	return MoveTagServices{
+		authData: ad,
+		decoder:  jd,
+		tagRepo:  td,
+		repo:     tm,
+	}
+}
+
+// Move performs action of moving a tag under the parent of the request, its subcategories move along
+func (svc MoveTagServices) Move(uuid string) (int, models.Response) {
+	_, ok := svc.authData.(models.VerifyData)
+	if !ok {
+		log.Printf("Failed to read authData\n")
+		return http.StatusBadRequest, models.Response{Message: "error", Data: nil}
+	}
+
+	var data models.MoveTagRequest
+	err := svc.decoder.Decode(&data)
+	if err != nil {
+		log.Printf("Failed to decode json data: %+v\n", err.Error())
+		return http.StatusBadRequest, models.Response{Message: "Bad Request", Data: err.Error()}
+	}
+
+	current, err := svc.tagRepo.FindByParam("uuid", uuid)
+	if err != nil {
+		log.Printf("Failed to get data: %+v\n", err.Error())
+		return http.StatusNotFound, models.Response{Message: "not found", Data: err.Error()}
+	}
+
+	var parentID *int64
+	if data.ParentUUID != nil {
+		parent, err := svc.tagRepo.FindByParam("uuid", *data.ParentUUID)
+		if err != nil {
+			log.Printf("Failed to get parent data: %+v\n", err.Error())
+			return http.StatusNotFound, models.Response{Message: "parent not found", Data: err.Error()}
+		}
+		parentID = &parent.Tag.ID
+	}
+
+	tag, err := svc.repo.Move(current.Tag.ID, parentID)
+	if errors.Is(err, models.ErrTagParentNotFound) {
+		log.Printf("Failed to move tag %s: %+v\n", uuid, err.Error())
+		return http.StatusNotFound, models.Response{Message: "parent not found", Data: err.Error()}
+	}
+	if errors.Is(err, models.ErrTagCycle) {
+		log.Printf("Failed to move tag %s: %+v\n", uuid, err.Error())
+		return http.StatusConflict, models.Response{Message: "Conflict", Data: err.Error()}
+	}
+	if err != nil {
+		log.Printf("Failed to save data: %+v\n", err.Error())
+		return http.StatusInternalServerError, models.Response{Message: "Failed to move tag", Data: err.Error()}
+	}
+
+	return http.StatusOK, models.Response{Message: "ok", Data: tag}
+}
+
+// TagRemover defines tag remover function
+type TagRemover interface {
+	Delete(id int64, force bool, actor models.Actor) error
+}
+
+// DeleteTagServices defines delete tag service struct
+type DeleteTagServices struct {
+	authData any
+	tagRepo  TagDetailer
+	repo     TagRemover
+}
+
+// NewDeleteTagServices inits DeleteTagServices
+func NewDeleteTagServices(ad any, td TagDetailer, tr TagRemover) DeleteTagServices {
+	return DeleteTagServices{
+		authData: ad,
+		tagRepo:  td,
+		repo:     tr,
+	}
+}
+
+// Delete performs action of deleting a tag. A tag still used by articles is only deleted, and removed
+// from them with a new history version of each, when force is set.
+func (svc DeleteTagServices) Delete(uuid string, force bool) (int, models.Response) {
+	authData, ok := svc.authData.(models.VerifyData)
+	if !ok {
+		log.Printf("Failed to read authData\n")
+		return http.StatusBadRequest, models.Response{Message: "error", Data: nil}
+	}
+
+	tag, err := svc.tagRepo.FindByParam("uuid", uuid)
+	if err != nil {
+		log.Printf("Failed to get data: %+v\n", err.Error())
+		return http.StatusNotFound, models.Response{Message: "not found", Data: err.Error()}
+	}
+	if tag.UsageCount > 0 && !force {
+		log.Printf("Failed to delete tag %s: in use\n", uuid)
+		return http.StatusConflict, models.Response{Message: "Conflict", Data: fmt.Sprintf("tag is used by %d articles, delete with force=true to remove it from them", tag.UsageCount)}
+	}
+
+	err = svc.repo.Delete(tag.Tag.ID, force, authData.Actor())
+	if errors.Is(err, models.ErrTagInUse) {
+		log.Printf("Failed to delete tag %s: in use\n", uuid)
+		return http.StatusConflict, models.Response{Message: "Conflict", Data: err.Error()}
+	}
+	if err != nil {
+		log.Printf("Failed to delete data: %+v\n", err.Error())
+		return http.StatusInternalServerError, models.Response{Message: "Failed to delete tag", Data: err.Error()}
+	}
+
+	return http.StatusOK, models.Response{Message: "ok"}
+}
@@ -3,9 +3,11 @@ package services
 import (
 	"errors"
 	"net/url"
+	"reflect"
 	"testing"
 
 	"github.com/herdiansc/go-cms/models"
+	"github.com/herdiansc/go-cms/queryspec"
 )
 
 type mockTagCreator struct {
@@ -31,13 +33,18 @@ var (
 		d: models.Tag{},
 		e: errors.New("error"),
 	}
+	mockOrphanTagCreator = mockTagCreator{
+		d: models.Tag{},
+		e: models.ErrTagParentNotFound,
+	}
 )
 
 func TestCreateTagServices_Create(t *testing.T) {
 	type fields struct {
 		authData  any
-		decoder   mockJsonDecoder
+		decoder   JsonDecoder
 		validator mockRequestValidator
+		tagRepo   mockTagDetailer
 		repo      mockTagCreator
 	}
 	tests := []struct {
@@ -51,6 +58,7 @@ func TestCreateTagServices_Create(t *testing.T) {
 				authData:  mockValidAuthData,
 				decoder:   mockSuccessJsonDecoder,
 				validator: mockSuccessRequestValidator,
+				tagRepo:   mockSuccessTagDetailer,
 				repo:      mockSuccessTagCreator,
 			},
 			want: 200,
@@ -61,6 +69,7 @@ func TestCreateTagServices_Create(t *testing.T) {
 				authData:  "invalid",
 				decoder:   mockSuccessJsonDecoder,
 				validator: mockSuccessRequestValidator,
+				tagRepo:   mockSuccessTagDetailer,
 				repo:      mockSuccessTagCreator,
 			},
 			want: 400,
@@ -71,6 +80,7 @@ func TestCreateTagServices_Create(t *testing.T) {
 				authData:  mockValidAuthData,
 				decoder:   mockFailedJsonDecoder,
 				validator: mockSuccessRequestValidator,
+				tagRepo:   mockSuccessTagDetailer,
 				repo:      mockSuccessTagCreator,
 			},
 			want: 400,
@@ -81,6 +91,7 @@ func TestCreateTagServices_Create(t *testing.T) {
 				authData:  mockValidAuthData,
 				decoder:   mockSuccessJsonDecoder,
 				validator: mockFailedRequestValidator,
+				tagRepo:   mockSuccessTagDetailer,
 				repo:      mockSuccessTagCreator,
 			},
 			want: 400,
@@ -91,14 +102,37 @@ func TestCreateTagServices_Create(t *testing.T) {
 				authData:  mockValidAuthData,
 				decoder:   mockSuccessJsonDecoder,
 				validator: mockSuccessRequestValidator,
+				tagRepo:   mockSuccessTagDetailer,
 				repo:      mockFailedTagCreator,
 			},
 			want: 500,
 		},
+		{
+			name: "Parent not found",
+			fields: fields{
+				authData:  mockValidAuthData,
+				decoder:   mockSuccessJsonDecoder,
+				validator: mockSuccessRequestValidator,
+				tagRepo:   mockSuccessTagDetailer,
+				repo:      mockOrphanTagCreator,
+			},
+			want: 404,
+		},
+		{
+			name: "Parent uuid not found",
+			fields: fields{
+				authData:  mockValidAuthData,
+				decoder:   mockBodyDecoder{body: `{"title": "go", "parent_uuid": "abc-456"}`},
+				validator: mockSuccessRequestValidator,
+				tagRepo:   mockFailedTagDetailer,
+				repo:      mockSuccessTagCreator,
+			},
+			want: 404,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			svc := NewCreateTagServices(tt.fields.authData, tt.fields.decoder, tt.fields.validator, tt.fields.repo)
+			svc := NewCreateTagServices(tt.fields.authData, tt.fields.decoder, tt.fields.validator, tt.fields.tagRepo, tt.fields.repo)
 			got, _ := svc.Create()
 			if got != tt.want {
 				t.Errorf("CreateTagServices.Create() got = %v, want %v", got, tt.want)
@@ -112,8 +146,8 @@ type mockTagLister struct {
 	e error
 }
 
-func (m mockTagLister) List(params map[string]interface{}) ([]models.TagListItem, error) {
-	return m.d, m.e
+func (m mockTagLister) List(q url.Values) ([]models.TagListItem, models.PageMeta, error) {
+	return m.d, models.PageMeta{Limit: 10}, m.e
 }
 
 var (
@@ -131,6 +165,10 @@ var (
 		d: []models.TagListItem{},
 		e: nil,
 	}
+	mockInvalidQueryTagLister = mockTagLister{
+		d: []models.TagListItem{},
+		e: queryspec.Error{Param: "orderField", Reason: "cannot sort"},
+	}
 )
 
 func TestListTagServices_List(t *testing.T) {
@@ -177,7 +215,7 @@ func TestListTagServices_List(t *testing.T) {
 			want: 200,
 		},
 		{
-			name: "Positive",
+			name: "Empty page",
 			fields: fields{
 				authData: mockValidAuthData,
 				repo:     mockEmptyTagLister,
@@ -185,7 +223,18 @@ func TestListTagServices_List(t *testing.T) {
 			args: args{
 				q: map[string][]string{"a": {"b"}},
 			},
-			want: 404,
+			want: 200,
+		},
+		{
+			name: "Invalid query",
+			fields: fields{
+				authData: mockValidAuthData,
+				repo:     mockInvalidQueryTagLister,
+			},
+			args: args{
+				q: map[string][]string{"orderField": {"a"}},
+			},
+			want: 400,
 		},
 	}
 	for _, tt := range tests {
@@ -225,7 +274,7 @@ func TestDetailTagServices_GetDetailByUUID(t *testing.T) {
 		repo     mockTagDetailer
 	}
 	type args struct {
-		id int64
+		uuid string
 	}
 	tests := []struct {
 		name   string
@@ -255,10 +304,738 @@ func TestDetailTagServices_GetDetailByUUID(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			svc := NewDetailTagServices(tt.fields.authData, tt.fields.repo)
-			got, _ := svc.GetDetailByUUID(tt.args.id)
+			got, _ := svc.GetDetailByUUID(tt.args.uuid)
 			if got != tt.want {
 				t.Errorf("DetailTagServices.GetDetailByUUID() got = %v, want %v", got, tt.want)
 			}
 		})
 	}
 }
+
+type mockTagRenamer struct {
+	d models.Tag
+	e error
+}
+
+func (m mockTagRenamer) Rename(id int64, title string) (models.Tag, error) {
+	return m.d, m.e
+}
+
+var (
+	mockSuccessTagRenamer = mockTagRenamer{
+		d: models.Tag{Title: "golang"},
+		e: nil,
+	}
+	mockTakenTagRenamer = mockTagRenamer{
+		d: models.Tag{},
+		e: models.ErrTagTitleTaken,
+	}
+	mockFailedTagRenamer = mockTagRenamer{
+		d: models.Tag{},
+		e: errors.New("error"),
+	}
+)
+
+func TestRenameTagServices_Rename(t *testing.T) {
+	type fields struct {
+		authData  any
+		decoder   JsonDecoder
+		validator mockRequestValidator
+		tagRepo   mockTagDetailer
+		repo      mockTagRenamer
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		want   int
+	}{
+		{
+			name: "Positive",
+			fields: fields{
+				authData:  mockValidAuthData,
+				decoder:   mockBodyDecoder{body: `{"title":" GoLang "}`},
+				validator: mockSuccessRequestValidator,
+				tagRepo:   mockSuccessTagDetailer,
+				repo:      mockSuccessTagRenamer,
+			},
+			want: 200,
+		},
+		{
+			name: "Failed to read authData",
+			fields: fields{
+				authData:  "invalid",
+				decoder:   mockBodyDecoder{body: `{"title":"golang"}`},
+				validator: mockSuccessRequestValidator,
+				tagRepo:   mockSuccessTagDetailer,
+				repo:      mockSuccessTagRenamer,
+			},
+			want: 400,
+		},
+		{
+			name: "Failed to decode json data",
+			fields: fields{
+				authData:  mockValidAuthData,
+				decoder:   mockFailedJsonDecoder,
+				validator: mockSuccessRequestValidator,
+				tagRepo:   mockSuccessTagDetailer,
+				repo:      mockSuccessTagRenamer,
+			},
+			want: 400,
+		},
+		{
+			name: "Failed to validate data",
+			fields: fields{
+				authData:  mockValidAuthData,
+				decoder:   mockBodyDecoder{body: `{"title":"golang"}`},
+				validator: mockFailedRequestValidator,
+				tagRepo:   mockSuccessTagDetailer,
+				repo:      mockSuccessTagRenamer,
+			},
+			want: 400,
+		},
+		{
+			name: "Blank title",
+			fields: fields{
+				authData:  mockValidAuthData,
+				decoder:   mockBodyDecoder{body: `{"title":"  "}`},
+				validator: mockSuccessRequestValidator,
+				tagRepo:   mockSuccessTagDetailer,
+				repo:      mockSuccessTagRenamer,
+			},
+			want: 400,
+		},
+		{
+			name: "Failed to get data",
+			fields: fields{
+				authData:  mockValidAuthData,
+				decoder:   mockBodyDecoder{body: `{"title":"golang"}`},
+				validator: mockSuccessRequestValidator,
+				tagRepo:   mockFailedTagDetailer,
+				repo:      mockSuccessTagRenamer,
+			},
+			want: 404,
+		},
+		{
+			name: "Title taken",
+			fields: fields{
+				authData:  mockValidAuthData,
+				decoder:   mockBodyDecoder{body: `{"title":"golang"}`},
+				validator: mockSuccessRequestValidator,
+				tagRepo:   mockSuccessTagDetailer,
+				repo:      mockTakenTagRenamer,
+			},
+			want: 409,
+		},
+		{
+			name: "Failed to save data",
+			fields: fields{
+				authData:  mockValidAuthData,
+				decoder:   mockBodyDecoder{body: `{"title":"golang"}`},
+				validator: mockSuccessRequestValidator,
+				tagRepo:   mockSuccessTagDetailer,
+				repo:      mockFailedTagRenamer,
+			},
+			want: 500,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := NewRenameTagServices(tt.fields.authData, tt.fields.decoder, tt.fields.validator, tt.fields.tagRepo, tt.fields.repo)
+			got, _ := svc.Rename("abc-123")
+			if got != tt.want {
+				t.Errorf("RenameTagServices.Rename() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+type mockTagMerger struct {
+	d models.Tag
+	e error
+}
+
+func (m mockTagMerger) Merge(id int64, targetID int64, actor models.Actor) (models.Tag, error) {
+	return m.d, m.e
+}
+
+var (
+	mockSuccessTagMerger = mockTagMerger{
+		d: models.Tag{Title: "golang"},
+		e: nil,
+	}
+	mockFailedTagMerger = mockTagMerger{
+		d: models.Tag{},
+		e: errors.New("error"),
+	}
+)
+
+func TestMergeTagServices_Merge(t *testing.T) {
+	type fields struct {
+		authData  any
+		decoder   JsonDecoder
+		validator mockRequestValidator
+		tagRepo   mockTagDetailer
+		repo      mockTagMerger
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		want   int
+	}{
+		{
+			name: "Positive",
+			fields: fields{
+				authData:  mockValidAuthData,
+				decoder:   mockBodyDecoder{body: `{"target_uuid":"abc-456"}`},
+				validator: mockSuccessRequestValidator,
+				tagRepo:   mockSuccessTagDetailer,
+				repo:      mockSuccessTagMerger,
+			},
+			want: 200,
+		},
+		{
+			name: "Failed to read authData",
+			fields: fields{
+				authData:  "invalid",
+				decoder:   mockBodyDecoder{body: `{"target_uuid":"abc-456"}`},
+				validator: mockSuccessRequestValidator,
+				tagRepo:   mockSuccessTagDetailer,
+				repo:      mockSuccessTagMerger,
+			},
+			want: 400,
+		},
+		{
+			name: "Failed to decode json data",
+			fields: fields{
+				authData:  mockValidAuthData,
+				decoder:   mockFailedJsonDecoder,
+				validator: mockSuccessRequestValidator,
+				tagRepo:   mockSuccessTagDetailer,
+				repo:      mockSuccessTagMerger,
+			},
+			want: 400,
+		},
+		{
+			name: "Failed to validate data",
+			fields: fields{
+				authData:  mockValidAuthData,
+				decoder:   mockBodyDecoder{body: `{}`},
+				validator: mockFailedRequestValidator,
+				tagRepo:   mockSuccessTagDetailer,
+				repo:      mockSuccessTagMerger,
+			},
+			want: 400,
+		},
+		{
+			name: "Merge into itself",
+			fields: fields{
+				authData:  mockValidAuthData,
+				decoder:   mockBodyDecoder{body: `{"target_uuid":"abc-123"}`},
+				validator: mockSuccessRequestValidator,
+				tagRepo:   mockSuccessTagDetailer,
+				repo:      mockSuccessTagMerger,
+			},
+			want: 400,
+		},
+		{
+			name: "Failed to get data",
+			fields: fields{
+				authData:  mockValidAuthData,
+				decoder:   mockBodyDecoder{body: `{"target_uuid":"abc-456"}`},
+				validator: mockSuccessRequestValidator,
+				tagRepo:   mockFailedTagDetailer,
+				repo:      mockSuccessTagMerger,
+			},
+			want: 404,
+		},
+		{
+			name: "Merged or deleted meanwhile",
+			fields: fields{
+				authData:  mockValidAuthData,
+				decoder:   mockBodyDecoder{body: `{"target_uuid":"abc-456"}`},
+				validator: mockSuccessRequestValidator,
+				tagRepo:   mockSuccessTagDetailer,
+				repo:      mockTagMerger{e: models.ErrTagMergeConflict},
+			},
+			want: 409,
+		},
+		{
+			name: "Failed to merge data",
+			fields: fields{
+				authData:  mockValidAuthData,
+				decoder:   mockBodyDecoder{body: `{"target_uuid":"abc-456"}`},
+				validator: mockSuccessRequestValidator,
+				tagRepo:   mockSuccessTagDetailer,
+				repo:      mockFailedTagMerger,
+			},
+			want: 500,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := NewMergeTagServices(tt.fields.authData, tt.fields.decoder, tt.fields.validator, tt.fields.tagRepo, tt.fields.repo)
+			got, _ := svc.Merge("abc-123")
+			if got != tt.want {
+				t.Errorf("MergeTagServices.Merge() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+type mockTagRemover struct {
+	e     error
+	actor *models.Actor
+}
+
+func (m mockTagRemover) Delete(id int64, force bool, actor models.Actor) error {
+	if m.actor != nil {
+		*m.actor = actor
+	}
+	return m.e
+}
+
+var (
+	mockUsedTagDetailer = mockTagDetailer{
+		d: models.TagDetail{UsageCount: 3},
+		e: nil,
+	}
+	mockSuccessTagRemover = mockTagRemover{
+		e: nil,
+	}
+	mockInUseTagRemover = mockTagRemover{
+		e: models.ErrTagInUse,
+	}
+	mockFailedTagRemover = mockTagRemover{
+		e: errors.New("error"),
+	}
+)
+
+func TestDeleteTagServices_Delete(t *testing.T) {
+	type fields struct {
+		authData any
+		tagRepo  mockTagDetailer
+		repo     mockTagRemover
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		force  bool
+		want   int
+	}{
+		{
+			name: "Positive",
+			fields: fields{
+				authData: mockValidAuthData,
+				tagRepo:  mockSuccessTagDetailer,
+				repo:     mockSuccessTagRemover,
+			},
+			want: 200,
+		},
+		{
+			name: "Forced delete of a used tag",
+			fields: fields{
+				authData: mockValidAuthData,
+				tagRepo:  mockUsedTagDetailer,
+				repo:     mockSuccessTagRemover,
+			},
+			force: true,
+			want:  200,
+		},
+		{
+			name: "Failed to read authData",
+			fields: fields{
+				authData: "invalid",
+				tagRepo:  mockSuccessTagDetailer,
+				repo:     mockSuccessTagRemover,
+			},
+			want: 400,
+		},
+		{
+			name: "Failed to get data",
+			fields: fields{
+				authData: mockValidAuthData,
+				tagRepo:  mockFailedTagDetailer,
+				repo:     mockSuccessTagRemover,
+			},
+			want: 404,
+		},
+		{
+			name: "Tag in use",
+			fields: fields{
+				authData: mockValidAuthData,
+				tagRepo:  mockUsedTagDetailer,
+				repo:     mockSuccessTagRemover,
+			},
+			want: 409,
+		},
+		{
+			name: "Tag used since it was read",
+			fields: fields{
+				authData: mockValidAuthData,
+				tagRepo:  mockSuccessTagDetailer,
+				repo:     mockInUseTagRemover,
+			},
+			want: 409,
+		},
+		{
+			name: "Failed to delete data",
+			fields: fields{
+				authData: mockValidAuthData,
+				tagRepo:  mockSuccessTagDetailer,
+				repo:     mockFailedTagRemover,
+			},
+			want: 500,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := NewDeleteTagServices(tt.fields.authData, tt.fields.tagRepo, tt.fields.repo)
+			got, _ := svc.Delete("abc-123", tt.force)
+			if got != tt.want {
+				t.Errorf("DeleteTagServices.Delete() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDeleteTagServices_Delete_RecordsActor(t *testing.T) {
+	var actor models.Actor
+	authData := mockValidAuthData
+	authData.RequestID = "req-1"
+	repo := mockTagRemover{actor: &actor}
+
+	svc := NewDeleteTagServices(authData, mockUsedTagDetailer, repo)
+	svc.Delete("abc-123", true)
+
+	want := models.Actor{ID: mockValidAuthData.ID, RequestID: "req-1"}
+	if actor != want {
+		t.Errorf("DeleteTagServices.Delete() actor = %+v, want %+v", actor, want)
+	}
+}
+
+type mockTagSuggester struct {
+	d     []models.TagSuggestion
+	e     error
+	q     *string
+	limit *int
+}
+
+func (m mockTagSuggester) Suggest(q string, limit int) ([]models.TagSuggestion, error) {
+	if m.q != nil {
+		*m.q = q
+	}
+	if m.limit != nil {
+		*m.limit = limit
+	}
+	return m.d, m.e
+}
+
+var (
+	mockSuccessTagSuggester = mockTagSuggester{
+		d: []models.TagSuggestion{{UUID: "abc-123", Title: "golang", UsageCount: 3, Similarity: 0.5}},
+		e: nil,
+	}
+	mockFailedTagSuggester = mockTagSuggester{
+		d: nil,
+		e: errors.New("error"),
+	}
+)
+
+func TestSuggestTagServices_Suggest(t *testing.T) {
+	type fields struct {
+		authData any
+		repo     mockTagSuggester
+	}
+	tests := []struct {
+		name      string
+		fields    fields
+		q         url.Values
+		want      int
+		wantQ     string
+		wantLimit int
+	}{
+		{
+			name: "Positive",
+			fields: fields{
+				authData: mockValidAuthData,
+				repo:     mockSuccessTagSuggester,
+			},
+			q:         url.Values{"q": {" Gol "}, "limit": {"5"}},
+			want:      200,
+			wantQ:     "gol",
+			wantLimit: 5,
+		},
+		{
+			name: "Default limit",
+			fields: fields{
+				authData: mockValidAuthData,
+				repo:     mockSuccessTagSuggester,
+			},
+			q:         url.Values{"q": {"gol"}},
+			want:      200,
+			wantQ:     "gol",
+			wantLimit: 10,
+		},
+		{
+			name: "Failed to read authData",
+			fields: fields{
+				authData: "invalid",
+				repo:     mockSuccessTagSuggester,
+			},
+			q:    url.Values{"q": {"gol"}},
+			want: 400,
+		},
+		{
+			name: "Empty query",
+			fields: fields{
+				authData: mockValidAuthData,
+				repo:     mockSuccessTagSuggester,
+			},
+			q:    url.Values{"q": {"  "}},
+			want: 400,
+		},
+		{
+			name: "Limit too big",
+			fields: fields{
+				authData: mockValidAuthData,
+				repo:     mockSuccessTagSuggester,
+			},
+			q:    url.Values{"q": {"gol"}, "limit": {"51"}},
+			want: 400,
+		},
+		{
+			name: "Failed to get data",
+			fields: fields{
+				authData: mockValidAuthData,
+				repo:     mockFailedTagSuggester,
+			},
+			q:    url.Values{"q": {"gol"}},
+			want: 500,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var q string
+			var limit int
+			tt.fields.repo.q = &q
+			tt.fields.repo.limit = &limit
+
+			svc := NewSuggestTagServices(tt.fields.authData, tt.fields.repo)
+			got, _ := svc.Suggest(tt.q)
+			if got != tt.want {
+				t.Errorf("SuggestTagServices.Suggest() got = %v, want %v", got, tt.want)
+			}
+			if tt.wantQ != "" && (q != tt.wantQ || limit != tt.wantLimit) {
+				t.Errorf("SuggestTagServices.Suggest() q = %v, limit = %v, want %v, %v", q, limit, tt.wantQ, tt.wantLimit)
+			}
+		})
+	}
+}
+
+type mockTagTreeLister struct {
+	d []models.TagListItem
+	e error
+}
+
+func (m mockTagTreeLister) All() ([]models.TagListItem, error) {
+	return m.d, m.e
+}
+
+func TestTreeTagServices_Tree(t *testing.T) {
+	technology, programming := int64(1), int64(2)
+	tags := []models.TagListItem{
+		{ID: 4, UUID: "uuid-4", Title: "go", ParentID: &programming, UsageCount: 3},
+		{ID: 3, UUID: "uuid-3", Title: "news", UsageCount: 1},
+		{ID: 2, UUID: "uuid-2", Title: "programming", ParentID: &technology},
+		{ID: 5, UUID: "uuid-5", Title: "rust", ParentID: &programming},
+		{ID: 1, UUID: "uuid-1", Title: "technology"},
+	}
+	want := []models.TagNode{
+		{UUID: "uuid-3", Title: "news", UsageCount: 1, Children: []models.TagNode{}},
+		{UUID: "uuid-1", Title: "technology", Children: []models.TagNode{
+			{UUID: "uuid-2", Title: "programming", Children: []models.TagNode{
+				{UUID: "uuid-4", Title: "go", UsageCount: 3, Children: []models.TagNode{}},
+				{UUID: "uuid-5", Title: "rust", Children: []models.TagNode{}},
+			}},
+		}},
+	}
+
+	tests := []struct {
+		name     string
+		authData any
+		repo     mockTagTreeLister
+		want     int
+		wantData []models.TagNode
+	}{
+		{
+			name:     "Positive",
+			authData: mockValidAuthData,
+			repo:     mockTagTreeLister{d: tags},
+			want:     200,
+			wantData: want,
+		},
+		{
+			name:     "Empty",
+			authData: mockValidAuthData,
+			repo:     mockTagTreeLister{d: []models.TagListItem{}},
+			want:     200,
+			wantData: []models.TagNode{},
+		},
+		{
+			name:     "Failed to read authData",
+			authData: "invalid",
+			repo:     mockTagTreeLister{d: tags},
+			want:     400,
+		},
+		{
+			name:     "Failed to get data",
+			authData: mockValidAuthData,
+			repo:     mockTagTreeLister{e: errors.New("error")},
+			want:     500,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := NewTreeTagServices(tt.authData, tt.repo)
+			got, res := svc.Tree()
+			if got != tt.want {
+				t.Errorf("TreeTagServices.Tree() got = %v, want %v", got, tt.want)
+			}
+			if tt.wantData != nil && !reflect.DeepEqual(res.Data, tt.wantData) {
+				t.Errorf("TreeTagServices.Tree() data = %+v, want %+v", res.Data, tt.wantData)
+			}
+		})
+	}
+}
+
+type mockTagMover struct {
+	d models.Tag
+	e error
+}
+
+func (m mockTagMover) Move(id int64, parentID *int64) (models.Tag, error) {
+	return m.d, m.e
+}
+
+var (
+	mockSuccessTagMover = mockTagMover{
+		d: models.Tag{},
+		e: nil,
+	}
+	mockOrphanTagMover = mockTagMover{
+		d: models.Tag{},
+		e: models.ErrTagParentNotFound,
+	}
+	mockCycleTagMover = mockTagMover{
+		d: models.Tag{},
+		e: models.ErrTagCycle,
+	}
+	mockFailedTagMover = mockTagMover{
+		d: models.Tag{},
+		e: errors.New("error"),
+	}
+)
+
+func TestMoveTagServices_Move(t *testing.T) {
+	type fields struct {
+		authData any
+		decoder  JsonDecoder
+		tagRepo  mockTagDetailer
+		repo     mockTagMover
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		want   int
+	}{
+		{
+			name: "Positive",
+			fields: fields{
+				authData: mockValidAuthData,
+				decoder:  mockBodyDecoder{body: `{"parent_uuid":"abc-456"}`},
+				tagRepo:  mockSuccessTagDetailer,
+				repo:     mockSuccessTagMover,
+			},
+			want: 200,
+		},
+		{
+			name: "Move to root",
+			fields: fields{
+				authData: mockValidAuthData,
+				decoder:  mockBodyDecoder{body: `{"parent_uuid":null}`},
+				tagRepo:  mockSuccessTagDetailer,
+				repo:     mockSuccessTagMover,
+			},
+			want: 200,
+		},
+		{
+			name: "Failed to read authData",
+			fields: fields{
+				authData: "invalid",
+				decoder:  mockBodyDecoder{body: `{"parent_uuid":"abc-456"}`},
+				tagRepo:  mockSuccessTagDetailer,
+				repo:     mockSuccessTagMover,
+			},
+			want: 400,
+		},
+		{
+			name: "Failed to decode json data",
+			fields: fields{
+				authData: mockValidAuthData,
+				decoder:  mockFailedJsonDecoder,
+				tagRepo:  mockSuccessTagDetailer,
+				repo:     mockSuccessTagMover,
+			},
+			want: 400,
+		},
+		{
+			name: "Tag not found",
+			fields: fields{
+				authData: mockValidAuthData,
+				decoder:  mockBodyDecoder{body: `{"parent_uuid":"abc-456"}`},
+				tagRepo:  mockFailedTagDetailer,
+				repo:     mockSuccessTagMover,
+			},
+			want: 404,
+		},
+		{
+			name: "Parent not found",
+			fields: fields{
+				authData: mockValidAuthData,
+				decoder:  mockBodyDecoder{body: `{"parent_uuid":"abc-456"}`},
+				tagRepo:  mockSuccessTagDetailer,
+				repo:     mockOrphanTagMover,
+			},
+			want: 404,
+		},
+		{
+			name: "Cycle",
+			fields: fields{
+				authData: mockValidAuthData,
+				decoder:  mockBodyDecoder{body: `{"parent_uuid":"abc-456"}`},
+				tagRepo:  mockSuccessTagDetailer,
+				repo:     mockCycleTagMover,
+			},
+			want: 409,
+		},
+		{
+			name: "Failed to move",
+			fields: fields{
+				authData: mockValidAuthData,
+				decoder:  mockBodyDecoder{body: `{"parent_uuid":"abc-456"}`},
+				tagRepo:  mockSuccessTagDetailer,
+				repo:     mockFailedTagMover,
+			},
+			want: 500,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := NewMoveTagServices(tt.fields.authData, tt.fields.decoder, tt.fields.tagRepo, tt.fields.repo)
+			got, _ := svc.Move("abc-123")
+			if got != tt.want {
+				t.Errorf("MoveTagServices.Move() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,81 @@
+package services
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/herdiansc/go-cms/models"
+)
+
+// Limits of trending tags
+const (
+	defaultTrendingLimit = 20
+	maxTrendingLimit     = 100
+)
+
+// TagTrendingLister defines trending tag lister function
+type TagTrendingLister interface {
+	List(window string, limit int) ([]models.TrendingTag, error)
+}
+
+// TrendingTagServices defines trending tag service struct
+type TrendingTagServices struct {
+	authData any
+	windows  []models.TrendingWindow
+	repo     TagTrendingLister
+}
+
+// NewTrendingTagServices inits TrendingTagServices, windows are the computed windows, the first is the default
+func NewTrendingTagServices(ad any, windows []models.TrendingWindow, tl TagTrendingLister) TrendingTagServices {
+	return TrendingTagServices{
+		authData: ad,
+		windows:  windows,
+		repo:     tl,
+	}
+}
+
+// List performs action of listing the highest scored tags of the window param, limited by the limit param
+func (svc TrendingTagServices) List(q url.Values) (int, models.Response) {
+	_, ok := svc.authData.(models.VerifyData)
+	if !ok {
+		log.Printf("Failed to read authData\n")
+		return http.StatusBadRequest, models.Response{Message: "error", Data: nil}
+	}
+
+	window := q.Get("window")
+	if window == "" && len(svc.windows) > 0 {
+		window = svc.windows[0].Name
+	}
+	names := make([]string, len(svc.windows))
+	found := false
+	for i, w := range svc.windows {
+		names[i] = w.Name
+		found = found || w.Name == window
+	}
+	if !found {
+		log.Printf("Unknown trending window: %s\n", window)
+		return http.StatusBadRequest, models.Response{Message: "Bad Request", Data: fmt.Sprintf("window must be one of %s", strings.Join(names, ", "))}
+	}
+
+	limit := defaultTrendingLimit
+	if raw := q.Get("limit"); raw != "" {
+		v, err := strconv.Atoi(raw)
+		if err != nil || v < 1 || v > maxTrendingLimit {
+			log.Printf("Invalid trending limit: %s\n", raw)
+			return http.StatusBadRequest, models.Response{Message: "Bad Request", Data: fmt.Sprintf("limit must be between 1 and %d", maxTrendingLimit)}
+		}
+		limit = v
+	}
+
+	data, err := svc.repo.List(window, limit)
+	if err != nil {
+		log.Printf("Failed to get data: %+v\n", err.Error())
+		return http.StatusInternalServerError, models.Response{Message: "Failed to get data", Data: err.Error()}
+	}
+
+	return http.StatusOK, models.Response{Message: "ok", Data: data}
+}
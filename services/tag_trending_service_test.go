@@ -0,0 +1,133 @@
+package services
+
+import (
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/herdiansc/go-cms/models"
+)
+
+type mockTagTrendingLister struct {
+	d      []models.TrendingTag
+	e      error
+	window *string
+	limit  *int
+}
+
+func (m mockTagTrendingLister) List(window string, limit int) ([]models.TrendingTag, error) {
+	if m.window != nil {
+		*m.window = window
+	}
+	if m.limit != nil {
+		*m.limit = limit
+	}
+	return m.d, m.e
+}
+
+var (
+	mockTrendingWindows = []models.TrendingWindow{
+		{Name: "7d", Duration: 7 * 24 * time.Hour},
+		{Name: "1d", Duration: 24 * time.Hour},
+	}
+	mockSuccessTagTrendingLister = mockTagTrendingLister{
+		d: []models.TrendingTag{{UUID: "5b7f0a51-2d0e-4a8e-9b43-3c2a6f1e8d77", Title: "golang", Score: 2.5}},
+		e: nil,
+	}
+	mockFailedTagTrendingLister = mockTagTrendingLister{
+		d: nil,
+		e: errors.New("error"),
+	}
+)
+
+func TestTrendingTagServices_List(t *testing.T) {
+	type fields struct {
+		authData any
+		repo     mockTagTrendingLister
+	}
+	tests := []struct {
+		name       string
+		fields     fields
+		q          url.Values
+		want       int
+		wantWindow string
+		wantLimit  int
+	}{
+		{
+			name: "Positive",
+			fields: fields{
+				authData: mockValidAuthData,
+				repo:     mockSuccessTagTrendingLister,
+			},
+			q:          url.Values{"window": {"1d"}, "limit": {"5"}},
+			want:       200,
+			wantWindow: "1d",
+			wantLimit:  5,
+		},
+		{
+			name: "Defaults",
+			fields: fields{
+				authData: mockValidAuthData,
+				repo:     mockSuccessTagTrendingLister,
+			},
+			q:          url.Values{},
+			want:       200,
+			wantWindow: "7d",
+			wantLimit:  20,
+		},
+		{
+			name: "Failed to read authData",
+			fields: fields{
+				authData: "invalid",
+				repo:     mockSuccessTagTrendingLister,
+			},
+			q:    url.Values{},
+			want: 400,
+		},
+		{
+			name: "Unknown window",
+			fields: fields{
+				authData: mockValidAuthData,
+				repo:     mockSuccessTagTrendingLister,
+			},
+			q:    url.Values{"window": {"2d"}},
+			want: 400,
+		},
+		{
+			name: "Limit too big",
+			fields: fields{
+				authData: mockValidAuthData,
+				repo:     mockSuccessTagTrendingLister,
+			},
+			q:    url.Values{"limit": {"1000"}},
+			want: 400,
+		},
+		{
+			name: "Failed to get data",
+			fields: fields{
+				authData: mockValidAuthData,
+				repo:     mockFailedTagTrendingLister,
+			},
+			q:    url.Values{},
+			want: 500,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var window string
+			var limit int
+			tt.fields.repo.window = &window
+			tt.fields.repo.limit = &limit
+
+			svc := NewTrendingTagServices(tt.fields.authData, mockTrendingWindows, tt.fields.repo)
+			got, _ := svc.List(tt.q)
+			if got != tt.want {
+				t.Errorf("TrendingTagServices.List() got = %v, want %v", got, tt.want)
+			}
+			if tt.wantWindow != "" && (window != tt.wantWindow || limit != tt.wantLimit) {
+				t.Errorf("TrendingTagServices.List() window = %v, limit = %v, want %v, %v", window, limit, tt.wantWindow, tt.wantLimit)
+			}
+		})
+	}
+}
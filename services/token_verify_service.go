@@ -1,23 +1,44 @@
 package services
 
 import (
+	"fmt"
 	"log"
 	"net/http"
 	"strings"
 
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/herdiansc/go-cms/config"
 	"github.com/herdiansc/go-cms/models"
 )
 
+// RevocationChecker defines token revocation checker function
+type RevocationChecker interface {
+	IsRevoked(jti string) (bool, error)
+}
+
+// AuthUUIDFinder defines auth finder by uuid function
+type AuthUUIDFinder interface {
+	FindByUUID(uuid string) (models.Auth, error)
+}
+
 // TokenVerifyServices defines TokenVerifyServices struct
-type TokenVerifyServices struct{}
+type TokenVerifyServices struct {
+	keys     config.KeySet
+	repo     RevocationChecker
+	authRepo AuthUUIDFinder
+}
 
 // NewTokenVerifyServices inits TokenVerifyServices
-func NewTokenVerifyServices() TokenVerifyServices {
-	return TokenVerifyServices{}
+func NewTokenVerifyServices(ks config.KeySet, rc RevocationChecker, af AuthUUIDFinder) TokenVerifyServices {
+	return TokenVerifyServices{
+		keys:     ks,
+		repo:     rc,
+		authRepo: af,
+	}
 }
 
-// Verify verifies token
+// Verify verifies token. Tokens carry the uuid of the user, its internal id and current role are looked up,
+// so a role change applies at once instead of when the token expires.
 func (svc TokenVerifyServices) Verify(authHeader string) (int, models.Response) {
 	log.Printf("authHeader: %+v\n", authHeader)
 	authHeaders := strings.Split(authHeader, " ")
@@ -25,8 +46,16 @@ func (svc TokenVerifyServices) Verify(authHeader string) (int, models.Response)
 		return http.StatusBadRequest, models.Response{Message: "Invalid token", Data: nil}
 	}
 	token, err := jwt.Parse(authHeaders[1], func(token *jwt.Token) (interface{}, error) {
-		return secretKey, nil
-	})
+		kid, _ := token.Header["kid"].(string)
+		key, ok := svc.keys.Lookup(kid)
+		if !ok {
+			return nil, fmt.Errorf("unknown kid %q", kid)
+		}
+		if token.Method.Alg() != key.Method.Alg() {
+			return nil, fmt.Errorf("unexpected algorithm %q for kid %q", token.Method.Alg(), kid)
+		}
+		return key.VerifyKey, nil
+	}, jwt.WithValidMethods(svc.keys.Algorithms()), jwt.WithExpirationRequired())
 
 	if err != nil {
 		log.Printf("Failed to parse token: %+v\n", err.Error())
@@ -34,9 +63,37 @@ func (svc TokenVerifyServices) Verify(authHeader string) (int, models.Response)
 	}
 
 	claims := token.Claims.(jwt.MapClaims)
+	uuid, _ := claims["uuid"].(string)
+	roleName, _ := claims["role"].(string)
+	jti, _ := claims["jti"].(string)
+	exp, _ := claims["exp"].(float64)
+	if jti == "" {
+		log.Printf("Token has no jti\n")
+		return http.StatusBadRequest, models.Response{Message: "Invalid token", Data: nil}
+	}
+
+	revoked, err := svc.repo.IsRevoked(jti)
+	if err != nil || revoked {
+		log.Printf("Token is revoked or revocation check failed: %+v\n", err)
+		return http.StatusUnauthorized, models.Response{Message: "Invalid token", Data: nil}
+	}
+
+	auth, err := svc.authRepo.FindByUUID(uuid)
+	if err != nil {
+		log.Printf("Failed to find user of token: %+v\n", err.Error())
+		return http.StatusUnauthorized, models.Response{Message: "Invalid token", Data: nil}
+	}
+	if roleName != auth.RoleName {
+		log.Printf("Role of user %s changed from %s to %s since the token was issued\n", auth.UUID, roleName, auth.RoleName)
+	}
+
 	responseData := models.VerifyData{
-		ID:       int64(claims["id"].(float64)),
-		Username: claims["username"].(string),
+		ID:        auth.ID,
+		UUID:      auth.UUID,
+		Username:  auth.Username,
+		RoleName:  auth.RoleName,
+		JTI:       jti,
+		ExpiresAt: int64(exp),
 	}
 
 	return http.StatusOK, models.Response{Message: "ok", Data: responseData}
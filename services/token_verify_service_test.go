@@ -1,25 +1,160 @@
 package services
 
 import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
 	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/herdiansc/go-cms/config"
+	"github.com/herdiansc/go-cms/models"
+)
+
+type mockRevocationChecker struct {
+	r bool
+	e error
+}
+
+func (m mockRevocationChecker) IsRevoked(jti string) (bool, error) {
+	return m.r, m.e
+}
+
+type mockAuthUUIDFinder struct {
+	d models.Auth
+	e error
+}
+
+func (m mockAuthUUIDFinder) FindByUUID(uuid string) (models.Auth, error) {
+	return m.d, m.e
+}
+
+var (
+	mockKeySet            = newMockKeySet("hs", "HS256", []byte("0123456789abcdef0123456789abcdef"))
+	mockNotRevokedChecker = mockRevocationChecker{
+		r: false,
+		e: nil,
+	}
+	mockRevokedChecker = mockRevocationChecker{
+		r: true,
+		e: nil,
+	}
+	mockFailedRevocationChecker = mockRevocationChecker{
+		r: false,
+		e: errors.New("error"),
+	}
+	mockSuccessAuthUUIDFinder = mockAuthUUIDFinder{
+		d: models.Auth{Base: models.Base{ID: 1}, UUID: "abc-123", Username: "test"},
+		e: nil,
+	}
+	mockFailedAuthUUIDFinder = mockAuthUUIDFinder{
+		d: models.Auth{},
+		e: errors.New("error"),
+	}
 )
 
+func newMockKeySet(kid, alg string, data []byte) config.KeySet {
+	key, err := config.ParseSigningKey(kid, alg, data)
+	if err != nil {
+		panic(err)
+	}
+	ks, err := config.NewKeySet(kid, key)
+	if err != nil {
+		panic(err)
+	}
+	return ks
+}
+
+func mockPrivateKeyPEM(key any) []byte {
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		panic(err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+}
+
 func TestTokenVerifyServices_Verify(t *testing.T) {
+	token, err := signAccessToken(jwt.NewWithClaims, mockKeySet, models.Auth{Base: models.Base{ID: 1}, UUID: "abc-123", Username: "test"})
+	if err != nil {
+		t.Fatalf("Failed to sign token: %+v", err)
+	}
+	noJtiToken := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"uuid":     "abc-123",
+		"username": "test",
+		"exp":      9999999999,
+	})
+	noJtiToken.Header["kid"] = mockKeySet.Active.ID
+	noJtiTokenString, _ := noJtiToken.SignedString(mockKeySet.Active.SignKey)
+	otherKeySet := newMockKeySet("other", "HS256", []byte("fedcba9876543210fedcba9876543210"))
+	otherToken, _ := signAccessToken(jwt.NewWithClaims, otherKeySet, models.Auth{Base: models.Base{ID: 1}, UUID: "abc-123", Username: "test"})
+
 	cases := []struct {
-		name   string
-		header string
-		want   int
+		name     string
+		header   string
+		repo     mockRevocationChecker
+		authRepo mockAuthUUIDFinder
+		want     int
 	}{
 		{
-			name:   "Positive",
-			header: "Bearer invalid",
-			want:   400,
+			name:     "Positive",
+			header:   fmt.Sprintf("Bearer %s", token),
+			repo:     mockNotRevokedChecker,
+			authRepo: mockSuccessAuthUUIDFinder,
+			want:     200,
+		},
+		{
+			name:     "Negative: Invalid token",
+			header:   "Bearer invalid",
+			repo:     mockNotRevokedChecker,
+			authRepo: mockSuccessAuthUUIDFinder,
+			want:     400,
+		},
+		{
+			name:     "Negative: Token without jti",
+			header:   fmt.Sprintf("Bearer %s", noJtiTokenString),
+			repo:     mockNotRevokedChecker,
+			authRepo: mockSuccessAuthUUIDFinder,
+			want:     400,
+		},
+		{
+			name:     "Negative: Unknown kid",
+			header:   fmt.Sprintf("Bearer %s", otherToken),
+			repo:     mockNotRevokedChecker,
+			authRepo: mockSuccessAuthUUIDFinder,
+			want:     400,
+		},
+		{
+			name:     "Negative: Revoked token",
+			header:   fmt.Sprintf("Bearer %s", token),
+			repo:     mockRevokedChecker,
+			authRepo: mockSuccessAuthUUIDFinder,
+			want:     401,
+		},
+		{
+			name:     "Negative: Failed to check revocation",
+			header:   fmt.Sprintf("Bearer %s", token),
+			repo:     mockFailedRevocationChecker,
+			authRepo: mockSuccessAuthUUIDFinder,
+			want:     401,
+		},
+		{
+			name:     "Negative: Unknown user",
+			header:   fmt.Sprintf("Bearer %s", token),
+			repo:     mockNotRevokedChecker,
+			authRepo: mockFailedAuthUUIDFinder,
+			want:     401,
 		},
 	}
 
 	for _, tt := range cases {
 		t.Run(tt.name, func(t *testing.T) {
-			svc := NewTokenVerifyServices()
+			svc := NewTokenVerifyServices(mockKeySet, tt.repo, tt.authRepo)
 			code, _ := svc.Verify(tt.header)
 			if code != tt.want {
 				t.Errorf("Expected resp to be %q but it was %q", tt.want, code)
@@ -27,3 +162,65 @@ func TestTokenVerifyServices_Verify(t *testing.T) {
 		})
 	}
 }
+
+func TestTokenVerifyServices_VerifyAsymmetric(t *testing.T) {
+	rsaKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	ecKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	_, edKey, _ := ed25519.GenerateKey(rand.Reader)
+
+	cases := []struct {
+		name string
+		alg  string
+		key  any
+	}{
+		{name: "RS256", alg: "RS256", key: rsaKey},
+		{name: "ES256", alg: "ES256", key: ecKey},
+		{name: "EdDSA", alg: "EdDSA", key: edKey},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			keys := newMockKeySet(tt.name, tt.alg, mockPrivateKeyPEM(tt.key))
+			token, err := signAccessToken(jwt.NewWithClaims, keys, models.Auth{Base: models.Base{ID: 1}, UUID: "abc-123", Username: "test"})
+			if err != nil {
+				t.Fatalf("Failed to sign token: %+v", err)
+			}
+
+			code, _ := NewTokenVerifyServices(keys, mockNotRevokedChecker, mockSuccessAuthUUIDFinder).Verify(fmt.Sprintf("Bearer %s", token))
+			if code != 200 {
+				t.Errorf("Expected resp to be %q but it was %q", 200, code)
+			}
+			if jwks := keys.JWKS(); len(jwks.Keys) != 1 || jwks.Keys[0].Kid != tt.name {
+				t.Errorf("Expected one published key with kid %s but got %+v", tt.name, jwks)
+			}
+		})
+	}
+}
+
+func TestTokenVerifyServices_VerifyRotatedKey(t *testing.T) {
+	oldKey, _ := config.ParseSigningKey("old", "HS256", []byte("0123456789abcdef0123456789abcdef"))
+	newKey, _ := config.ParseSigningKey("new", "HS256", []byte("fedcba9876543210fedcba9876543210"))
+	oldKeySet, _ := config.NewKeySet("old", oldKey)
+	rotatedKeySet, _ := config.NewKeySet("new", newKey, oldKey)
+
+	token, _ := signAccessToken(jwt.NewWithClaims, oldKeySet, models.Auth{Base: models.Base{ID: 1}, UUID: "abc-123", Username: "test"})
+	code, _ := NewTokenVerifyServices(rotatedKeySet, mockNotRevokedChecker, mockSuccessAuthUUIDFinder).Verify(fmt.Sprintf("Bearer %s", token))
+	if code != 200 {
+		t.Errorf("Expected resp to be %q but it was %q", 200, code)
+	}
+}
+
+func TestTokenVerifyServices_VerifyChangedRole(t *testing.T) {
+	token, _ := signAccessToken(jwt.NewWithClaims, mockKeySet, models.Auth{Base: models.Base{ID: 1}, UUID: "abc-123", Username: "test", RoleName: "ADMIN"})
+	authRepo := mockAuthUUIDFinder{
+		d: models.Auth{Base: models.Base{ID: 1}, UUID: "abc-123", Username: "test", RoleName: "WRITER"},
+	}
+
+	code, res := NewTokenVerifyServices(mockKeySet, mockNotRevokedChecker, authRepo).Verify(fmt.Sprintf("Bearer %s", token))
+	if code != 200 {
+		t.Fatalf("Expected resp to be %q but it was %q", 200, code)
+	}
+	if data := res.Data.(models.VerifyData); data.RoleName != "WRITER" {
+		t.Errorf("Expected role to be %q but it was %q", "WRITER", data.RoleName)
+	}
+}
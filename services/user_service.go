@@ -0,0 +1,74 @@
+package services
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/herdiansc/go-cms/models"
+)
+
+// RoleFinder defines role finder function
+type RoleFinder interface {
+	FindByName(name string) (models.Role, error)
+}
+
+// AuthRoleUpdater defines auth role updater function
+type AuthRoleUpdater interface {
+	UpdateRole(uuid string, roleName string) (models.Auth, error)
+}
+
+// ChangeRoleServices defines change role service struct
+type ChangeRoleServices struct {
+	authData  any
+	decoder   JsonDecoder
+	validator RequestValidator
+	roleRepo  RoleFinder
+	repo      AuthRoleUpdater
+}
+
+// NewChangeRoleServices inits ChangeRoleServices
+func NewChangeRoleServices(ad any, jd JsonDecoder, rv RequestValidator, rf RoleFinder, au AuthRoleUpdater) ChangeRoleServices {
+	return ChangeRoleServices{
+		authData:  ad,
+		decoder:   jd,
+		validator: rv,
+		roleRepo:  rf,
+		repo:      au,
+	}
+}
+
+// ChangeRole performs action of changing role of a user
+func (svc ChangeRoleServices) ChangeRole(uuid string) (int, models.Response) {
+	_, ok := svc.authData.(models.VerifyData)
+	if !ok {
+		log.Printf("Failed to read authData\n")
+		return http.StatusBadRequest, models.Response{Message: "error", Data: nil}
+	}
+
+	var data models.ChangeRoleRequest
+	err := svc.decoder.Decode(&data)
+	if err != nil {
+		log.Printf("Failed to decode json data: %+v\n", err.Error())
+		return http.StatusBadRequest, models.Response{Message: "Bad Request", Data: err.Error()}
+	}
+
+	err = svc.validator.Struct(data)
+	if err != nil {
+		log.Printf("Failed to validate data: %+v\n", err.Error())
+		return http.StatusBadRequest, models.Response{Message: "Bad Request", Data: err.Error()}
+	}
+
+	role, err := svc.roleRepo.FindByName(data.Role)
+	if err != nil {
+		log.Printf("Failed to get role: %+v\n", err.Error())
+		return http.StatusBadRequest, models.Response{Message: "Bad Request", Data: "unknown role " + data.Role}
+	}
+
+	auth, err := svc.repo.UpdateRole(uuid, role.Name)
+	if err != nil {
+		log.Printf("Failed to save data: %+v\n", err.Error())
+		return http.StatusNotFound, models.Response{Message: "Failed to change role", Data: err.Error()}
+	}
+
+	return http.StatusOK, models.Response{Message: "ok", Data: auth.ProfileResponse()}
+}
@@ -0,0 +1,142 @@
+package services
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/herdiansc/go-cms/models"
+)
+
+type mockRoleFinder struct {
+	d models.Role
+	e error
+}
+
+func (m mockRoleFinder) FindByName(name string) (models.Role, error) {
+	return m.d, m.e
+}
+
+type mockAuthRoleUpdater struct {
+	d models.Auth
+	e error
+}
+
+func (m mockAuthRoleUpdater) UpdateRole(uuid string, roleName string) (models.Auth, error) {
+	return m.d, m.e
+}
+
+var (
+	mockSuccessRoleFinder = mockRoleFinder{
+		d: models.Role{Name: models.RoleEditor},
+		e: nil,
+	}
+	mockFailedRoleFinder = mockRoleFinder{
+		d: models.Role{},
+		e: errors.New("error"),
+	}
+	mockSuccessAuthRoleUpdater = mockAuthRoleUpdater{
+		d: models.Auth{},
+		e: nil,
+	}
+	mockFailedAuthRoleUpdater = mockAuthRoleUpdater{
+		d: models.Auth{},
+		e: errors.New("error"),
+	}
+)
+
+func TestChangeRoleServices_ChangeRole(t *testing.T) {
+	type fields struct {
+		authData  any
+		decoder   mockJsonDecoder
+		validator mockRequestValidator
+		roleRepo  mockRoleFinder
+		repo      mockAuthRoleUpdater
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		want   int
+	}{
+		{
+			name: "Positive",
+			fields: fields{
+				authData:  mockValidAuthData,
+				decoder:   mockSuccessJsonDecoder,
+				validator: mockSuccessRequestValidator,
+				roleRepo:  mockSuccessRoleFinder,
+				repo:      mockSuccessAuthRoleUpdater,
+			},
+			want: 200,
+		},
+		{
+			name: "Failed to read authData",
+			fields: fields{
+				authData:  "invalid",
+				decoder:   mockSuccessJsonDecoder,
+				validator: mockSuccessRequestValidator,
+				roleRepo:  mockSuccessRoleFinder,
+				repo:      mockSuccessAuthRoleUpdater,
+			},
+			want: 400,
+		},
+		{
+			name: "Failed to decode json data",
+			fields: fields{
+				authData:  mockValidAuthData,
+				decoder:   mockFailedJsonDecoder,
+				validator: mockSuccessRequestValidator,
+				roleRepo:  mockSuccessRoleFinder,
+				repo:      mockSuccessAuthRoleUpdater,
+			},
+			want: 400,
+		},
+		{
+			name: "Failed to validate data",
+			fields: fields{
+				authData:  mockValidAuthData,
+				decoder:   mockSuccessJsonDecoder,
+				validator: mockFailedRequestValidator,
+				roleRepo:  mockSuccessRoleFinder,
+				repo:      mockSuccessAuthRoleUpdater,
+			},
+			want: 400,
+		},
+		{
+			name: "Unknown role",
+			fields: fields{
+				authData:  mockValidAuthData,
+				decoder:   mockSuccessJsonDecoder,
+				validator: mockSuccessRequestValidator,
+				roleRepo:  mockFailedRoleFinder,
+				repo:      mockSuccessAuthRoleUpdater,
+			},
+			want: 400,
+		},
+		{
+			name: "Failed to save data",
+			fields: fields{
+				authData:  mockValidAuthData,
+				decoder:   mockSuccessJsonDecoder,
+				validator: mockSuccessRequestValidator,
+				roleRepo:  mockSuccessRoleFinder,
+				repo:      mockFailedAuthRoleUpdater,
+			},
+			want: 404,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := NewChangeRoleServices(
+				tt.fields.authData,
+				tt.fields.decoder,
+				tt.fields.validator,
+				tt.fields.roleRepo,
+				tt.fields.repo,
+			)
+			got, _ := svc.ChangeRole("abc-123")
+			if got != tt.want {
+				t.Errorf("ChangeRoleServices.ChangeRole() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}